@@ -0,0 +1,120 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"encoding/binary"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const (
+	ErrUnsupportedVersion errors.Msg = "unsupported binary format version"
+	ErrInvalidBinary      errors.Msg = "invalid binary data"
+)
+
+// binaryVersion is the version of the binary format of Values. It must be
+// incremented whenever the format changes, older versions must remain
+// decodable.
+const binaryVersion byte = 1
+
+var (
+	_ encoding.BinaryMarshaler   = (Values)(nil)
+	_ encoding.BinaryUnmarshaler = (*Values)(nil)
+)
+
+// MarshalBinary encodes Values in a compact binary form. The encoded data
+// starts with a format version, followed by the number of keys and each key
+// and Value prefixed with their length. Keys are written in sorted order, so
+// equal Values result in equal data. As Values implements
+// encoding.BinaryMarshaler, it is also used by encoding/gob.
+func (vs Values) MarshalBinary() ([]byte, error) {
+	size := 1 + binary.MaxVarintLen64
+	for k, v := range vs {
+		size += len(k) + len(v) + 2*binary.MaxVarintLen64
+	}
+
+	buf := make([]byte, 0, size)
+	buf = append(buf, binaryVersion)
+	buf = binary.AppendUvarint(buf, uint64(len(vs)))
+	for _, k := range vs.Keys() {
+		buf = binary.AppendUvarint(buf, uint64(len(k)))
+		buf = append(buf, k...)
+		buf = binary.AppendUvarint(buf, uint64(len(vs[k])))
+		buf = append(buf, vs[k]...)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary decodes data created by MarshalBinary and replaces the
+// contents of Values with it.
+func (vs *Values) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return errors.New(ErrInvalidBinary)
+	}
+	if data[0] != binaryVersion {
+		return errors.Newf("%w `%d`", ErrUnsupportedVersion, data[0])
+	}
+
+	r := binaryReader{data: data[1:]}
+	n := r.uvarint()
+	if r.err != nil {
+		return r.err
+	}
+	if n > uint64(len(r.data)/2) {
+		// each key and value requires at least one byte for its length
+		return errors.New(ErrInvalidBinary)
+	}
+
+	res := make(Values, n)
+	for i := uint64(0); i < n && r.err == nil; i++ {
+		k := r.string()
+		res[k] = Value(r.string())
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.data) != 0 {
+		return errors.New(ErrInvalidBinary)
+	}
+
+	*vs = res
+	return nil
+}
+
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+
+	x, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errors.New(ErrInvalidBinary)
+		return 0
+	}
+	r.data = r.data[n:]
+	return x
+}
+
+func (r *binaryReader) string() string {
+	n := r.uvarint()
+	if r.err != nil {
+		return ""
+	}
+	if n > uint64(len(r.data)) {
+		r.err = errors.New(ErrInvalidBinary)
+		return ""
+	}
+
+	s := string(r.data[:n])
+	r.data = r.data[n:]
+	return s
+}
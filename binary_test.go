@@ -0,0 +1,79 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValues_MarshalBinary(t *testing.T) {
+	tests := map[string]Values{
+		"empty":  {},
+		"single": {"foo": "bar"},
+		"multi":  {"foo": "bar", "empty": "", "list": "a,b,c", "multi\nline": "x\ny"},
+	}
+	for name, vs := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := vs.MarshalBinary()
+			assert.NoError(t, err)
+			assert.Equal(t, binaryVersion, data[0])
+
+			var have Values
+			assert.NoError(t, have.UnmarshalBinary(data))
+			assert.Equal(t, vs, have)
+		})
+	}
+
+	t.Run("deterministic", func(t *testing.T) {
+		vs := Values{"a": "1", "b": "2", "c": "3"}
+		want, _ := vs.MarshalBinary()
+		for i := 0; i < 10; i++ {
+			have, _ := vs.MarshalBinary()
+			assert.Equal(t, want, have)
+		}
+	})
+}
+
+func TestValues_UnmarshalBinary(t *testing.T) {
+	valid, _ := Values{"foo": "bar"}.MarshalBinary()
+
+	tests := map[string]struct {
+		data    []byte
+		wantErr error
+	}{
+		"empty":         {data: nil, wantErr: ErrInvalidBinary},
+		"version":       {data: []byte{99, 0}, wantErr: ErrUnsupportedVersion},
+		"truncated":     {data: valid[:len(valid)-1], wantErr: ErrInvalidBinary},
+		"trailing":      {data: append(append([]byte{}, valid...), 0), wantErr: ErrInvalidBinary},
+		"invalid count": {data: []byte{binaryVersion, 0xff, 0xff, 0xff, 0x0f}, wantErr: ErrInvalidBinary},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have := Values{"keep": "me"}
+			assert.ErrorIs(t, have.UnmarshalBinary(tc.data), tc.wantErr)
+			assert.Equal(t, Values{"keep": "me"}, have)
+		})
+	}
+}
+
+func TestValues_gob(t *testing.T) {
+	type snapshot struct {
+		Name   string
+		Values Values
+	}
+
+	want := snapshot{Name: "config", Values: Values{"foo": "bar", "qux": "xoo"}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, gob.NewEncoder(&buf).Encode(want))
+
+	var have snapshot
+	assert.NoError(t, gob.NewDecoder(&buf).Decode(&have))
+	assert.Equal(t, want, have)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import "reflect"
+
+// Canonicalize returns the canonical form of val for typ, using the global
+// Unmarshaler and Marshaler. See Unmarshaler.Canonicalize for additional
+// details.
+func Canonicalize(val Value, typ reflect.Type) (Value, error) {
+	return unmarshaler.Canonicalize(val, typ)
+}
+
+// Canonicalize unmarshals val to a new value of type typ and marshals it
+// again, using Options.Marshaler or a Marshaler with the same Options as
+// Unmarshaler when it is not set. The result is the canonical form of val, so
+// equivalent values (e.g. "1h0m0s" and "60m" for a time.Duration, or "0x0A"
+// and "10" for an int) are equal as strings. An empty Value is returned as is.
+func (u *Unmarshaler) Canonicalize(val Value, typ reflect.Type) (Value, error) {
+	if val.IsEmpty() {
+		return val, nil
+	}
+
+	ptr := reflect.New(typ)
+	if err := u.Unmarshal(val, ptr); err != nil {
+		return "", err
+	}
+
+	return u.marshaler().Marshal(ptr.Elem())
+}
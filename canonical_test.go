@@ -0,0 +1,66 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := map[string]struct {
+		input Value
+		typ   reflect.Type
+		want  Value
+	}{
+		"duration": {input: "60m", typ: durationType, want: "1h0m0s"},
+		"hex int":  {input: "0x0A", typ: reflect.TypeOf(0), want: "10"},
+		"bool":     {input: "TRUE", typ: reflect.TypeOf(false), want: "true"},
+		"float":    {input: "1.50", typ: reflect.TypeOf(float64(0)), want: "1.5"},
+		"slice":    {input: "0x1,02,3", typ: reflect.TypeOf([]uint{}), want: "1,2,3"},
+		"map":      {input: "b=2,a=1", typ: reflect.TypeOf(map[string]int{}), want: "a=1,b=2"},
+		"month":    {input: "jan", typ: reflect.TypeOf(time.January), want: "January"},
+		"uuid": {
+			input: "{6BA7B810-9DAD-11D1-80B4-00C04FD430C8}",
+			typ:   reflect.TypeOf(UUID{}),
+			want:  "6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+		},
+		"empty": {input: "", typ: durationType, want: ""},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, haveErr := Canonicalize(tc.input, tc.typ)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, tc.want, have)
+		})
+	}
+
+	t.Run("local funcs", func(t *testing.T) {
+		type level int
+		typ := reflect.TypeOf(level(0))
+
+		var m Marshaler
+		m.Register(typ, func(v any) (string, error) {
+			return strings.Repeat("*", int(v.(level))), nil
+		})
+		u := NewUnmarshaler(WithMarshaler(&m))
+		u.Register(typ, func(val Value, dest any) error {
+			*dest.(*level) = level(len(strings.TrimSpace(val.String())))
+			return nil
+		})
+
+		have, haveErr := u.Canonicalize(" *** ", typ)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, Value("***"), have)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, haveErr := Canonicalize("nope", durationType)
+		assert.ErrorIs(t, haveErr, ErrParseFailure)
+	})
+}
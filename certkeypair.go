@@ -0,0 +1,133 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"crypto/tls"
+	"encoding"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*CertKeyPair)(nil)
+	_ encoding.TextUnmarshaler = (*CertKeyPair)(nil)
+	_ encoding.TextUnmarshaler = (*VerifiedCertKeyPair)(nil)
+)
+
+// CertKeyPair contains the paths to a PEM encoded certificate file and its
+// private key file. Its raw Value has the format "cert.pem:key.pem". Windows
+// paths with a drive letter are supported as well, e.g.
+// "C:\certs\tls.crt:C:\certs\tls.key".
+// Unmarshaling a CertKeyPair does not touch the filesystem, use
+// VerifiedCertKeyPair to load and verify the files on unmarshal.
+type CertKeyPair struct {
+	CertFile string
+	KeyFile  string
+}
+
+// ParseCertKeyPair parses str as a CertKeyPair. The paths are separated by the
+// last ":" which is not part of a drive letter.
+func ParseCertKeyPair(str string) (CertKeyPair, error) {
+	cert, key, ok := cutCertKey(str)
+	if !ok || cert == "" || key == "" {
+		return CertKeyPair{}, errors.Wrap(
+			errors.Newf("expected format `cert:key`, got `%s`", str),
+			ErrParseFailure,
+		)
+	}
+	return CertKeyPair{CertFile: cert, KeyFile: key}, nil
+}
+
+// cutCertKey cuts str around the last ":" which is not part of a drive letter,
+// e.g. the ":" of "C:\" or "C:/".
+func cutCertKey(str string) (cert, key string, found bool) {
+	for i := len(str) - 1; i >= 0; i-- {
+		if str[i] == ':' && !isDriveColon(str, i) {
+			return str[:i], str[i+1:], true
+		}
+	}
+	return str, "", false
+}
+
+// isDriveColon indicates if the ":" at index i of str follows a drive letter
+// at the start of a path.
+func isDriveColon(str string, i int) bool {
+	if i < 1 || i+1 >= len(str) || (str[i+1] != '\\' && str[i+1] != '/') {
+		return false
+	}
+	if c := str[i-1] | 0x20; c < 'a' || c > 'z' {
+		return false
+	}
+	return i == 1 || str[i-2] == ':'
+}
+
+// Load reads and parses the certificate and private key files using
+// tls.LoadX509KeyPair.
+func (ck CertKeyPair) Load() (tls.Certificate, error) {
+	cert, err := tls.LoadX509KeyPair(ck.CertFile, ck.KeyFile)
+	if err != nil {
+		return cert, errors.Wrap(err, ErrValidationFailure)
+	}
+	return cert, nil
+}
+
+// String returns the CertKeyPair formatted as "cert:key", or an empty string
+// when CertKeyPair is the zero value.
+func (ck CertKeyPair) String() string {
+	if ck == (CertKeyPair{}) {
+		return ""
+	}
+	return ck.CertFile + ":" + ck.KeyFile
+}
+
+// MarshalText returns the CertKeyPair formatted as text.
+func (ck CertKeyPair) MarshalText() ([]byte, error) { return []byte(ck.String()), nil }
+
+// UnmarshalText parses text as a CertKeyPair. An empty text results in the zero
+// value.
+func (ck *CertKeyPair) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*ck = CertKeyPair{}
+		return nil
+	}
+
+	x, err := ParseCertKeyPair(string(text))
+	if err != nil {
+		return err
+	}
+	*ck = x
+	return nil
+}
+
+// VerifiedCertKeyPair is a CertKeyPair which loads its files when it is
+// unmarshaled. Unmarshaling fails with an ErrValidationFailure when the files
+// do not exist or do not contain a valid certificate and private key.
+type VerifiedCertKeyPair struct {
+	CertKeyPair
+	Certificate tls.Certificate
+}
+
+// UnmarshalText parses text as a CertKeyPair and loads its files. An empty text
+// results in the zero value.
+func (ck *VerifiedCertKeyPair) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*ck = VerifiedCertKeyPair{}
+		return nil
+	}
+
+	pair, err := ParseCertKeyPair(string(text))
+	if err != nil {
+		return err
+	}
+	cert, err := pair.Load()
+	if err != nil {
+		return err
+	}
+
+	ck.CertKeyPair = pair
+	ck.Certificate = cert
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeCertKeyPair(t *testing.T) CertKeyPair {
+	t.Helper()
+	must := func(err error) {
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	must(err)
+
+	tmpl := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	must(err)
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	must(err)
+
+	dir := t.TempDir()
+	res := CertKeyPair{
+		CertFile: filepath.Join(dir, "cert.pem"),
+		KeyFile:  filepath.Join(dir, "key.pem"),
+	}
+	must(os.WriteFile(res.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	must(os.WriteFile(res.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return res
+}
+
+func TestParseCertKeyPair(t *testing.T) {
+	have, haveErr := ParseCertKeyPair("cert.pem:key.pem")
+	assert.NoError(t, haveErr)
+	assert.Equal(t, CertKeyPair{CertFile: "cert.pem", KeyFile: "key.pem"}, have)
+	assert.Equal(t, Value("cert.pem:key.pem"), MustMarshal(have))
+
+	windows := map[string]CertKeyPair{
+		`C:\certs\tls.crt:C:\certs\tls.key`: {CertFile: `C:\certs\tls.crt`, KeyFile: `C:\certs\tls.key`},
+		`c:/certs/tls.crt:d:/tls.key`:       {CertFile: `c:/certs/tls.crt`, KeyFile: `d:/tls.key`},
+		`cert.pem:C:\tls.key`:               {CertFile: `cert.pem`, KeyFile: `C:\tls.key`},
+		`C:\tls.crt:key.pem`:                {CertFile: `C:\tls.crt`, KeyFile: `key.pem`},
+		`a:b`:                               {CertFile: `a`, KeyFile: `b`},
+	}
+	for input, want := range windows {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseCertKeyPair(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, want, have)
+			assert.Equal(t, input, have.String())
+		})
+	}
+
+	for _, input := range []string{"", "cert.pem", ":key.pem", "cert.pem:", `C:\tls.crt`} {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseCertKeyPair(input)
+			assert.ErrorIs(t, haveErr, ErrParseFailure)
+		})
+	}
+}
+
+func TestCertKeyPair_zero(t *testing.T) {
+	assert.Equal(t, Value(""), MustMarshal(CertKeyPair{}))
+
+	ck := CertKeyPair{CertFile: "cert.pem", KeyFile: "key.pem"}
+	assert.NoError(t, ck.UnmarshalText(nil))
+	assert.Equal(t, CertKeyPair{}, ck)
+	assert.NoError(t, Unmarshal(MustMarshal(CertKeyPair{}), &ck, WithEmptyMode(EmptyZero)))
+	assert.Equal(t, CertKeyPair{}, ck)
+
+	var vck VerifiedCertKeyPair
+	assert.NoError(t, vck.UnmarshalText(nil))
+	assert.Equal(t, VerifiedCertKeyPair{}, vck)
+}
+
+func TestVerifiedCertKeyPair(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		pair := writeCertKeyPair(t)
+
+		var have VerifiedCertKeyPair
+		assert.NoError(t, Unmarshal(Value(pair.String()), &have))
+		assert.Equal(t, pair, have.CertKeyPair)
+		assert.Len(t, have.Certificate.Certificate, 1)
+		assert.Equal(t, Value(pair.String()), MustMarshal(have))
+	})
+	t.Run("missing files", func(t *testing.T) {
+		var have VerifiedCertKeyPair
+		assert.ErrorIs(t, Unmarshal("cert.pem:key.pem", &have), ErrValidationFailure)
+		assert.Equal(t, VerifiedCertKeyPair{}, have)
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding"
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const (
+	ErrChecksumMismatch    errors.Msg = "checksum mismatch"
+	ErrUnknownChecksumAlgo errors.Msg = "unknown checksum algorithm"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Checksummed)(nil)
+	_ encoding.TextUnmarshaler = (*Checksummed)(nil)
+)
+
+// DefaultChecksumAlgorithm is the algorithm which is used by Checksummed when
+// none is set.
+const DefaultChecksumAlgorithm = "sha256"
+
+var checksumAlgorithms = map[string]func() hash.Hash{
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+}
+
+// Checksummed is a Value which is annotated with a checksum of its contents,
+// e.g. "sha256:<hex>=payload". The checksum is verified when unmarshaling, so
+// raw values which are distributed through untrusted channels can be checked
+// for their integrity. Supported algorithms are sha256, sha384 and sha512.
+// Note that a checksum protects against corruption but not against
+// tampering, as anyone can recompute it.
+type Checksummed struct {
+	// Algorithm is the name of the checksum algorithm. It defaults to
+	// DefaultChecksumAlgorithm.
+	Algorithm string
+	// Value is the verified payload.
+	Value Value
+}
+
+// ParseChecksummed parses str as a Checksummed and verifies its checksum.
+func ParseChecksummed(str string) (Checksummed, error) {
+	algo, rest, ok := strings.Cut(str, ":")
+	if !ok {
+		return Checksummed{}, errors.Wrap(errors.New("missing checksum algorithm"), ErrParseFailure)
+	}
+	sum, payload, ok := strings.Cut(rest, "=")
+	if !ok {
+		return Checksummed{}, errors.Wrap(errors.New("missing `=` after checksum"), ErrParseFailure)
+	}
+
+	want, err := hex.DecodeString(sum)
+	if err != nil {
+		return Checksummed{}, errors.Wrap(err, ErrParseFailure)
+	}
+
+	c := Checksummed{Algorithm: algo, Value: Value(payload)}
+	have, err := c.Sum()
+	if err != nil {
+		return Checksummed{}, err
+	}
+	if subtle.ConstantTimeCompare(have, want) != 1 {
+		return Checksummed{}, errors.Wrap(errors.New(ErrChecksumMismatch), ErrValidationFailure)
+	}
+	return c, nil
+}
+
+func (c Checksummed) algorithm() string {
+	if c.Algorithm == "" {
+		return DefaultChecksumAlgorithm
+	}
+	return c.Algorithm
+}
+
+// Sum returns the checksum of Value, using Algorithm.
+func (c Checksummed) Sum() ([]byte, error) {
+	fn, ok := checksumAlgorithms[c.algorithm()]
+	if !ok {
+		return nil, errors.Wrap(errors.Newf("%w `%s`", ErrUnknownChecksumAlgo, c.Algorithm), ErrParseFailure)
+	}
+
+	h := fn()
+	h.Write([]byte(c.Value))
+	return h.Sum(nil), nil
+}
+
+// MarshalText returns the Checksummed formatted as "algorithm:checksum=value".
+func (c Checksummed) MarshalText() ([]byte, error) {
+	sum, err := c.Sum()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(c.algorithm() + ":" + hex.EncodeToString(sum) + "=" + c.Value.String()), nil
+}
+
+// UnmarshalText parses text using ParseChecksummed.
+func (c *Checksummed) UnmarshalText(text []byte) error {
+	x, err := ParseChecksummed(string(text))
+	if err != nil {
+		return err
+	}
+	*c = x
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksummed(t *testing.T) {
+	const (
+		payload = "hello=world"
+		sha256  = "sha256:3d011e09502a84552a0f8ae112d024cc2c115597e3a577d5f49007902c221dc5=" + payload
+	)
+
+	t.Run("marshal", func(t *testing.T) {
+		have, err := Marshal(Checksummed{Value: payload})
+		assert.NoError(t, err)
+		assert.Equal(t, Value(sha256), have)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		for _, algo := range []string{"sha256", "sha384", "sha512"} {
+			text, err := Checksummed{Algorithm: algo, Value: payload}.MarshalText()
+			assert.NoError(t, err)
+
+			var have Checksummed
+			assert.NoError(t, Unmarshal(Value(text), &have))
+			assert.Equal(t, Checksummed{Algorithm: algo, Value: payload}, have)
+		}
+	})
+
+	tests := map[string]struct {
+		input   string
+		wantErr error
+	}{
+		"mismatch":          {input: sha256 + "!", wantErr: ErrChecksumMismatch},
+		"unknown algorithm": {input: "md5:abcd=x", wantErr: ErrUnknownChecksumAlgo},
+		"invalid hex":       {input: "sha256:xyz=x", wantErr: ErrParseFailure},
+		"missing algorithm": {input: "payload", wantErr: ErrParseFailure},
+		"missing payload":   {input: "sha256:abcd", wantErr: ErrParseFailure},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseChecksummed(tc.input)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+
+	_, err := Checksummed{Algorithm: "md5"}.MarshalText()
+	assert.ErrorIs(t, err, ErrUnknownChecksumAlgo)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultChunkSeparator is the separator between the key of a value and the
+// index of its chunks, e.g. "FOO_0".
+const DefaultChunkSeparator = "_"
+
+var _ OriginSource = (*Chunked)(nil)
+
+// Chunked is a Source which reassembles values which are split across
+// multiple keys, e.g. "FOO_0", "FOO_1", "FOO_2", into a single Value with key
+// "FOO". This is a common workaround for platforms which limit the length of
+// environment variables. Chunks are numbered from 0 and joined in order until
+// the first missing index. A key which exists in the underlying Source itself
+// takes precedence over its chunks.
+type Chunked struct {
+	src Source
+	sep string
+}
+
+// NewChunked returns a new Chunked which reassembles the chunks from Source
+// src, which are separated from their index by sep. An empty sep results in
+// DefaultChunkSeparator.
+func NewChunked(src Source, sep string) *Chunked {
+	if sep == "" {
+		sep = DefaultChunkSeparator
+	}
+	return &Chunked{src: src, sep: sep}
+}
+
+// Lookup returns the Value of key, either from the underlying Source or
+// reassembled from its chunks, and a boolean indicating if it exists.
+func (c *Chunked) Lookup(key string) (Value, bool) {
+	v, _, ok := c.LookupOrigin(key)
+	return v, ok
+}
+
+// LookupOrigin is like Lookup but also returns the Origin of the Value. The
+// Origin of a reassembled Value is the Origin of its first chunk.
+func (c *Chunked) LookupOrigin(key string) (Value, Origin, bool) {
+	if v, origin, ok := c.lookup(key); ok {
+		return v, origin, true
+	}
+
+	first, origin, ok := c.lookup(c.chunkKey(key, 0))
+	if !ok {
+		return "", Origin{}, false
+	}
+
+	var buf strings.Builder
+	buf.WriteString(first.String())
+	for i := 1; ; i++ {
+		v, _, ok := c.lookup(c.chunkKey(key, i))
+		if !ok {
+			break
+		}
+		buf.WriteString(v.String())
+	}
+	return Value(buf.String()), origin, true
+}
+
+// Keys returns all keys of the underlying Source in sorted order, where the
+// keys of reassembled chunks are replaced by the key of their Value.
+func (c *Chunked) Keys() []string {
+	keys := c.src.Keys()
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[k] = struct{}{}
+	}
+
+	for _, k := range keys {
+		key, ok := strings.CutSuffix(k, c.sep+"0")
+		if !ok {
+			continue
+		}
+		if _, exists := set[key]; exists {
+			// key takes precedence, its chunks are separate keys
+			continue
+		}
+		for i := 0; ; i++ {
+			chunk := c.chunkKey(key, i)
+			if _, exists := set[chunk]; !exists {
+				break
+			}
+			delete(set, chunk)
+		}
+		set[key] = struct{}{}
+	}
+
+	res := make([]string, 0, len(set))
+	for k := range set {
+		res = append(res, k)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (c *Chunked) chunkKey(key string, i int) string {
+	return key + c.sep + strconv.Itoa(i)
+}
+
+func (c *Chunked) lookup(key string) (Value, Origin, bool) {
+	if os, ok := c.src.(OriginSource); ok {
+		return os.LookupOrigin(key)
+	}
+	v, ok := c.src.Lookup(key)
+	return v, Origin{}, ok
+}
+
+// SplitChunks splits Value v into chunks of at most size bytes and returns
+// them as Values with keys in the form of key, sep and their index, which can
+// be reassembled using Chunked. An empty sep results in
+// DefaultChunkSeparator, a size of 0 results in a single chunk. Chunks are
+// not split within a multibyte UTF-8 character, which may result in smaller
+// chunks, unless size is smaller than the character itself.
+func SplitChunks(key string, v Value, size int, sep string) Values {
+	if sep == "" {
+		sep = DefaultChunkSeparator
+	}
+	if size <= 0 {
+		size = len(v) + 1
+	}
+
+	c := Chunked{sep: sep}
+	res := make(Values, len(v)/size+1)
+	for i := 0; ; i++ {
+		n := size
+		if n >= len(v) {
+			n = len(v)
+		} else {
+			// do not split a multibyte character
+			for n > 1 && !utf8.RuneStart(v[n]) {
+				n--
+			}
+		}
+
+		res[c.chunkKey(key, i)] = v[:n]
+		v = v[n:]
+		if len(v) == 0 {
+			break
+		}
+	}
+	return res
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunked(t *testing.T) {
+	src := NewChunked(Values{
+		"CERT_0": "-----BEGIN ",
+		"CERT_1": "CERTIFICATE",
+		"CERT_2": "-----",
+		"CERT_4": "orphan",
+		"PORT":   "8080",
+		"NAME":   "direct",
+		"NAME_0": "chunk",
+		"GAP_1":  "no first chunk",
+	}, "")
+
+	t.Run("lookup", func(t *testing.T) {
+		tests := map[string]struct {
+			key    string
+			want   Value
+			wantOk bool
+		}{
+			"chunks":           {key: "CERT", want: "-----BEGIN CERTIFICATE-----", wantOk: true},
+			"plain key":        {key: "PORT", want: "8080", wantOk: true},
+			"key precedence":   {key: "NAME", want: "direct", wantOk: true},
+			"chunk key":        {key: "CERT_1", want: "CERTIFICATE", wantOk: true},
+			"no first chunk":   {key: "GAP"},
+			"non-existing key": {key: "FOO"},
+		}
+		for name, tc := range tests {
+			t.Run(name, func(t *testing.T) {
+				have, ok := src.Lookup(tc.key)
+				assert.Equal(t, tc.wantOk, ok)
+				assert.Equal(t, tc.want, have)
+			})
+		}
+	})
+	t.Run("keys", func(t *testing.T) {
+		assert.Equal(t, []string{"CERT", "CERT_4", "GAP_1", "NAME", "NAME_0", "PORT"}, src.Keys())
+	})
+	t.Run("origin", func(t *testing.T) {
+		src := NewChunked(NewLayered().
+			Add("env", Values{"KEY-0": "foo", "KEY-1": "bar"}), "-")
+
+		have, origin, ok := src.LookupOrigin("KEY")
+		assert.True(t, ok)
+		assert.Equal(t, Value("foobar"), have)
+		assert.Equal(t, "env", origin.Source)
+	})
+	t.Run("struct", func(t *testing.T) {
+		var have struct {
+			Cert string `rawconv:"CERT"`
+		}
+		_, err := NewUnmarshaler(WithUnknownKeys(RejectUnknownKeys)).
+			UnmarshalStruct(NewChunked(SplitChunks("CERT", "-----BEGIN CERTIFICATE-----", 4, ""), ""), &have)
+		assert.NoError(t, err)
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----", have.Cert)
+	})
+}
+
+func TestSplitChunks(t *testing.T) {
+	tests := map[string]struct {
+		input Value
+		size  int
+		want  Values
+	}{
+		"exact": {
+			input: "abcdef",
+			size:  3,
+			want:  Values{"KEY_0": "abc", "KEY_1": "def"},
+		},
+		"remainder": {
+			input: "abcdefg",
+			size:  3,
+			want:  Values{"KEY_0": "abc", "KEY_1": "def", "KEY_2": "g"},
+		},
+		"single": {
+			input: "abc",
+			size:  0,
+			want:  Values{"KEY_0": "abc"},
+		},
+		"empty": {
+			input: "",
+			size:  3,
+			want:  Values{"KEY_0": ""},
+		},
+		"multibyte": {
+			input: "aé€b",
+			size:  4,
+			want:  Values{"KEY_0": "aé", "KEY_1": "€b"},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have := SplitChunks("KEY", tc.input, tc.size, "")
+			assert.Equal(t, tc.want, have)
+
+			v, ok := NewChunked(have, "").Lookup("KEY")
+			assert.True(t, ok)
+			assert.Equal(t, tc.input, v)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const ErrDecompressFailure errors.Msg = "failed to decompress"
+
+const (
+	// CompressedPrefix is the prefix of a compressed Value, followed by its
+	// base64 encoded gzip data, e.g. "gz:<base64>".
+	CompressedPrefix = "gz:"
+
+	// DefaultCompressThreshold is the minimum length in bytes of a Value which
+	// is compressed when Options.Compress is enabled.
+	DefaultCompressThreshold = 1024
+)
+
+// IsCompressed indicates if v is a compressed Value.
+func IsCompressed(v Value) bool { return strings.HasPrefix(string(v), CompressedPrefix) }
+
+// Compress compresses v using gzip and returns it as "gz:<base64>".
+func Compress(v Value) (Value, error) {
+	var buf bytes.Buffer
+	buf.WriteString(CompressedPrefix)
+
+	enc := base64.NewEncoder(base64.StdEncoding, &buf)
+	zw := gzip.NewWriter(enc)
+	if _, err := zw.Write([]byte(v)); err != nil {
+		return "", errors.WithStack(err)
+	}
+	if err := zw.Close(); err != nil {
+		return "", errors.WithStack(err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return Value(buf.String()), nil
+}
+
+// Decompress decompresses v, which is created with Compress. When max is
+// larger than 0, an ErrValueTooLong error is returned when the decompressed
+// Value exceeds max bytes.
+func Decompress(v Value, max int) (Value, error) {
+	if !IsCompressed(v) {
+		return "", errors.New(ErrDecompressFailure)
+	}
+
+	dec := base64.NewDecoder(base64.StdEncoding, strings.NewReader(string(v[len(CompressedPrefix):])))
+	zr, err := gzip.NewReader(dec)
+	if err != nil {
+		return "", errors.Wrap(err, ErrDecompressFailure)
+	}
+
+	var r io.Reader = zr
+	if max > 0 {
+		// read a single byte more to detect if max is exceeded
+		r = io.LimitReader(zr, int64(max)+1)
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", errors.Wrap(err, ErrDecompressFailure)
+	}
+	if max > 0 && len(data) > max {
+		return "", errors.Newf("%w of %d bytes", ErrValueTooLong, max)
+	}
+	return Value(data), nil
+}
+
+func (o Options) compressThreshold() int {
+	if o.CompressThreshold <= 0 {
+		return DefaultCompressThreshold
+	}
+	return o.CompressThreshold
+}
+
+// compress compresses str when it exceeds the compress threshold, and when
+// the result is actually shorter.
+func (m *Marshaler) compress(str string) (string, error) {
+	if len(str) < m.compressThreshold() {
+		return str, nil
+	}
+
+	v, err := Compress(Value(str))
+	if err != nil || len(v) >= len(str) {
+		return str, err
+	}
+	return v.String(), nil
+}
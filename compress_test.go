@@ -0,0 +1,88 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompress(t *testing.T) {
+	input := Value(strings.Repeat(`{"key":"value"},`, 100))
+
+	have, err := Compress(input)
+	assert.NoError(t, err)
+	assert.True(t, IsCompressed(have))
+	assert.Less(t, len(have), len(input))
+
+	t.Run("decompress", func(t *testing.T) {
+		res, err := Decompress(have, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, input, res)
+	})
+	t.Run("max length", func(t *testing.T) {
+		_, err := Decompress(have, len(input)-1)
+		assert.ErrorIs(t, err, ErrValueTooLong)
+
+		res, err := Decompress(have, len(input))
+		assert.NoError(t, err)
+		assert.Equal(t, input, res)
+	})
+
+	tests := map[string]Value{
+		"not compressed": "foobar",
+		"invalid base64": "gz:!!",
+		"invalid gzip":   "gz:Zm9vYmFy",
+		"truncated":      have[:len(have)/2],
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Decompress(input, 0)
+			assert.ErrorIs(t, err, ErrDecompressFailure)
+		})
+	}
+}
+
+func TestMarshaler_Compress(t *testing.T) {
+	large := strings.Repeat("abc", 400)
+
+	t.Run("round trip", func(t *testing.T) {
+		v, err := Marshal(large, WithCompress(0))
+		assert.NoError(t, err)
+		assert.True(t, IsCompressed(v))
+
+		var have string
+		assert.NoError(t, Unmarshal(v, &have, WithCompress(0)))
+		assert.Equal(t, large, have)
+	})
+	t.Run("below threshold", func(t *testing.T) {
+		v, err := Marshal(large, WithCompress(len(large)+1))
+		assert.NoError(t, err)
+		assert.Equal(t, Value(large), v)
+	})
+	t.Run("not shorter", func(t *testing.T) {
+		v, err := Marshal("abcdefgh", WithCompress(1))
+		assert.NoError(t, err)
+		assert.Equal(t, Value("abcdefgh"), v)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		v, err := Compress(Value(large))
+		assert.NoError(t, err)
+
+		var have string
+		assert.NoError(t, Unmarshal(v, &have))
+		assert.Equal(t, v.String(), have)
+	})
+	t.Run("limit", func(t *testing.T) {
+		v, err := Compress(Value(large))
+		assert.NoError(t, err)
+
+		var have string
+		err = Unmarshal(v, &have, WithCompress(0), WithLimits(Limits{MaxLength: 100}))
+		assert.ErrorIs(t, err, ErrValueTooLong)
+	})
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"time"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Date)(nil)
+	_ encoding.TextUnmarshaler = (*Date)(nil)
+	_ encoding.TextMarshaler   = (*TimeOfDay)(nil)
+	_ encoding.TextUnmarshaler = (*TimeOfDay)(nil)
+)
+
+// DateLayout is the layout of a Date's raw Value.
+const DateLayout = "2006-01-02"
+
+// Date is a calendar date without time and location. Its raw Value has the
+// format YYYY-MM-DD, e.g. "1997-08-29".
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the Date of time.Time t, in t's location.
+func DateOf(t time.Time) Date {
+	var d Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// Time returns Date as time.Time at midnight in location loc.
+func (d Date) Time(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// IsZero indicates if Date is the zero value.
+func (d Date) IsZero() bool { return d == Date{} }
+
+// String returns Date formatted as YYYY-MM-DD, or an empty string when Date
+// is the zero value.
+func (d Date) String() string {
+	if d.IsZero() {
+		return ""
+	}
+	return d.Time(time.UTC).Format(DateLayout)
+}
+
+// MarshalText returns Date formatted as YYYY-MM-DD, or an empty text when
+// Date is the zero value.
+func (d Date) MarshalText() ([]byte, error) { return []byte(d.String()), nil }
+
+// UnmarshalText parses text with format YYYY-MM-DD. An empty text results in
+// the zero value.
+func (d *Date) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Date{}
+		return nil
+	}
+
+	t, err := time.Parse(DateLayout, string(text))
+	if err != nil {
+		return errors.Wrap(err, ErrParseFailure)
+	}
+
+	*d = DateOf(t)
+	return nil
+}
+
+// TimeOfDay is a time within a day, without date and location. Its raw Value
+// has the format HH:MM or HH:MM:SS, e.g. "13:37" or "13:37:59".
+type TimeOfDay struct {
+	Hour   int
+	Minute int
+	Second int
+}
+
+// TimeOfDayOf returns the TimeOfDay of time.Time t, in t's location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	var tod TimeOfDay
+	tod.Hour, tod.Minute, tod.Second = t.Clock()
+	return tod
+}
+
+// Duration returns the time.Duration since midnight.
+func (tod TimeOfDay) Duration() time.Duration {
+	return time.Duration(tod.Hour)*time.Hour +
+		time.Duration(tod.Minute)*time.Minute +
+		time.Duration(tod.Second)*time.Second
+}
+
+// String returns TimeOfDay formatted as HH:MM, or HH:MM:SS when Second is not
+// zero.
+func (tod TimeOfDay) String() string {
+	t := time.Date(0, 1, 1, tod.Hour, tod.Minute, tod.Second, 0, time.UTC)
+	if tod.Second == 0 {
+		return t.Format("15:04")
+	}
+	return t.Format("15:04:05")
+}
+
+// MarshalText returns TimeOfDay formatted as HH:MM or HH:MM:SS.
+func (tod TimeOfDay) MarshalText() ([]byte, error) { return []byte(tod.String()), nil }
+
+// UnmarshalText parses text with format HH:MM or HH:MM:SS.
+func (tod *TimeOfDay) UnmarshalText(text []byte) error {
+	layout := "15:04"
+	if len(text) > len(layout) {
+		layout = "15:04:05"
+	}
+
+	t, err := time.Parse(layout, string(text))
+	if err != nil {
+		return errors.Wrap(err, ErrParseFailure)
+	}
+
+	*tod = TimeOfDayOf(t)
+	return nil
+}
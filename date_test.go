@@ -0,0 +1,66 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDate(t *testing.T) {
+	var have Date
+	assert.NoError(t, Unmarshal("1997-08-29", &have))
+	assert.Equal(t, Date{Year: 1997, Month: time.August, Day: 29}, have)
+	assert.Equal(t, time.Date(1997, 8, 29, 0, 0, 0, 0, time.UTC), have.Time(time.UTC))
+	assert.Equal(t, Value("1997-08-29"), MustMarshal(have))
+	assert.False(t, have.IsZero())
+
+	t.Run("zero", func(t *testing.T) {
+		assert.Equal(t, Value(""), MustMarshal(Date{}))
+
+		d := Date{Year: 1997, Month: time.August, Day: 29}
+		assert.NoError(t, d.UnmarshalText(nil))
+		assert.True(t, d.IsZero())
+		assert.NoError(t, Unmarshal(MustMarshal(Date{}), &d, WithEmptyMode(EmptyZero)))
+		assert.True(t, d.IsZero())
+
+		var z Date
+		assert.NoError(t, Unmarshal(MustMarshal(z), &z))
+		assert.True(t, z.IsZero())
+	})
+
+	for _, input := range []Value{"1997-8-29", "1997-02-30", "29-08-1997", "foo"} {
+		t.Run(input.String(), func(t *testing.T) {
+			assert.ErrorIs(t, Unmarshal(input, &have), ErrParseFailure)
+		})
+	}
+}
+
+func TestTimeOfDay(t *testing.T) {
+	tests := map[Value]TimeOfDay{
+		"13:37":    {Hour: 13, Minute: 37},
+		"00:00":    {},
+		"23:59:59": {Hour: 23, Minute: 59, Second: 59},
+	}
+	for input, want := range tests {
+		t.Run(input.String(), func(t *testing.T) {
+			var have TimeOfDay
+			assert.NoError(t, Unmarshal(input, &have))
+			assert.Equal(t, want, have)
+			assert.Equal(t, input, MustMarshal(have))
+		})
+	}
+
+	assert.Equal(t, time.Hour*13+time.Minute*37+time.Second, TimeOfDay{13, 37, 1}.Duration())
+
+	for _, input := range []Value{"24:00", "13:60", "1337", "13:37:00:00", "foo"} {
+		t.Run(input.String(), func(t *testing.T) {
+			var have TimeOfDay
+			assert.ErrorIs(t, Unmarshal(input, &have), ErrParseFailure)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import "reflect"
+
+// Logger logs debug messages with alternating key-value pairs as arguments.
+// A *slog.Logger satisfies this interface.
+type Logger interface {
+	Debug(msg string, args ...any)
+}
+
+func (fm funcMatch) logArgs(typ reflect.Type) []any {
+	scope := "local"
+	if fm.global {
+		scope = "global"
+	}
+	return []any{
+		"type", typ.String(),
+		"registered", fm.registered.String(),
+		"interface", fm.registered.Kind() == reflect.Interface,
+		"scope", scope,
+	}
+}
+
+// debugLookup logs the result of looking up a func for typ, with optional
+// additional key-value pairs in args. Values must never be added to args as
+// they may contain secrets.
+func debugLookup(l Logger, op string, typ reflect.Type, found bool, match funcMatch, args ...any) {
+	if found {
+		l.Debug("rawconv: "+op+" using registered func", append(match.logArgs(typ), args...)...)
+		return
+	}
+	if !match.kind {
+		// unsupported types are logged when the error is created
+		return
+	}
+	l.Debug("rawconv: "+op+" using kind", append([]any{
+		"type", typ.String(),
+		"kind", typ.Kind().String(),
+	}, args...)...)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordLogger struct{ lines []string }
+
+func (l *recordLogger) Debug(msg string, args ...any) {
+	var sb strings.Builder
+	sb.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		_, _ = fmt.Fprintf(&sb, " %v=%v", args[i], args[i+1])
+	}
+	l.lines = append(l.lines, sb.String())
+}
+
+func TestOptions_Logger(t *testing.T) {
+	var logger recordLogger
+	u := Unmarshaler{Options: Options{Logger: &logger}}
+	u.Register(reflect.TypeOf(Bytes(0)), func(val Value, dest any) error {
+		return nil
+	})
+
+	var dur time.Duration
+	assert.NoError(t, u.Unmarshal("secret", reflect.ValueOf(new(Bytes))))
+	assert.Error(t, u.Unmarshal("hunter2", reflect.ValueOf(&dur)))
+	assert.NoError(t, u.Unmarshal("6ba7b8109dad11d180b400c04fd430c8", reflect.ValueOf(new(UUID))))
+	assert.NoError(t, u.Unmarshal("1,2", reflect.ValueOf(new([]int))))
+	assert.Error(t, u.Unmarshal("x", reflect.ValueOf(new(chan int))))
+
+	assert.Equal(t, []string{
+		"rawconv: unmarshal using registered func type=*rawconv.Bytes registered=rawconv.Bytes interface=false scope=local length=6",
+		"rawconv: unmarshal using registered func type=*time.Duration registered=time.Duration interface=false scope=global length=7",
+		"rawconv: unmarshal using registered func type=*rawconv.UUID registered=encoding.TextMarshaler interface=true scope=global length=32",
+		"rawconv: unmarshal using kind type=*[]int kind=ptr length=3",
+		"rawconv: unmarshal using kind type=int kind=int length=1",
+		"rawconv: unmarshal using kind type=int kind=int length=1",
+		"rawconv: unmarshal using kind type=*chan int kind=ptr length=1",
+		"rawconv: unmarshal unsupported type type=*chan int",
+	}, logger.lines)
+
+	for _, line := range logger.lines {
+		assert.NotContains(t, line, "hunter2")
+	}
+
+	t.Run("marshal", func(t *testing.T) {
+		var logger recordLogger
+		m := Marshaler{Options: Options{Logger: &logger}}
+		_, _ = m.Marshal(reflect.ValueOf(time.Second))
+		_, _ = m.Marshal(reflect.ValueOf("foo"))
+
+		assert.Equal(t, []string{
+			"rawconv: marshal using registered func type=time.Duration registered=time.Duration interface=false scope=global",
+			"rawconv: marshal using kind type=string kind=string",
+		}, logger.lines)
+	})
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Decimal)(nil)
+	_ encoding.TextUnmarshaler = (*Decimal)(nil)
+	_ encoding.TextMarshaler   = (*Money)(nil)
+	_ encoding.TextUnmarshaler = (*Money)(nil)
+)
+
+// Decimal is an exact decimal number, e.g. "12.34", which is stored as an
+// integer amount of minor units and a scale. This avoids any rounding errors
+// that occur when using floats. Exponent notation is not supported.
+type Decimal struct {
+	// Units is the amount of minor units, e.g. 1234 for "12.34".
+	Units int64
+	// Scale is the number of decimals, e.g. 2 for "12.34".
+	Scale uint8
+}
+
+// ParseDecimal parses str as a Decimal.
+func ParseDecimal(str string) (Decimal, error) {
+	var d Decimal
+	if err := d.parse(str); err != nil {
+		return d, err
+	}
+	return d, nil
+}
+
+func (d *Decimal) parse(str string) error {
+	s := str
+	var neg bool
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if (whole == "" && frac == "") || !isDigits(whole) || !isDigits(frac) {
+		return errors.Wrap(errors.Newf("invalid decimal `%s`", str), ErrParseFailure)
+	}
+	if len(frac) > math.MaxUint8 {
+		return errors.Wrap(errors.Newf("too many decimals in `%s`", str), ErrValidationFailure)
+	}
+
+	units, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return errors.Wrap(errors.Newf("decimal `%s` out of range", str), ErrValidationFailure)
+	}
+	if neg {
+		units = -units
+	}
+
+	d.Units, d.Scale = units, uint8(len(frac))
+	return nil
+}
+
+// String returns Decimal formatted with Scale decimals, e.g. "12.34".
+func (d Decimal) String() string {
+	str := strconv.FormatInt(d.Units, 10)
+	if d.Scale == 0 {
+		return str
+	}
+
+	var sign string
+	if d.Units < 0 {
+		sign, str = "-", str[1:]
+	}
+	if pad := int(d.Scale) + 1 - len(str); pad > 0 {
+		str = strings.Repeat("0", pad) + str
+	}
+	i := len(str) - int(d.Scale)
+	return sign + str[:i] + "." + str[i:]
+}
+
+// Rat returns Decimal as a *big.Rat.
+func (d Decimal) Rat() *big.Rat {
+	denom := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale)), nil)
+	return new(big.Rat).SetFrac(big.NewInt(d.Units), denom)
+}
+
+// Float64 returns the nearest float64 value of Decimal.
+func (d Decimal) Float64() float64 {
+	f, _ := d.Rat().Float64()
+	return f
+}
+
+// Cmp compares Decimal d with o, regardless of their scale, and returns -1,
+// 0 or +1 depending on whether d is less than, equal to or greater than o.
+func (d Decimal) Cmp(o Decimal) int { return d.Rat().Cmp(o.Rat()) }
+
+// MarshalText returns Decimal formatted as text.
+func (d Decimal) MarshalText() ([]byte, error) { return []byte(d.String()), nil }
+
+// UnmarshalText parses text as a Decimal.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	return d.parse(string(text))
+}
+
+// Money is a Decimal amount with an optional ISO 4217 currency code. Its raw
+// Value has the format "12.34 EUR", "EUR 12.34" or "12.34" when the currency
+// is unknown.
+type Money struct {
+	Decimal
+	// Currency is the uppercase three letter currency code, e.g. "EUR".
+	Currency string
+}
+
+// ParseMoney parses str as Money.
+func ParseMoney(str string) (Money, error) {
+	var m Money
+	if err := m.parse(str); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+func (m *Money) parse(str string) error {
+	fields := strings.Fields(str)
+	switch len(fields) {
+	case 1:
+		m.Currency = ""
+		return m.Decimal.parse(fields[0])
+	case 2:
+		amount, currency := fields[0], fields[1]
+		if isAlpha(amount) {
+			amount, currency = currency, amount
+		}
+		if len(currency) != 3 || !isAlpha(currency) {
+			return errors.Wrap(errors.Newf("invalid currency `%s`", currency), ErrParseFailure)
+		}
+
+		m.Currency = strings.ToUpper(currency)
+		return m.Decimal.parse(amount)
+	default:
+		return errors.Wrap(errors.Newf("invalid money `%s`", str), ErrParseFailure)
+	}
+}
+
+// String returns Money formatted as "12.34 EUR", or "12.34" when Currency is
+// empty.
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Decimal.String()
+	}
+	return m.Decimal.String() + " " + m.Currency
+}
+
+// MarshalText returns Money formatted as text.
+func (m Money) MarshalText() ([]byte, error) { return []byte(m.String()), nil }
+
+// UnmarshalText parses text as Money.
+func (m *Money) UnmarshalText(text []byte) error {
+	return m.parse(string(text))
+}
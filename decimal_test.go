@@ -0,0 +1,89 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := map[string]struct {
+		want    Decimal
+		wantStr string
+	}{
+		"12.34":  {want: Decimal{Units: 1234, Scale: 2}, wantStr: "12.34"},
+		"-0.05":  {want: Decimal{Units: -5, Scale: 2}, wantStr: "-0.05"},
+		"+7":     {want: Decimal{Units: 7}, wantStr: "7"},
+		".5":     {want: Decimal{Units: 5, Scale: 1}, wantStr: "0.5"},
+		"10.":    {want: Decimal{Units: 10}, wantStr: "10"},
+		"0.100":  {want: Decimal{Units: 100, Scale: 3}, wantStr: "0.100"},
+		"-12.00": {want: Decimal{Units: -1200, Scale: 2}, wantStr: "-12.00"},
+	}
+	for input, tc := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseDecimal(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, tc.want, have)
+			assert.Equal(t, tc.wantStr, have.String())
+		})
+	}
+
+	invalid := map[string]error{
+		"":                     ErrParseFailure,
+		".":                    ErrParseFailure,
+		"1.2.3":                ErrParseFailure,
+		"1e3":                  ErrParseFailure,
+		"12,34":                ErrParseFailure,
+		"99999999999999999999": ErrValidationFailure,
+	}
+	for input, wantErr := range invalid {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseDecimal(input)
+			assert.ErrorIs(t, haveErr, wantErr)
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	a := Decimal{Units: 1230, Scale: 3}
+	b := Decimal{Units: 123, Scale: 2}
+	assert.Equal(t, 0, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(Decimal{Units: 2}))
+	assert.Equal(t, big.NewRat(123, 100), b.Rat())
+	assert.Equal(t, 1.23, b.Float64())
+
+	var have Decimal
+	assert.NoError(t, Unmarshal("0.30", &have))
+	assert.Equal(t, Value("0.30"), MustMarshal(have))
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := map[string]Money{
+		"12.34 EUR": {Decimal: Decimal{Units: 1234, Scale: 2}, Currency: "EUR"},
+		"usd 5":     {Decimal: Decimal{Units: 5}, Currency: "USD"},
+		"-0.99":     {Decimal: Decimal{Units: -99, Scale: 2}},
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseMoney(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, want, have)
+		})
+	}
+
+	for _, input := range []string{"", "EUR", "12.34 EURO", "12.34 E1R", "1 2 3", "EUR USD"} {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseMoney(input)
+			assert.ErrorIs(t, haveErr, ErrParseFailure)
+		})
+	}
+
+	var have Money
+	assert.NoError(t, Unmarshal("EUR 12.30", &have))
+	assert.Equal(t, Value("12.30 EUR"), MustMarshal(have))
+}
@@ -7,8 +7,9 @@ package rawconv
 import (
 	"reflect"
 	"strings"
+	"time"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 const (
@@ -20,6 +21,7 @@ const (
 	ErrArrayTooManyValues errors.Msg = "too many values"
 	ErrMapInvalidFormat   errors.Msg = "invalid map format"
 	ErrUnmarshalFuncExec  errors.Msg = "error while executing UnmarshalFunc"
+	ErrEmptyValue         errors.Msg = "empty value"
 )
 
 // Unmarshal parses Value and stores the result in the value pointed to by v.
@@ -34,22 +36,45 @@ const (
 //   - complex64, complex128
 //   - array, slice
 //   - map
-//   - time.Duration
+//   - time.Duration, time.Weekday, time.Month
 //   - url.URL
+//   - color.RGBA
 //   - encoding.TextUnmarshaler
 //
-// Use RegisterUnmarshalFunc to add additional (custom) types.
-func Unmarshal(val Value, v any) error {
+// Use RegisterUnmarshalFunc to add additional (custom) types. Option(s)
+// override the Options of the global Unmarshaler for this call only.
+// Registered funcs take precedence over interfaces, which take precedence over
+// the type's kind. Use Options.Stages to change this order, see DefaultStages.
+func Unmarshal(val Value, v any, opts ...Option) error {
 	rv := reflect.ValueOf(v)
 	if rv.Kind() != reflect.Ptr || rv.IsNil() {
 		return errors.New(ErrPointerExpected)
 	}
 
-	return unmarshaler.unmarshal(val, rv, false)
+	return unmarshaler.with(opts).unmarshal(val, rv, false)
+}
+
+// UnmarshalReflect parses Value and stores the result in reflect.Value v using
+// the global Unmarshaler. Argument v must either be a pointer, or a settable
+// value, otherwise ErrUnableToSet is returned. See Unmarshal for additional
+// details.
+func UnmarshalReflect(val Value, v reflect.Value, opts ...Option) error {
+	return unmarshaler.Unmarshal(val, v, opts...)
+}
+
+// MustUnmarshal is like Unmarshal but panics when an error occurs. It
+// simplifies the initialization of package level variables and use within
+// tests.
+func MustUnmarshal(val Value, v any, opts ...Option) {
+	if err := Unmarshal(val, v, opts...); err != nil {
+		panic(err)
+	}
 }
 
 // UnmarshalFunc is a function which can unmarshal a Value to any type.
-// Argument dest is always a pointer to the value to unmarshal to.
+// Argument dest is always a pointer to the value to unmarshal to. When the
+// UnmarshalFunc is registered for a pointer type *T, dest is a **T so the
+// UnmarshalFunc is able to set the pointer itself.
 type UnmarshalFunc func(val Value, dest any) error
 
 // GetUnmarshalFunc returns the globally registered UnmarshalFunc for
@@ -69,45 +94,123 @@ type Unmarshaler struct {
 }
 
 // Register the UnmarshalFunc for typ but only for this Unmarshaler.
+// It panics when the Unmarshaler is frozen.
 func (u *Unmarshaler) Register(typ reflect.Type, fn UnmarshalFunc) *Unmarshaler {
 	u.register.add(typ, fn)
 	return u
 }
 
+// Freeze the Unmarshaler so any further call to Register panics. A frozen
+// Unmarshaler can safely be shared across goroutines without additional
+// synchronization, as long as its Options are not modified and no funcs are
+// registered globally anymore.
+func (u *Unmarshaler) Freeze() *Unmarshaler {
+	u.register.frozen = true
+	return u
+}
+
+// Frozen indicates if the Unmarshaler is frozen using Freeze.
+func (u *Unmarshaler) Frozen() bool { return u.register.frozen }
+
+// Scope takes a snapshot of the UnmarshalFunc funcs which are registered with
+// this Unmarshaler. The returned func restores this snapshot, removing or
+// reverting any funcs registered in the meantime. The frozen state is not part
+// of the snapshot, a Unmarshaler frozen within the scope remains frozen.
+func (u *Unmarshaler) Scope() (restore func()) {
+	snapshot := u.register.clone()
+	return func() { u.register.restore(snapshot) }
+}
+
 // Func returns the (globally) registered UnmarshalFunc for reflect.Type typ or
 // nil if there is none registered with Register or RegisterUnmarshalFunc.
 func (u *Unmarshaler) Func(typ reflect.Type) UnmarshalFunc {
-	if u.register.initialized() {
-		if fn := u.register.find(typ); fn != nil {
-			return fn
-		}
-	}
-	// fallback to global unmarshaler
-	return unmarshaler.register.find(typ)
+	fn, _ := u.lookup(typ)
+	return fn
+}
+
+func (u *Unmarshaler) lookup(typ reflect.Type) (UnmarshalFunc, funcMatch) {
+	return resolve(u.stages(), &u.register, &unmarshaler.register, typ)
 }
 
 // Unmarshal tries to unmarshal Value to a supported type which matches the
 // type of v, and sets the parsed value to it. See Unmarshal for additional
-// details.
-func (u *Unmarshaler) Unmarshal(val Value, v reflect.Value) error {
+// details. Option(s) override the Options of the Unmarshaler for this call
+// only.
+func (u *Unmarshaler) Unmarshal(val Value, v reflect.Value, opts ...Option) error {
 	if v.Kind() != reflect.Ptr && !v.CanSet() {
 		return errors.New(ErrUnableToSet)
 	}
-	return u.unmarshal(val, v, false)
+	return u.with(opts).unmarshal(val, v, false)
 }
 
-func (u *Unmarshaler) unmarshal(v Value, dest reflect.Value, nested bool) error {
-	if fn := u.Func(dest.Type()); fn != nil {
+func (u *Unmarshaler) unmarshal(v Value, dest reflect.Value, nested bool) (err error) {
+	if !nested {
+		if u.Hooks != nil {
+			defer hookUnmarshal(u.Hooks, dest, time.Now(), &err)
+		}
+		if err := u.Limits.checkLength(v); err != nil {
+			return err
+		}
+		if u.KeyProvider != nil && IsEnvelope(v) {
+			if v, err = Decrypt(u.KeyProvider, v); err != nil {
+				return err
+			}
+		}
+		if u.Compress && IsCompressed(v) {
+			if v, err = Decompress(v, u.Limits.MaxLength); err != nil {
+				return err
+			}
+		}
+		if u.EscapeNewlines {
+			v = Value(unescapeNewlines(v.String()))
+		}
+	}
+	if v.IsEmpty() {
+		switch u.EmptyMode {
+		case EmptySkip:
+			return nil
+		case EmptyZero:
+			return zero(dest)
+		case EmptyError:
+			return errors.New(ErrEmptyValue)
+		}
+	}
+	if u.DockerCompat {
+		v = dockerValue(v, dest.Type())
+	}
+	if u.DurationFormat != DurationDefault {
+		if v, err = u.DurationFormat.durationValue(v, dest.Type()); err != nil {
+			return err
+		}
+	}
+	if u.PathMode != 0 {
+		if v, err = u.PathMode.pathValue(v, dest.Type()); err != nil {
+			return err
+		}
+	}
+
+	fn, match := u.lookup(dest.Type())
+	if u.Logger != nil {
+		debugLookup(u.Logger, "unmarshal", dest.Type(), fn != nil, match, "length", len(v))
+	}
+	if fn != nil {
+		if u.RecoverPanics {
+			defer recoverPanic(&err, dest.Type(), v)
+		}
+		if match.registered.Kind() == reflect.Ptr {
+			return fn.execPointer(v, dest, match.registered)
+		}
 		return fn.Exec(v, dest)
 	}
+	if !match.kind {
+		return u.unsupported(dest.Type())
+	}
 
 	if v.IsEmpty() {
 		return nil
 	}
 
 	ot := dest.Type()
-
-	var err error
 	for dest.Kind() == reflect.Ptr {
 		if dest.IsNil() {
 			if !dest.CanSet() {
@@ -131,22 +234,32 @@ func (u *Unmarshaler) unmarshal(v Value, dest reflect.Value, nested bool) error
 		return err
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		x, err := intSize(v, dest.Type().Bits())
+		x, err := intBase(v, u.intBase(false), dest.Type().Bits())
 		dest.SetInt(x)
 		return err
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		x, err := uintSize(v, dest.Type().Bits())
+		x, err := uintBase(v, u.intBase(false), dest.Type().Bits())
 		dest.SetUint(x)
 		return err
 
 	case reflect.Float32, reflect.Float64:
 		x, err := floatSize(v, dest.Type().Bits())
+		if err == nil {
+			if err = u.checkFloat(x); err != nil {
+				return err
+			}
+		}
 		dest.SetFloat(x)
 		return err
 
 	case reflect.Complex64, reflect.Complex128:
 		x, err := complexSize(v, dest.Type().Bits())
+		if err == nil {
+			if err = u.checkComplex(x); err != nil {
+				return err
+			}
+		}
 		dest.SetComplex(x)
 		return err
 
@@ -154,13 +267,15 @@ func (u *Unmarshaler) unmarshal(v Value, dest reflect.Value, nested bool) error
 		if nested {
 			return errors.New(ErrUnmarshalNested)
 		}
-
-		parts := split(v.String(), u.itemSeparator())
+		parts, err := u.splitItems(v.String())
+		if err != nil {
+			return err
+		}
 		typ := dest.Type().Elem()
 
 		partsLen, arrayLen := len(parts), dest.Len()
 		for i := 0; i < partsLen && i < arrayLen; i++ {
-			part := strings.TrimSpace(parts[i])
+			part := u.trimSpace(parts[i], typ)
 			val := reflect.New(typ).Elem()
 			if err = u.unmarshal(Value(part), val, true); err != nil {
 				return err
@@ -176,13 +291,15 @@ func (u *Unmarshaler) unmarshal(v Value, dest reflect.Value, nested bool) error
 		if nested {
 			return errors.New(ErrUnmarshalNested)
 		}
-
-		parts := split(v.String(), u.itemSeparator())
+		parts, err := u.splitItems(v.String())
+		if err != nil {
+			return err
+		}
 		slice := reflect.MakeSlice(dest.Type(), 0, len(parts))
 		typ := dest.Type().Elem()
 
 		for _, part := range parts {
-			part = strings.TrimSpace(part)
+			part = u.trimSpace(part, typ)
 			val := reflect.New(typ).Elem()
 			if err = u.unmarshal(Value(part), val, true); err != nil {
 				return err
@@ -197,27 +314,24 @@ func (u *Unmarshaler) unmarshal(v Value, dest reflect.Value, nested bool) error
 		if nested {
 			return errors.New(ErrUnmarshalNested)
 		}
-
-		parts := split(v.String(), u.itemSeparator())
+		pairs, err := u.splitPairs(v.String())
 		if dest.IsNil() {
-			dest.Set(reflect.MakeMapWithSize(dest.Type(), len(parts)))
+			dest.Set(reflect.MakeMapWithSize(dest.Type(), len(pairs)))
+		}
+		if err != nil {
+			return err
 		}
 
 		keyTyp := dest.Type().Key()
 		valTyp := dest.Type().Elem()
 
-		for _, part := range parts {
-			kv := strings.SplitN(part, u.keyValueSeparator(), 2)
-			if len(kv) != 2 {
-				return errors.New(ErrMapInvalidFormat)
-			}
-
+		for _, pair := range pairs {
 			key := reflect.New(keyTyp).Elem()
-			if err = u.unmarshal(Value(kv[0]), key, true); err != nil {
+			if err = u.unmarshal(Value(pair[0]), key, true); err != nil {
 				return err
 			}
 			val := reflect.New(valTyp).Elem()
-			if err = u.unmarshal(Value(kv[1]), val, true); err != nil {
+			if err = u.unmarshal(Value(pair[1]), val, true); err != nil {
 				return err
 			}
 
@@ -226,8 +340,45 @@ func (u *Unmarshaler) unmarshal(v Value, dest reflect.Value, nested bool) error
 		return nil
 
 	default:
-		return errors.WithStack(&UnsupportedTypeError{Type: ot})
+		return u.unsupported(ot)
+	}
+}
+
+// with returns an Unmarshaler with the Options of u, modified by opts. It
+// returns u when there are no opts.
+func (u *Unmarshaler) with(opts []Option) *Unmarshaler {
+	if len(opts) == 0 {
+		return u
+	}
+	res := Unmarshaler{Options: u.Options, register: u.register}
+	res.Options.apply(opts)
+	return &res
+}
+
+// forField returns an Unmarshaler with the Options of u, overridden by the
+// options of tag t. It returns u when t does not override any Options.
+func (u *Unmarshaler) forField(t tag) (*Unmarshaler, error) {
+	opts, ok, err := t.applyOptions(u.Options)
+	if err != nil || !ok {
+		return u, err
 	}
+	return &Unmarshaler{Options: opts, register: u.register}, nil
+}
+
+// marshaler returns Options.Marshaler, or a Marshaler with the Options of u
+// when it is not set.
+func (u *Unmarshaler) marshaler() *Marshaler {
+	if u.Marshaler != nil {
+		return u.Marshaler
+	}
+	return &Marshaler{Options: u.Options}
+}
+
+func (u *Unmarshaler) unsupported(typ reflect.Type) error {
+	if u.Logger != nil {
+		u.Logger.Debug("rawconv: unmarshal unsupported type", "type", typ.String())
+	}
+	return errors.WithStack(&UnsupportedTypeError{Type: typ})
 }
 
 // Exec executes the UnmarshalFunc by taking the address of dest, and passing it
@@ -255,6 +406,23 @@ func (fn UnmarshalFunc) Exec(v Value, dest reflect.Value) error {
 	return fn.exec(v, dest)
 }
 
+// execPointer executes the UnmarshalFunc, which is registered for pointer type
+// typ, with a pointer to the typ value dest (eventually) points to. This allows
+// the UnmarshalFunc to set the pointer itself.
+func (fn UnmarshalFunc) execPointer(v Value, dest reflect.Value, typ reflect.Type) error {
+	var err error
+	for dest.Type() != typ {
+		if dest, err = value(dest); err != nil {
+			return err
+		}
+		dest = dest.Elem()
+	}
+	if !dest.CanAddr() {
+		return errors.New(ErrUnableToAddr)
+	}
+	return fn.exec(v, dest.Addr())
+}
+
 func (fn UnmarshalFunc) exec(val Value, dest reflect.Value) error {
 	if err := fn(val, dest.Interface()); err != nil {
 		return errors.Wrap(err, ErrUnmarshalFuncExec)
@@ -274,6 +442,113 @@ func value(rv reflect.Value) (reflect.Value, error) {
 	return rv, nil
 }
 
-func split(str, sep string) []string {
-	return strings.Split(str, sep)
+// zero sets the target of dest to its zero value. When dest is a pointer which
+// cannot be set itself, the value it points to is considered the target.
+func zero(dest reflect.Value) error {
+	if dest.Kind() == reflect.Ptr && !dest.CanSet() {
+		if dest.IsNil() {
+			return nil
+		}
+		dest = dest.Elem()
+	}
+	if !dest.CanSet() {
+		return errors.New(ErrUnableToSet)
+	}
+
+	dest.Set(reflect.Zero(dest.Type()))
+	return nil
+}
+
+// trimSpace trims leading and trailing whitespace from str and warns when it
+// is changed.
+func (u *Unmarshaler) trimSpace(str string, typ reflect.Type) string {
+	if u.QuoteItems || u.Tokenizer != nil {
+		// whitespace within quoted or tokenized items is always significant
+		return str
+	}
+	res := strings.TrimSpace(str)
+	if len(res) != len(str) {
+		u.warn(WarnTrimmedSpace, typ)
+	}
+	return res
+}
+
+// splitItems splits str using Options.Tokenizer, or the items separator when
+// there is none. When separators are escaped, the escape characters are
+// removed from the resulting parts. When items are quoted, each part must be a
+// quoted string and is unquoted.
+func (u *Unmarshaler) splitItems(str string) ([]string, error) {
+	if u.Tokenizer != nil {
+		parts, err := u.Tokenizer.Split(str)
+		if err != nil {
+			return nil, err
+		}
+		return parts, checkLen(len(parts), u.Limits.MaxItems, ErrTooManyItems)
+	}
+	if u.QuoteItems {
+		parts, err := splitQuoted(str, u.itemSeparator())
+		if err != nil {
+			return nil, err
+		}
+		return parts, checkLen(len(parts), u.Limits.MaxItems, ErrTooManyItems)
+	}
+
+	esc := u.escapeChar()
+	if err := checkCount(str, u.itemSeparator(), esc, u.Limits.MaxItems, ErrTooManyItems); err != nil {
+		return nil, err
+	}
+
+	parts := splitEscaped(str, u.itemSeparator(), esc)
+	if esc != 0 {
+		for i, part := range parts {
+			parts[i] = unescape(part, esc)
+		}
+	}
+	return parts, nil
+}
+
+// splitPairs splits str into key-value pairs using Options.Tokenizer, or the
+// items and key-value separators when there is none.
+func (u *Unmarshaler) splitPairs(str string) ([][2]string, error) {
+	if u.Tokenizer != nil {
+		pairs, err := u.Tokenizer.SplitPairs(str)
+		if err != nil {
+			return nil, err
+		}
+		return pairs, checkLen(len(pairs), u.Limits.MaxEntries, ErrTooManyEntries)
+	}
+	if u.QuoteItems {
+		parts, err := splitQuoted(str, u.keyValueSeparator(), u.itemSeparator())
+		if err != nil {
+			return nil, err
+		}
+		if len(parts)%2 != 0 {
+			return nil, errors.New(ErrMapInvalidFormat)
+		}
+		if err = checkLen(len(parts)/2, u.Limits.MaxEntries, ErrTooManyEntries); err != nil {
+			return nil, err
+		}
+
+		pairs := make([][2]string, 0, len(parts)/2)
+		for i := 0; i < len(parts); i += 2 {
+			pairs = append(pairs, [2]string{parts[i], parts[i+1]})
+		}
+		return pairs, nil
+	}
+
+	esc := u.escapeChar()
+	if err := checkCount(str, u.itemSeparator(), esc, u.Limits.MaxEntries, ErrTooManyEntries); err != nil {
+		return nil, err
+	}
+
+	parts := splitEscaped(str, u.itemSeparator(), esc)
+	pairs := make([][2]string, 0, len(parts))
+	for _, part := range parts {
+		k, v, ok := cutEscaped(part, u.keyValueSeparator(), esc)
+		if !ok {
+			return nil, errors.New(ErrMapInvalidFormat)
+		}
+		pairs = append(pairs, [2]string{unescape(k, esc), unescape(v, esc)})
+	}
+	return pairs, nil
 }
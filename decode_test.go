@@ -5,9 +5,11 @@
 package rawconv
 
 import (
+	"image/color"
 	"net"
 	"net/url"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -30,6 +32,35 @@ func TestUnmarshal(t *testing.T) {
 	}
 }
 
+func TestUnmarshalReflect(t *testing.T) {
+	t.Run("settable", func(t *testing.T) {
+		var have struct{ Timeout time.Duration }
+		rv := reflect.ValueOf(&have).Elem().Field(0)
+		assert.NoError(t, UnmarshalReflect("10s", rv))
+		assert.Equal(t, time.Second*10, have.Timeout)
+	})
+	t.Run("unable to set", func(t *testing.T) {
+		assert.ErrorIs(t, UnmarshalReflect("10s", reflect.ValueOf(time.Second)), ErrUnableToSet)
+	})
+}
+
+func TestMustUnmarshal(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var have time.Duration
+		assert.NotPanics(t, func() { MustUnmarshal("10s", &have) })
+		assert.Equal(t, time.Second*10, have)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var have int
+		assert.Panics(t, func() { MustUnmarshal("foo", &have) })
+	})
+	t.Run("options", func(t *testing.T) {
+		var have int
+		assert.NotPanics(t, func() { MustUnmarshal("ff", &have, WithIntBase(16)) })
+		assert.Equal(t, 255, have)
+	})
+}
+
 func TestUnmarshaler_Func(t *testing.T) {
 	var u Unmarshaler
 	u.Register(reflect.TypeOf(t), func(Value, any) error {
@@ -38,6 +69,23 @@ func TestUnmarshaler_Func(t *testing.T) {
 	testRegisterFind(t, 0, func(typ reflect.Type) any { return u.Func(typ) })
 }
 
+func TestUnmarshaler_Register_pointer(t *testing.T) {
+	shared := new(int)
+	var u Unmarshaler
+	u.Register(reflect.TypeOf(shared), func(_ Value, dest any) error {
+		*dest.(**int) = shared
+		return nil
+	})
+
+	var have *int
+	assert.NoError(t, u.Unmarshal("x", reflect.ValueOf(&have)))
+	assert.Same(t, shared, have)
+
+	var nested **int
+	assert.NoError(t, u.Unmarshal("x", reflect.ValueOf(&nested)))
+	assert.Same(t, shared, *nested)
+}
+
 func TestUnmarshaler_Unmarshal(t *testing.T) {
 	timeVal, _ := time.Parse(time.RFC3339, "1997-08-29T13:37:00Z")
 	urlPtr, _ := url.ParseRequestURI("http://localhost/")
@@ -96,6 +144,14 @@ func TestUnmarshaler_Unmarshal(t *testing.T) {
 			input: "10s",
 			want:  time.Second * 10,
 		}},
+		"weekday": {{
+			input: "Friday",
+			want:  time.Friday,
+		}},
+		"month": {{
+			input: "aug",
+			want:  time.August,
+		}},
 		"time": {{
 			input: "1997-08-29T13:37:00Z",
 			want:  timeVal,
@@ -114,6 +170,10 @@ func TestUnmarshaler_Unmarshal(t *testing.T) {
 			input: "192.168.1.1",
 			want:  net.IPv4(192, 168, 1, 1),
 		}},
+		"color": {{
+			input: "#ff8000",
+			want:  color.RGBA{R: 0xff, G: 0x80, A: 0xff},
+		}},
 		"array": {{
 			input: "1,2,3",
 			want:  [3]int{1, 2, 3},
@@ -178,6 +238,80 @@ func TestUnmarshaler_Unmarshal(t *testing.T) {
 	})
 }
 
+func TestUnmarshaler_EmptyMode(t *testing.T) {
+	tests := map[EmptyMode]struct {
+		want     any
+		wantPtr  *int
+		wantTime time.Time
+		wantErr  error
+	}{
+		EmptySkip:  {want: 10, wantPtr: ptr(5)},
+		EmptyZero:  {want: 0},
+		EmptyError: {want: 10, wantPtr: ptr(5), wantErr: ErrEmptyValue},
+	}
+
+	for mode, tc := range tests {
+		t.Run(strconv.Itoa(int(mode)), func(t *testing.T) {
+			var u Unmarshaler
+			u.EmptyMode = mode
+
+			haveInt := 10
+			assertErr(t, tc.wantErr, u.Unmarshal("", reflect.ValueOf(&haveInt)))
+			assert.Equal(t, tc.want, haveInt)
+
+			havePtr := ptr(5)
+			assertErr(t, tc.wantErr, u.Unmarshal("", reflect.ValueOf(&havePtr)))
+			assert.Equal(t, tc.wantPtr, havePtr)
+
+			// time.Time normally fails to unmarshal an empty value
+			var haveTime time.Time
+			assertErr(t, tc.wantErr, u.Unmarshal("", reflect.ValueOf(&haveTime)))
+			assert.Equal(t, tc.wantTime, haveTime)
+		})
+	}
+}
+
+func TestUnmarshaler_Reject(t *testing.T) {
+	var u Unmarshaler
+	u.RejectNaN = true
+	u.RejectInf = true
+
+	tests := map[string]struct {
+		input   Value
+		target  any
+		wantErr error
+	}{
+		"nan":            {input: "NaN", target: new(float64), wantErr: ErrNaNNotAllowed},
+		"inf":            {input: "+Inf", target: new(float32), wantErr: ErrInfNotAllowed},
+		"-infinity":      {input: "-infinity", target: new(float64), wantErr: ErrInfNotAllowed},
+		"finite":         {input: "1.5", target: new(float64)},
+		"out of range":   {input: "1e400", target: new(float64), wantErr: ErrValidationFailure},
+		"nan slice":      {input: "1.5,NaN", target: new([]float64), wantErr: ErrNaNNotAllowed},
+		"nan complex":    {input: "(NaN+1i)", target: new(complex128), wantErr: ErrNaNNotAllowed},
+		"inf complex":    {input: "(1+Infi)", target: new(complex64), wantErr: ErrInfNotAllowed},
+		"finite complex": {input: "(1.5+2.5i)", target: new(complex128)},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assertErr(t, tc.wantErr, u.Unmarshal(tc.input, reflect.ValueOf(tc.target)))
+		})
+	}
+
+	t.Run("untouched", func(t *testing.T) {
+		have := 1.0
+		assert.ErrorIs(t, u.Unmarshal("NaN", reflect.ValueOf(&have)), ErrNaNNotAllowed)
+		assert.Equal(t, 1.0, have)
+	})
+}
+
+func assertErr(t *testing.T, wantErr, haveErr error) {
+	if wantErr != nil {
+		assert.ErrorIs(t, haveErr, wantErr)
+	} else {
+		assert.NoError(t, haveErr)
+	}
+}
+
 func TestParseFunc_Exec(t *testing.T) {
 	durationType := reflect.TypeOf(time.Nanosecond)
 	parseFunc := UnmarshalFunc(unmarshalDuration)
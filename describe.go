@@ -0,0 +1,148 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// DocTagName is the name of the struct tag which contains the documentation
+// of a field, e.g. `doc:"timeout of the request"`.
+const DocTagName = "doc"
+
+// FieldDescription describes the expected Value of a struct field.
+type FieldDescription struct {
+	// Key is the key of the Value within a Source.
+	Key string `json:"key"`
+	// Field is the path of the field within the struct, e.g. "Db.Host".
+	Field string `json:"field"`
+	// Type is the Go type of the field, e.g. "time.Duration".
+	Type string `json:"type"`
+	// Default is the marshaled value of the field, when it is not a zero
+	// value. It is replaced with Redacted for secret fields and keys which
+	// contain e.g. "password", "secret" or "token". Types which implement
+	// Redacter are displayed in their redacted form.
+	Default Value `json:"default,omitempty"`
+	// Required indicates the field is marked with the "required" tag option.
+	Required bool `json:"required,omitempty"`
+	// Aliases are the alternative keys of the field, from the "alias" tag
+	// option.
+	Aliases []string `json:"aliases,omitempty"`
+	// Deprecated indicates the field is marked with the "deprecated" tag
+	// option.
+	Deprecated bool `json:"deprecated,omitempty"`
+	// Secret indicates the field is marked with the "secret" tag option.
+	Secret bool `json:"secret,omitempty"`
+	// Doc is the documentation from the `doc` struct tag.
+	Doc string `json:"doc,omitempty"`
+}
+
+// DescribeStruct describes the fields of struct v, using the global Marshaler.
+// See Marshaler.DescribeStruct for additional details.
+func DescribeStruct(v any) ([]FieldDescription, error) {
+	return marshaler.DescribeStruct(v)
+}
+
+// DescribeStruct returns a FieldDescription for each field of struct v that
+// would be handled by UnmarshalStruct. Argument v may be a struct value or a
+// pointer to a struct. Non-zero field values are marshaled and used as the
+// field's default value, secret values are redacted. A nil pointer to a struct
+// results in descriptions without default values.
+func (m *Marshaler) DescribeStruct(v any) ([]FieldDescription, error) {
+	_, res, err := m.describeStruct(v)
+	return res, err
+}
+
+func (m *Marshaler) describeStruct(v any) ([]structField, []FieldDescription, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+
+	var typ reflect.Type
+	switch {
+	case rv.Kind() == reflect.Struct:
+		typ = rv.Type()
+	case rv.Kind() == reflect.Ptr && rv.Type().Elem().Kind() == reflect.Struct:
+		typ = rv.Type().Elem()
+		rv = reflect.Value{}
+	default:
+		return nil, nil, errors.New(ErrStructExpected)
+	}
+
+	fields := structFields(typ, func(typ reflect.Type) bool {
+		return m.Func(typ) != nil
+	})
+
+	res := make([]FieldDescription, 0, len(fields))
+	for _, field := range fields {
+		desc := FieldDescription{
+			Key:        field.key,
+			Field:      field.path,
+			Type:       field.typ.String(),
+			Required:   field.tag.has("required"),
+			Aliases:    field.aliases,
+			Deprecated: field.tag.has("deprecated"),
+			Secret:     field.tag.has("secret"),
+			Doc:        field.sf.Tag.Get(DocTagName),
+		}
+
+		if rv.IsValid() {
+			if fv := rv.FieldByIndex(field.index); !fv.IsZero() {
+				var err error
+				if desc.Default, err = m.describeValue(field, fv); err != nil {
+					return nil, nil, err
+				}
+			}
+		}
+
+		res = append(res, desc)
+	}
+	return fields, res, nil
+}
+
+// describeValue marshals the value fv of field for display. Values of secret
+// fields, of keys which look like secrets and of types which implement
+// Redacter are redacted.
+func (m *Marshaler) describeValue(field structField, fv reflect.Value) (Value, error) {
+	fm, err := m.forField(field.tag)
+	var val Value
+	if err == nil {
+		val, err = fm.Marshal(fv)
+	}
+	if err != nil {
+		return "", errors.WithStack(&FieldError{
+			Field: field.path,
+			Key:   field.key,
+			Err:   err,
+		})
+	}
+	if val.IsEmpty() {
+		return val, nil
+	}
+	if field.tag.has("secret") || isSecretKey(field.key) {
+		return Redacted, nil
+	}
+	if r, ok := redacter(fv); ok {
+		return Value(r.Redacted()), nil
+	}
+	return val, nil
+}
+
+func redacter(v reflect.Value) (Redacter, bool) {
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil, false
+	}
+	if r, ok := v.Interface().(Redacter); ok {
+		return r, true
+	}
+	if v.CanAddr() {
+		r, ok := v.Addr().Interface().(Redacter)
+		return r, ok
+	}
+	return nil, false
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"time"
+)
+
+var detectors = []struct {
+	typ   reflect.Type
+	parse func(v Value) bool
+}{
+	{reflect.TypeOf(false), func(v Value) bool {
+		_, err := v.Bool()
+		return err == nil
+	}},
+	{reflect.TypeOf(0), func(v Value) bool {
+		_, err := v.Int()
+		return err == nil
+	}},
+	{reflect.TypeOf(uint(0)), func(v Value) bool {
+		_, err := v.Uint()
+		return err == nil
+	}},
+	{reflect.TypeOf(float64(0)), func(v Value) bool {
+		_, err := v.Float64()
+		return err == nil
+	}},
+	{reflect.TypeOf(complex128(0)), func(v Value) bool {
+		_, err := v.Complex128()
+		return err == nil
+	}},
+	{durationType, func(v Value) bool {
+		_, err := v.Duration()
+		return err == nil
+	}},
+	{timeType, func(v Value) bool {
+		_, err := time.Parse(time.RFC3339, v.String())
+		return err == nil
+	}},
+	{urlType, func(v Value) bool {
+		u, err := v.Url()
+		return err == nil && u.Scheme != ""
+	}},
+}
+
+// Detect returns the builtin types Value can be unmarshaled to. Detected
+// types, in order, are bool, int, uint, float64, complex128, time.Duration,
+// time.Time (RFC 3339) and url.URL (absolute urls only). Note that a Value
+// is always a valid string, which is therefore not reported. An empty Value
+// results in no types.
+func Detect(v Value) []reflect.Type {
+	if v.IsEmpty() {
+		return nil
+	}
+
+	var res []reflect.Type
+	for _, d := range detectors {
+		if d.parse(v) {
+			res = append(res, d.typ)
+		}
+	}
+	return res
+}
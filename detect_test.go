@@ -0,0 +1,43 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	var (
+		boolType    = reflect.TypeOf(false)
+		intType     = reflect.TypeOf(0)
+		uintType    = reflect.TypeOf(uint(0))
+		floatType   = reflect.TypeOf(float64(0))
+		complexType = reflect.TypeOf(complex128(0))
+	)
+
+	tests := map[Value][]reflect.Type{
+		"":                     nil,
+		"foo":                  nil,
+		"true":                 {boolType},
+		"1":                    {boolType, intType, uintType, floatType, complexType},
+		"42":                   {intType, uintType, floatType, complexType},
+		"-42":                  {intType, floatType, complexType},
+		"1.5":                  {floatType, complexType},
+		"(1+2i)":               {complexType},
+		"0":                    {boolType, intType, uintType, floatType, complexType, durationType},
+		"1h30m":                {durationType},
+		"2024-01-02T15:04:05Z": {timeType},
+		"https://example.com":  {urlType},
+		"/relative/path":       nil,
+	}
+	for input, want := range tests {
+		t.Run(input.String(), func(t *testing.T) {
+			assert.Equal(t, want, Detect(input))
+		})
+	}
+}
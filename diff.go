@@ -0,0 +1,128 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// Differences contains the keys which are added, removed or changed between
+// two sets of values, see Diff.
+type Differences struct {
+	// Added contains the keys which only exist in b, their Old Value is empty.
+	Added []Change
+	// Removed contains the keys which only exist in a, their New Value is
+	// empty.
+	Removed []Change
+	// Changed contains the keys which exist in both a and b but with
+	// different values.
+	Changed []Change
+}
+
+// IsEmpty indicates if there are no Differences.
+func (d Differences) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff compares a and b using the global Marshaler. See Marshaler.Diff for
+// additional details.
+func Diff(a, b any) (Differences, error) {
+	return marshaler.Diff(a, b)
+}
+
+// Diff compares a and b and returns the keys which are added, removed or
+// changed. Both a and b may be a Source or a struct (or pointer to a struct),
+// and do not need to be of the same type. Struct fields are marshaled with
+// Marshaler so values are compared in their normalized form, whereas the
+// Value(s) of a Source are compared as is. All lists are sorted by key.
+func (m *Marshaler) Diff(a, b any) (Differences, error) {
+	av, err := m.diffEntries(a)
+	if err != nil {
+		return Differences{}, err
+	}
+	bv, err := m.diffEntries(b)
+	if err != nil {
+		return Differences{}, err
+	}
+
+	var res Differences
+	for key, old := range av {
+		if n, ok := bv[key]; !ok {
+			res.Removed = append(res.Removed, Change{Field: old.Field, Key: key, Old: old.Old})
+		} else if n.Old != old.Old {
+			field := n.Field
+			if field == "" {
+				field = old.Field
+			}
+			res.Changed = append(res.Changed, Change{Field: field, Key: key, Old: old.Old, New: n.Old})
+		}
+	}
+	for key, n := range bv {
+		if _, ok := av[key]; !ok {
+			res.Added = append(res.Added, Change{Field: n.Field, Key: key, New: n.Old})
+		}
+	}
+
+	sortChanges(res.Added)
+	sortChanges(res.Removed)
+	sortChanges(res.Changed)
+	return res, nil
+}
+
+// diffEntries returns a Change for each key of v, with Old containing its
+// Value.
+func (m *Marshaler) diffEntries(v any) (map[string]Change, error) {
+	if src, ok := v.(Source); ok {
+		keys := src.Keys()
+		res := make(map[string]Change, len(keys))
+		for _, key := range keys {
+			val, _ := src.Lookup(key)
+			res[key] = Change{Key: key, Old: val}
+		}
+		return res, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New(ErrStructExpected)
+	}
+
+	fields := structFields(rv.Type(), func(typ reflect.Type) bool {
+		return m.Func(typ) != nil
+	})
+	res := make(map[string]Change, len(fields))
+	for _, field := range fields {
+		fm, err := m.forField(field.tag)
+		if err != nil {
+			return nil, errors.WithStack(&FieldError{
+				Field: field.path,
+				Key:   field.key,
+				Err:   err,
+			})
+		}
+		val, err := fm.Marshal(rv.FieldByIndex(field.index))
+		if err != nil {
+			return nil, errors.WithStack(&FieldError{
+				Field: field.path,
+				Key:   field.key,
+				Err:   err,
+			})
+		}
+		res[field.key] = Change{Field: field.path, Key: field.key, Old: val}
+	}
+	return res, nil
+}
+
+func sortChanges(changes []Change) {
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Key < changes[j].Key
+	})
+}
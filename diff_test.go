@@ -0,0 +1,66 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("values", func(t *testing.T) {
+		have, haveErr := Diff(
+			Values{"host": "localhost", "port": "8080", "debug": "true"},
+			Values{"host": "localhost", "port": "9090", "timeout": "5s"},
+		)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, Differences{
+			Added:   []Change{{Key: "timeout", New: "5s"}},
+			Removed: []Change{{Key: "debug", Old: "true"}},
+			Changed: []Change{{Key: "port", Old: "8080", New: "9090"}},
+		}, have)
+	})
+	t.Run("equal", func(t *testing.T) {
+		have, haveErr := Diff(Values{"a": "1"}, Values{"a": "1"})
+		assert.NoError(t, haveErr)
+		assert.True(t, have.IsEmpty())
+	})
+	t.Run("structs", func(t *testing.T) {
+		type config struct {
+			Timeout time.Duration `rawconv:"timeout"`
+			Hosts   []string      `rawconv:"hosts"`
+		}
+
+		have, haveErr := Diff(
+			config{Timeout: 5 * time.Second, Hosts: []string{"a"}},
+			&config{Timeout: 5 * time.Second, Hosts: []string{"a", "b"}},
+		)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, Differences{
+			Changed: []Change{{Field: "Hosts", Key: "hosts", Old: "a", New: "a,b"}},
+		}, have)
+	})
+	t.Run("struct and values", func(t *testing.T) {
+		type config struct {
+			Timeout time.Duration `rawconv:"timeout"`
+		}
+
+		have, haveErr := Diff(Values{"timeout": "5s"}, config{Timeout: 5 * time.Second})
+		assert.NoError(t, haveErr)
+		assert.True(t, have.IsEmpty())
+
+		have, haveErr = Diff(Values{"timeout": "5000ms"}, config{Timeout: 5 * time.Second})
+		assert.NoError(t, haveErr)
+		assert.Equal(t, Differences{
+			Changed: []Change{{Field: "Timeout", Key: "timeout", Old: "5000ms", New: "5s"}},
+		}, have)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, haveErr := Diff(Values{}, 42)
+		assert.ErrorIs(t, haveErr, ErrStructExpected)
+	})
+}
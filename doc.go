@@ -17,8 +17,9 @@ Out of the box, this package supports all logical base types and some common typ
   - complex64, complex128
   - array, slice
   - map
-  - time.Duration
+  - time.Duration, time.Weekday, time.Month
   - url.URL
+  - color.RGBA
   - encoding.TextUnmarshaler, encoding.TextMarshaler
 
 # Array, slice and map conversions
@@ -33,11 +34,21 @@ Unmarshaler. This is also done for keys of maps.
 
 > Nested arrays, slices and maps are not supported.
 
+# Values and sources
+
+Values is a map of raw values indexed by key, and implements the Source
+interface. Multiple Source(s) (defaults, file, environment, flags) can be merged
+with Layered, where layers which are added later take precedence over earlier
+ones. Layered also reports which layer supplied a value.
+
 # Structs
 
-This package does not contain any logic for traversing struct types, because the
-implementation would really depend on the use case. However, it is possible to
-incorporate this package in your own struct unmarshaling logic.
+UnmarshalStruct unmarshals the values of a Source into the fields of a struct.
+The key of a field defaults to its name and can be changed using the
+`rawconv:"key"` struct tag. It reports which fields are actually set, so
+fields which are not provided by the Source can keep their default values.
+For more specific use cases it is possible to incorporate this package in your
+own struct unmarshaling logic.
 
 # Custom types
 
@@ -49,5 +60,13 @@ RegisterUnmarshalFunc.
 If you do not wish to globally expose your MarshalFunc or UnmarshalFunc
 implementations, it is possible to register them to a new Marshaler and/or
 Unmarshaler and use those instances in your application instead.
+
+# Errors
+
+By default, errors are created using github.com/go-pogo/errors, which adds
+stack traces. Build with the rawconv_stderrors tag, e.g.
+"go build -tags rawconv_stderrors", to use the standard library errors and fmt
+packages instead. Other tags have no effect. Use errors.Is with the exported
+Err constants to check errors, which works the same in both cases.
 */
 package rawconv
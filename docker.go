@@ -0,0 +1,77 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+var (
+	// dockerSizeTypes contain the size types and the number of bytes of their
+	// unit.
+	dockerSizeTypes = map[reflect.Type]int64{
+		reflect.TypeOf(Bytes(0)): 1,
+		reflect.TypeOf(KiB(0)):   1 << 10,
+		reflect.TypeOf(MiB(0)):   1 << 20,
+		reflect.TypeOf(GiB(0)):   1 << 30,
+	}
+
+	// dockerSizeUnits are the units of sizes in Docker/compose syntax, which
+	// are always binary.
+	dockerSizeUnits = map[string]uint{
+		"": 0, "b": 0,
+		"k": 10, "kb": 10,
+		"m": 20, "mb": 20,
+		"g": 30, "gb": 30,
+		"t": 40, "tb": 40,
+		"p": 50, "pb": 50,
+	}
+)
+
+// dockerValue normalizes v, in Docker/compose syntax, to a Value which can be
+// parsed as typ. It returns v as is when typ is not a duration or size type,
+// or when v cannot be normalized.
+func dockerValue(v Value, typ reflect.Type) Value {
+	typ = indirectType(typ)
+	if typ == durationType {
+		return Value(strings.ToLower(removeSpaces(v.String())))
+	}
+
+	unit, ok := dockerSizeTypes[typ]
+	if !ok {
+		return v
+	}
+
+	str := strings.ToLower(removeSpaces(v.String()))
+	num, suffix := splitQuantity(str)
+	shift, ok := dockerSizeUnits[suffix]
+	if num == "" || !ok {
+		return v
+	}
+
+	x, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return v
+	}
+
+	x.Mul(x, new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), shift)))
+	x.Quo(x, big.NewRat(unit, 1))
+	if !x.IsInt() {
+		return v
+	}
+	return Value(x.Num().String())
+}
+
+func removeSpaces(str string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, str)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDockerCompat(t *testing.T) {
+	u := NewUnmarshaler(WithDockerCompat())
+
+	tests := map[string]struct {
+		input Value
+		want  any
+	}{
+		"duration":             {input: "1h30m", want: 90 * time.Minute},
+		"duration spaces":      {input: " 1h 30m ", want: 90 * time.Minute},
+		"duration upper":       {input: "1H30M10S", want: 90*time.Minute + 10*time.Second},
+		"duration ptr":         {input: "5 s", want: ptr(5 * time.Second)},
+		"bytes":                {input: "4096", want: Bytes(4096)},
+		"bytes b":              {input: "100b", want: Bytes(100)},
+		"bytes kb":             {input: "2kb", want: Bytes(2048)},
+		"bytes gb lower":       {input: "10gb", want: Bytes(10 << 30)},
+		"bytes m":              {input: "512m", want: Bytes(512 << 20)},
+		"bytes upper spaces":   {input: "1.5 GB", want: Bytes(3 << 29)},
+		"kib":                  {input: "1m", want: KiB(1024)},
+		"mib":                  {input: "2G", want: MiB(2048)},
+		"gib":                  {input: "1t", want: GiB(1024)},
+		"durations in a slice": {input: "1m, 2 M", want: []time.Duration{time.Minute, 2 * time.Minute}},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dest := reflect.New(reflect.TypeOf(tc.want))
+			assert.NoError(t, u.Unmarshal(tc.input, dest))
+			assert.Equal(t, tc.want, dest.Elem().Interface())
+		})
+	}
+
+	errs := map[string]struct {
+		input  Value
+		target any
+	}{
+		"unknown unit":  {input: "10xb", target: new(Bytes)},
+		"not divisible": {input: "1.5k", target: new(KiB)},
+		"duration":      {input: "1 day", target: new(time.Duration)},
+	}
+	for name, tc := range errs {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, u.Unmarshal(tc.input, reflect.ValueOf(tc.target)), ErrParseFailure)
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		var b Bytes
+		assert.ErrorIs(t, Unmarshal("10gb", &b), ErrParseFailure)
+		var d time.Duration
+		assert.ErrorIs(t, Unmarshal("1H", &d), ErrParseFailure)
+	})
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// DurationFormat determines how time.Duration values are formatted and
+// parsed.
+type DurationFormat uint8
+
+const (
+	// DurationDefault formats durations using time.Duration.String and parses
+	// them using time.ParseDuration, e.g. "1m30s".
+	DurationDefault DurationFormat = iota
+	// DurationSeconds formats and parses durations as a (fractional) number
+	// of seconds, e.g. "90" or "1.5".
+	DurationSeconds
+	// DurationMilliseconds formats and parses durations as a (fractional)
+	// number of milliseconds, e.g. "1500".
+	DurationMilliseconds
+)
+
+func (f DurationFormat) unit() (time.Duration, string) {
+	switch f {
+	case DurationSeconds:
+		return time.Second, "s"
+	case DurationMilliseconds:
+		return time.Millisecond, "ms"
+	default:
+		return 0, ""
+	}
+}
+
+// durationValue converts v, a number of units of DurationFormat f, to a Value
+// which can be parsed by time.ParseDuration. It returns v as is when typ is
+// not a duration type.
+func (f DurationFormat) durationValue(v Value, typ reflect.Type) (Value, error) {
+	_, suffix := f.unit()
+	if suffix == "" || v.IsEmpty() || indirectType(typ) != durationType {
+		return v, nil
+	}
+	if !isDecimal(v.String()) {
+		return "", errors.Wrap(errors.Newf("duration `%s` is not a number", v), ErrParseFailure)
+	}
+	return v + Value(suffix), nil
+}
+
+// isDecimal indicates if str is an optionally signed decimal number, e.g.
+// "-1.5".
+func isDecimal(str string) bool {
+	if len(str) > 0 && (str[0] == '-' || str[0] == '+') {
+		str = str[1:]
+	}
+
+	var digits, dots int
+	for _, c := range str {
+		switch {
+		case c >= '0' && c <= '9':
+			digits++
+		case c == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && dots <= 1
+}
+
+// format formats d as a number of units of DurationFormat f, without losing
+// precision.
+func (f DurationFormat) format(d time.Duration) string {
+	unit, _ := f.unit()
+	if unit == 0 {
+		return d.String()
+	}
+
+	var buf strings.Builder
+	if d < 0 {
+		buf.WriteByte('-')
+	}
+
+	// use unsigned integers, so the minimum duration does not overflow
+	n := uint64(d)
+	if d < 0 {
+		n = -n
+	}
+	buf.WriteString(strconv.FormatUint(n/uint64(unit), 10))
+	if frac := n % uint64(unit); frac != 0 {
+		digits := len(strconv.FormatUint(uint64(unit), 10)) - 1
+		str := strconv.FormatUint(frac, 10)
+		buf.WriteByte('.')
+		buf.WriteString(strings.Repeat("0", digits-len(str)))
+		buf.WriteString(strings.TrimRight(str, "0"))
+	}
+	return buf.String()
+}
+
+// marshal formats val, which is a (pointer to a) time.Duration,
+// according to DurationFormat f.
+func (f DurationFormat) marshal(val reflect.Value) string {
+	for val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return ""
+		}
+		val = val.Elem()
+	}
+	return f.format(time.Duration(val.Int()))
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDurationFormat(t *testing.T) {
+	tests := map[string]struct {
+		format DurationFormat
+		input  time.Duration
+		want   Value
+	}{
+		"default":            {format: DurationDefault, input: 90 * time.Second, want: "1m30s"},
+		"seconds":            {format: DurationSeconds, input: 90 * time.Second, want: "90"},
+		"fractional seconds": {format: DurationSeconds, input: 1500 * time.Millisecond, want: "1.5"},
+		"nanoseconds":        {format: DurationSeconds, input: time.Second + time.Nanosecond, want: "1.000000001"},
+		"negative seconds":   {format: DurationSeconds, input: -2500 * time.Millisecond, want: "-2.5"},
+		"zero seconds":       {format: DurationSeconds, input: 0, want: "0"},
+		"milliseconds":       {format: DurationMilliseconds, input: 1500 * time.Millisecond, want: "1500"},
+		"microseconds":       {format: DurationMilliseconds, input: 1500 * time.Microsecond, want: "1.5"},
+		"max seconds":        {format: DurationSeconds, input: math.MaxInt64, want: "9223372036.854775807"},
+		"min seconds":        {format: DurationSeconds, input: math.MinInt64, want: "-9223372036.854775808"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := Marshal(tc.input, WithDurationFormat(tc.format))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, have)
+
+			var d time.Duration
+			assert.NoError(t, Unmarshal(have, &d, WithDurationFormat(tc.format)))
+			assert.Equal(t, tc.input, d)
+		})
+	}
+
+	t.Run("pointer", func(t *testing.T) {
+		d := 2 * time.Second
+		have, err := Marshal(&d, WithDurationFormat(DurationSeconds))
+		assert.NoError(t, err)
+		assert.Equal(t, Value("2"), have)
+
+		have, err = Marshal((*time.Duration)(nil), WithDurationFormat(DurationSeconds))
+		assert.NoError(t, err)
+		assert.Equal(t, Value(""), have)
+	})
+	t.Run("slice", func(t *testing.T) {
+		var have []time.Duration
+		assert.NoError(t, Unmarshal("1,2.5", &have, WithDurationFormat(DurationSeconds)))
+		assert.Equal(t, []time.Duration{time.Second, 2500 * time.Millisecond}, have)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		var have time.Duration
+		for _, input := range []Value{"1m", "1.2.3", "-", "--1", "1e3"} {
+			assert.ErrorIs(t, Unmarshal(input, &have, WithDurationFormat(DurationSeconds)), ErrParseFailure, input)
+		}
+	})
+}
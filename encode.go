@@ -6,10 +6,12 @@ package rawconv
 
 import (
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 const ErrMarshalNested errors.Msg = "cannot marshal nested array/slice/map"
@@ -26,12 +28,45 @@ const ErrMarshalNested errors.Msg = "cannot marshal nested array/slice/map"
 //   - complex64, complex128
 //   - array, slice
 //   - map
-//   - time.Duration
+//   - time.Duration, time.Weekday, time.Month
 //   - url.URL
+//   - color.RGBA
 //
-// Use RegisterMarshalFunc to add additional (custom) types.
-func Marshal(v any) (Value, error) {
-	return marshaler.Marshal(reflect.ValueOf(v))
+// Floats and complex numbers are formatted using the smallest number of digits
+// necessary to represent the value exactly. Special values are formatted as
+// "NaN", "+Inf" and "-Inf". Unmarshaling these results in the original value,
+// which guarantees a round-trip for scalar floats and complex numbers. Use
+// Options.RejectNaN and Options.RejectInf to reject these special values
+// instead.
+//
+// Arrays, slices and maps are not guaranteed to round-trip, e.g. when their
+// items contain a separator or surrounding whitespace, or a slice is nil
+// instead of empty. Use Options.QuoteItems (or Options.EscapeSeparators) when
+// items may contain arbitrary text.
+//
+// Use RegisterMarshalFunc to add additional (custom) types. Option(s) override
+// the Options of the global Marshaler for this call only.
+// Registered funcs take precedence over interfaces, which take precedence over
+// the type's kind. Use Options.Stages to change this order, see DefaultStages.
+func Marshal(v any, opts ...Option) (Value, error) {
+	return marshaler.Marshal(reflect.ValueOf(v), opts...)
+}
+
+// MarshalReflect formats the value of reflect.Value v to a raw string Value.
+// Unlike Marshal, v is not boxed in an interface, which preserves its
+// addressability. See Marshal for additional details.
+func MarshalReflect(v reflect.Value, opts ...Option) (Value, error) {
+	return marshaler.Marshal(v, opts...)
+}
+
+// MustMarshal is like Marshal but panics when an error occurs. It simplifies
+// the initialization of package level variables and use within tests.
+func MustMarshal(v any, opts ...Option) Value {
+	val, err := Marshal(v, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return val
 }
 
 type MarshalFunc func(v any) (string, error)
@@ -52,34 +87,92 @@ type Marshaler struct {
 }
 
 // Register the MarshalFunc for typ but only for this Marshaler.
+// It panics when the Marshaler is frozen.
 func (m *Marshaler) Register(typ reflect.Type, fn MarshalFunc) *Marshaler {
 	m.register.add(typ, fn)
 	return m
 }
 
+// Freeze the Marshaler so any further call to Register panics. A frozen
+// Marshaler can safely be shared across goroutines without additional
+// synchronization, as long as its Options are not modified and no funcs are
+// registered globally anymore.
+func (m *Marshaler) Freeze() *Marshaler {
+	m.register.frozen = true
+	return m
+}
+
+// Frozen indicates if the Marshaler is frozen using Freeze.
+func (m *Marshaler) Frozen() bool { return m.register.frozen }
+
+// Scope takes a snapshot of the MarshalFunc funcs which are registered with
+// this Marshaler. The returned func restores this snapshot, removing or
+// reverting any funcs registered in the meantime. The frozen state is not part
+// of the snapshot, a Marshaler frozen within the scope remains frozen.
+func (m *Marshaler) Scope() (restore func()) {
+	snapshot := m.register.clone()
+	return func() { m.register.restore(snapshot) }
+}
+
 // Func returns the (globally) registered MarshalFunc for reflect.Type typ or
 // nil if there is none registered with Register or RegisterMarshalFunc.
 func (m *Marshaler) Func(typ reflect.Type) MarshalFunc {
-	if m.register.initialized() {
-		if fn := m.register.find(typ); fn != nil {
-			return fn
-		}
-	}
-	// fallback to global marshaler
-	return marshaler.register.find(typ)
+	fn, _ := m.lookup(typ)
+	return fn
+}
+
+func (m *Marshaler) lookup(typ reflect.Type) (MarshalFunc, funcMatch) {
+	return resolve(m.stages(), &m.register, &marshaler.register, typ)
 }
 
 // Marshal returns the string representation of the value.
 // If the underlying reflect.Value is nil, it returns an empty string.
-func (m *Marshaler) Marshal(val reflect.Value) (Value, error) {
+// Option(s) override the Options of the Marshaler for this call only.
+func (m *Marshaler) Marshal(val reflect.Value, opts ...Option) (_ Value, err error) {
+	m = m.with(opts)
+	if m.Hooks != nil {
+		defer hookMarshal(m.Hooks, val, time.Now(), &err)
+	}
+
 	str, err := m.marshal(val, false)
-	return Value(str), err
+	if err != nil {
+		return "", err
+	}
+	if m.EscapeNewlines {
+		str = escapeNewlines(str)
+	}
+	if m.Compress {
+		if str, err = m.compress(str); err != nil {
+			return "", err
+		}
+	}
+	if m.ShellQuote {
+		str = shellQuote(str)
+	}
+	return Value(str), nil
 }
 
-func (m *Marshaler) marshal(val reflect.Value, nested bool) (string, error) {
-	if fn := m.Func(val.Type()); fn != nil {
+func (m *Marshaler) marshal(val reflect.Value, nested bool) (str string, err error) {
+	if m.DurationFormat != DurationDefault && indirectType(val.Type()) == durationType {
+		return m.DurationFormat.marshal(val), nil
+	}
+
+	fn, match := m.lookup(val.Type())
+	if m.Logger != nil {
+		debugLookup(m.Logger, "marshal", val.Type(), fn != nil, match)
+	}
+	if fn != nil {
+		if m.RecoverPanics {
+			defer recoverPanic(&err, val.Type(), val)
+		}
+		if match.registered.Kind() == reflect.Ptr {
+			return fn.execPointer(val, match.registered)
+		}
 		return fn.exec(val)
 	}
+	if !match.kind {
+		return "", m.unsupported(val.Type())
+	}
 
 	ot := val.Type()
 	for val.Kind() == reflect.Ptr {
@@ -97,48 +190,45 @@ func (m *Marshaler) marshal(val reflect.Value, nested bool) (string, error) {
 		return strconv.FormatBool(val.Bool()), nil
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		return strconv.FormatInt(val.Int(), 10), nil
+		return strconv.FormatInt(val.Int(), m.intBase(true)), nil
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		return strconv.FormatUint(val.Uint(), 10), nil
+		return strconv.FormatUint(val.Uint(), m.intBase(true)), nil
 
 	case reflect.Float32, reflect.Float64:
-		return strconv.FormatFloat(val.Float(), 'g', -1, 64), nil
+		if err := m.checkFloat(val.Float()); err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(val.Float(), m.floatFormat(), -1, val.Type().Bits()), nil
 
 	case reflect.Complex64, reflect.Complex128:
-		return strconv.FormatComplex(val.Complex(), 'g', -1, 128), nil
+		if err := m.checkComplex(val.Complex()); err != nil {
+			return "", err
+		}
+		return formatComplex(val.Complex(), m.floatFormat(), val.Type().Bits(), m.ComplexFormat), nil
 
 	case reflect.Array, reflect.Slice:
 		if nested {
 			return "", errors.New(ErrMarshalNested)
 		}
 
-		sep := m.itemSeparator()
-
-		var buf strings.Builder
+		items := make([]string, 0, val.Len())
 		for i := 0; i < val.Len(); i++ {
 			v, err := m.marshal(val.Index(i), true)
 			if err != nil {
 				return "", err
 			}
-
-			if i > 0 {
-				buf.WriteString(sep)
-			}
-			buf.WriteString(v)
+			items = append(items, v)
 		}
-		return buf.String(), nil
+		return m.joinItems(items)
 
 	case reflect.Map:
 		if nested {
 			return "", errors.New(ErrMarshalNested)
 		}
 
-		sep1 := m.keyValueSeparator()
-		sep2 := m.itemSeparator()
-
-		var buf strings.Builder
-		var firstDone bool
+		// sort the key-value pairs by key, so the output is deterministic
+		pairs := make([][2]string, 0, val.Len())
 		for iter := val.MapRange(); iter.Next(); {
 			v, err := m.marshal(iter.Value(), true)
 			if err != nil {
@@ -148,21 +238,84 @@ func (m *Marshaler) marshal(val reflect.Value, nested bool) (string, error) {
 			if err != nil {
 				return "", err
 			}
+			pairs = append(pairs, [2]string{k, v})
+		}
+		sort.Slice(pairs, func(i, j int) bool { return pairs[i][0] < pairs[j][0] })
+		return m.joinPairs(pairs)
 
-			if firstDone {
-				buf.WriteString(sep2)
-			}
+	default:
+		return "", m.unsupported(ot)
+	}
+}
+
+// with returns a Marshaler with the Options of m, modified by opts. It returns
+// m when there are no opts.
+func (m *Marshaler) with(opts []Option) *Marshaler {
+	if len(opts) == 0 {
+		return m
+	}
+	res := Marshaler{Options: m.Options, register: m.register}
+	res.Options.apply(opts)
+	return &res
+}
+
+// forField returns a Marshaler with the Options of m, overridden by the options
+// of tag t. It returns m when t does not override any Options.
+func (m *Marshaler) forField(t tag) (*Marshaler, error) {
+	opts, ok, err := t.applyOptions(m.Options)
+	if err != nil || !ok {
+		return m, err
+	}
+	return &Marshaler{Options: opts, register: m.register}, nil
+}
+
+// joinItems joins the items of an array or slice using Options.Tokenizer,
+// or the items separator when there is none.
+func (m *Marshaler) joinItems(items []string) (string, error) {
+	if m.Tokenizer != nil {
+		return m.Tokenizer.Join(items)
+	}
 
-			buf.WriteString(k)
-			buf.WriteString(sep1)
-			buf.WriteString(v)
-			firstDone = true
+	sep := m.itemSeparator()
+
+	var buf strings.Builder
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteString(sep)
 		}
-		return buf.String(), nil
+		buf.WriteString(m.formatItem(item, sep))
+	}
+	return buf.String(), nil
+}
 
-	default:
-		return "", errors.WithStack(&UnsupportedTypeError{Type: ot})
+// joinPairs joins the key-value pairs of a map using Options.Tokenizer, or
+// the key-value and items separators when there is none.
+func (m *Marshaler) joinPairs(pairs [][2]string) (string, error) {
+	if m.Tokenizer != nil {
+		return m.Tokenizer.JoinPairs(pairs)
 	}
+
+	sep1 := m.keyValueSeparator()
+	sep2 := m.itemSeparator()
+
+	var buf strings.Builder
+	for i, pair := range pairs {
+		if i > 0 {
+			buf.WriteString(sep2)
+		}
+
+		buf.WriteString(m.formatItem(pair[0], sep1, sep2))
+		buf.WriteString(sep1)
+		buf.WriteString(m.formatItem(pair[1], sep1, sep2))
+	}
+	return buf.String(), nil
+}
+
+func (m *Marshaler) unsupported(typ reflect.Type) error {
+	if m.Logger != nil {
+		m.Logger.Debug("rawconv: marshal unsupported type", "type", typ.String())
+	}
+	return errors.WithStack(&UnsupportedTypeError{Type: typ})
 }
 
 // Exec executes the MarshalFunc for the given reflect.Value.
@@ -171,6 +324,26 @@ func (fn MarshalFunc) Exec(val reflect.Value) (Value, error) {
 	return Value(str), err
 }
 
+// execPointer executes the MarshalFunc, which is registered for pointer type
+// typ, with the typ value val (eventually) points to.
+func (fn MarshalFunc) execPointer(val reflect.Value, typ reflect.Type) (string, error) {
+	for val.Type() != typ {
+		if val.IsNil() {
+			return "", nil
+		}
+		val = val.Elem()
+	}
+	if val.IsNil() {
+		return "", nil
+	}
+
+	str, err := fn(val.Interface())
+	if err != nil {
+		return str, errors.WithStack(err)
+	}
+	return str, nil
+}
+
 func (fn MarshalFunc) exec(val reflect.Value) (string, error) {
 	for val.Kind() == reflect.Ptr {
 		if val.IsNil() {
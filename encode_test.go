@@ -5,6 +5,8 @@
 package rawconv
 
 import (
+	"image/color"
+	"math"
 	"net"
 	"net/url"
 	"reflect"
@@ -12,7 +14,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -59,15 +61,41 @@ func TestMarshal(t *testing.T) {
 		"float": {{
 			input: 123.456,
 			want:  Value("123.456"),
+		}, {
+			input: float32(0.1),
+			want:  Value("0.1"),
+		}, {
+			input: math.NaN(),
+			want:  Value("NaN"),
+		}, {
+			input: math.Inf(1),
+			want:  Value("+Inf"),
+		}, {
+			input: math.Inf(-1),
+			want:  Value("-Inf"),
 		}},
 		"complex": {{
 			input: 123.456 + 789.123i,
 			want:  Value("(123.456+789.123i)"),
+		}, {
+			input: complex64(0.1 + 0.2i),
+			want:  Value("(0.1+0.2i)"),
+		}, {
+			input: complex(math.NaN(), math.Inf(-1)),
+			want:  Value("(NaN-Infi)"),
 		}},
 		"duration": {{
 			input: (time.Second * 70) + (time.Millisecond * 123),
 			want:  Value("1m10.123s"),
 		}},
+		"weekday": {{
+			input: time.Friday,
+			want:  Value("Friday"),
+		}},
+		"month": {{
+			input: time.August,
+			want:  Value("August"),
+		}},
 		"time": {{
 			input: time.Date(1997, 8, 29, 13, 37, 1, 0, time.UTC),
 			want:  Value("1997-08-29T13:37:01Z"),
@@ -83,6 +111,10 @@ func TestMarshal(t *testing.T) {
 			input: net.IPv4(192, 168, 1, 1),
 			want:  Value("192.168.1.1"),
 		}},
+		"color": {{
+			input: color.RGBA{R: 0xff, G: 0x80, A: 0x80},
+			want:  Value("#ff800080"),
+		}},
 		"array": {{
 			input: [3]int{1, 2, 3},
 			want:  Value("1,2,3"),
@@ -140,6 +172,93 @@ func TestMarshal(t *testing.T) {
 	}
 }
 
+func TestMarshal_mapSorted(t *testing.T) {
+	have, haveErr := Marshal(map[string]int{"c": 3, "a": 1, "b": 2})
+	assert.Equal(t, Value("a=1,b=2,c=3"), have)
+	assert.NoError(t, haveErr)
+}
+
+func TestMarshaler_Reject(t *testing.T) {
+	var m Marshaler
+	m.RejectNaN = true
+	m.RejectInf = true
+
+	tests := map[string]struct {
+		input   any
+		wantErr error
+	}{
+		"nan":          {input: math.NaN(), wantErr: ErrNaNNotAllowed},
+		"inf":          {input: math.Inf(1), wantErr: ErrInfNotAllowed},
+		"-inf float32": {input: float32(math.Inf(-1)), wantErr: ErrInfNotAllowed},
+		"nan complex":  {input: complex(1, math.NaN()), wantErr: ErrNaNNotAllowed},
+		"inf slice":    {input: []float64{1, math.Inf(1)}, wantErr: ErrInfNotAllowed},
+		"finite":       {input: 1.5},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, haveErr := m.Marshal(reflect.ValueOf(tc.input))
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, haveErr, tc.wantErr)
+			} else {
+				assert.NoError(t, haveErr)
+			}
+		})
+	}
+}
+
+func TestMarshaler_NoExponent(t *testing.T) {
+	var m Marshaler
+	m.NoExponent = true
+
+	tests := map[string]struct {
+		input any
+		want  Value
+	}{
+		"large":   {input: 1e6, want: "1000000"},
+		"small":   {input: 1.5e-7, want: "0.00000015"},
+		"float32": {input: float32(2.5e10), want: "25000000000"},
+		"complex": {input: 1e6 + 2e-3i, want: "(1000000+0.002i)"},
+		"inf":     {input: math.Inf(1), want: "+Inf"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, haveErr := m.Marshal(reflect.ValueOf(tc.input))
+			assert.Equal(t, tc.want, have)
+			assert.NoError(t, haveErr)
+		})
+	}
+}
+
+func TestMarshaler_IntBase(t *testing.T) {
+	m := Marshaler{Options: Options{IntBase: 16}}
+	have, haveErr := m.Marshal(reflect.ValueOf([]int{255, -16}))
+	assert.Equal(t, Value("ff,-10"), have)
+	assert.NoError(t, haveErr)
+
+	u := Unmarshaler{Options: Options{IntBase: 16}}
+	var dest []uint8
+	assert.NoError(t, u.Unmarshal("ff,10", reflect.ValueOf(&dest)))
+	assert.Equal(t, []uint8{255, 16}, dest)
+}
+
+func TestMarshalReflect(t *testing.T) {
+	have, haveErr := MarshalReflect(reflect.ValueOf(time.Second * 10))
+	assert.Equal(t, Value("10s"), have)
+	assert.NoError(t, haveErr)
+}
+
+func TestMustMarshal(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		assert.Equal(t, Value("10s"), MustMarshal(time.Second*10))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		assert.Panics(t, func() { MustMarshal(make(chan struct{})) })
+	})
+	t.Run("options", func(t *testing.T) {
+		assert.Equal(t, Value("ff"), MustMarshal(255, WithIntBase(16)))
+	})
+}
+
 func TestMarshaler_Func(t *testing.T) {
 	var m Marshaler
 	m.Register(reflect.TypeOf(t), func(any) (string, error) {
@@ -148,6 +267,25 @@ func TestMarshaler_Func(t *testing.T) {
 	testRegisterFind(t, 1, func(typ reflect.Type) any { return m.Func(typ) })
 }
 
+func TestMarshaler_Register_pointer(t *testing.T) {
+	shared := new(int)
+	var m Marshaler
+	m.Register(reflect.TypeOf(shared), func(v any) (string, error) {
+		if v.(*int) == shared {
+			return "shared", nil
+		}
+		return "other", nil
+	})
+
+	have, err := m.Marshal(reflect.ValueOf(&shared))
+	assert.NoError(t, err)
+	assert.Equal(t, Value("shared"), have)
+
+	have, err = m.Marshal(reflect.ValueOf((*int)(nil)))
+	assert.NoError(t, err)
+	assert.Equal(t, Value(""), have)
+}
+
 func TestMarshalFunc_Exec(t *testing.T) {
 	wantErr := errors.New("some err")
 	_, haveErr := MarshalFunc(func(v any) (string, error) {
@@ -0,0 +1,114 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const (
+	ErrInvalidEnvelope errors.Msg = "invalid encrypted envelope"
+	ErrDecryptFailure  errors.Msg = "failed to decrypt"
+)
+
+// EnvelopePrefix is the prefix of an encrypted envelope, followed by its
+// base64 encoded nonce and ciphertext, e.g. "enc:v1:<nonce>:<ciphertext>".
+const EnvelopePrefix = "enc:v1:"
+
+// KeyProvider provides the key which is used to encrypt and decrypt
+// envelopes. The key must be 16, 24 or 32 bytes long to select AES-128,
+// AES-192 or AES-256.
+type KeyProvider interface {
+	Key() ([]byte, error)
+}
+
+// KeyProviderFunc is a func which implements KeyProvider.
+type KeyProviderFunc func() ([]byte, error)
+
+func (fn KeyProviderFunc) Key() ([]byte, error) { return fn() }
+
+// StaticKey is a KeyProvider which always returns the same key.
+type StaticKey []byte
+
+func (k StaticKey) Key() ([]byte, error) { return k, nil }
+
+// IsEnvelope indicates if v is an encrypted envelope.
+func IsEnvelope(v Value) bool { return strings.HasPrefix(string(v), EnvelopePrefix) }
+
+// Encrypt encrypts v using AES-GCM with the key of kp and returns it as an
+// envelope in the form of "enc:v1:<nonce>:<ciphertext>".
+func Encrypt(kp KeyProvider, v Value) (Value, error) {
+	aead, err := newAEAD(kp)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	enc := base64.RawURLEncoding
+	ciphertext := aead.Seal(nil, nonce, []byte(v), []byte(EnvelopePrefix))
+	return Value(EnvelopePrefix + enc.EncodeToString(nonce) + ":" + enc.EncodeToString(ciphertext)), nil
+}
+
+// Decrypt decrypts envelope v, which is created with Encrypt, using the key of
+// kp.
+func Decrypt(kp KeyProvider, v Value) (Value, error) {
+	if !IsEnvelope(v) {
+		return "", errors.New(ErrInvalidEnvelope)
+	}
+
+	nonce, ciphertext, ok := strings.Cut(string(v[len(EnvelopePrefix):]), ":")
+	if !ok {
+		return "", errors.New(ErrInvalidEnvelope)
+	}
+
+	// strict decoding rejects non-canonical encodings of the same bytes
+	enc := base64.RawURLEncoding.Strict()
+	n, err := enc.DecodeString(nonce)
+	if err != nil {
+		return "", errors.Wrap(err, ErrInvalidEnvelope)
+	}
+	c, err := enc.DecodeString(ciphertext)
+	if err != nil {
+		return "", errors.Wrap(err, ErrInvalidEnvelope)
+	}
+
+	aead, err := newAEAD(kp)
+	if err != nil {
+		return "", err
+	}
+	if len(n) != aead.NonceSize() {
+		return "", errors.New(ErrInvalidEnvelope)
+	}
+
+	plaintext, err := aead.Open(nil, n, c, []byte(EnvelopePrefix))
+	if err != nil {
+		return "", errors.New(ErrDecryptFailure)
+	}
+	return Value(plaintext), nil
+}
+
+func newAEAD(kp KeyProvider) (cipher.AEAD, error) {
+	key, err := kp.Key()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncrypt(t *testing.T) {
+	key := StaticKey("0123456789abcdef0123456789abcdef")
+
+	t.Run("round trip", func(t *testing.T) {
+		env, err := Encrypt(key, "s3cr3t")
+		assert.NoError(t, err)
+		assert.True(t, IsEnvelope(env))
+		assert.NotContains(t, env.String(), "s3cr3t")
+
+		have, err := Decrypt(key, env)
+		assert.NoError(t, err)
+		assert.Equal(t, Value("s3cr3t"), have)
+	})
+	t.Run("unique nonce", func(t *testing.T) {
+		a, _ := Encrypt(key, "s3cr3t")
+		b, _ := Encrypt(key, "s3cr3t")
+		assert.NotEqual(t, a, b)
+	})
+	t.Run("invalid key", func(t *testing.T) {
+		_, err := Encrypt(StaticKey("short"), "s3cr3t")
+		assert.Error(t, err)
+	})
+
+	env, err := Encrypt(key, "s3cr3t")
+	assert.NoError(t, err)
+
+	// flip a single bit of the ciphertext
+	i := strings.LastIndexByte(env.String(), ':') + 1
+	ciphertext, err := base64.RawURLEncoding.DecodeString(env.String()[i:])
+	assert.NoError(t, err)
+	ciphertext[0] ^= 1
+	tampered := env[:i] + Value(base64.RawURLEncoding.EncodeToString(ciphertext))
+
+	tests := map[string]struct {
+		key     KeyProvider
+		input   Value
+		wantErr error
+	}{
+		"wrong key": {
+			key:     StaticKey("fedcba9876543210fedcba9876543210"),
+			input:   env,
+			wantErr: ErrDecryptFailure,
+		},
+		"tampered": {
+			key:     key,
+			input:   tampered,
+			wantErr: ErrDecryptFailure,
+		},
+		"no envelope": {
+			key:     key,
+			input:   "s3cr3t",
+			wantErr: ErrInvalidEnvelope,
+		},
+		"missing ciphertext": {
+			key:     key,
+			input:   EnvelopePrefix + "abc",
+			wantErr: ErrInvalidEnvelope,
+		},
+		"invalid base64": {
+			key:     key,
+			input:   EnvelopePrefix + "!!:!!",
+			wantErr: ErrInvalidEnvelope,
+		},
+		"non-canonical base64": {
+			key:     key,
+			input:   EnvelopePrefix + "AB:" + env[i:],
+			wantErr: ErrInvalidEnvelope,
+		},
+		"invalid nonce": {
+			key:     key,
+			input:   EnvelopePrefix + "AAAA:" + env[i:],
+			wantErr: ErrInvalidEnvelope,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Decrypt(tc.key, tc.input)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestUnmarshaler_KeyProvider(t *testing.T) {
+	key := StaticKey("0123456789abcdef")
+	env, err := Encrypt(key, "8080")
+	assert.NoError(t, err)
+
+	t.Run("decrypt", func(t *testing.T) {
+		var have int
+		assert.NoError(t, NewUnmarshaler(WithKeyProvider(key)).Unmarshal(env, reflect.ValueOf(&have)))
+		assert.Equal(t, 8080, have)
+	})
+	t.Run("plain value", func(t *testing.T) {
+		var have int
+		assert.NoError(t, Unmarshal("8080", &have, WithKeyProvider(key)))
+		assert.Equal(t, 8080, have)
+	})
+	t.Run("without provider", func(t *testing.T) {
+		var have string
+		assert.NoError(t, Unmarshal(env, &have))
+		assert.Equal(t, env.String(), have)
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		var have int
+		err := Unmarshal(env, &have, WithKeyProvider(StaticKey("fedcba9876543210")))
+		assert.ErrorIs(t, err, ErrDecryptFailure)
+	})
+}
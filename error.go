@@ -5,10 +5,11 @@
 package rawconv
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 type UnsupportedTypeError struct {
@@ -25,9 +26,33 @@ func (e *UnsupportedTypeError) Error() string {
 	return "type `" + e.Type.String() + "` is not supported"
 }
 
+// PanicError is returned when a registered MarshalFunc or UnmarshalFunc
+// panics and Options.RecoverPanics is enabled.
+type PanicError struct {
+	// Type is the type the func is registered for.
+	Type reflect.Type
+	// Value is the raw Value passed to an UnmarshalFunc, or the value passed
+	// to a MarshalFunc.
+	Value any
+	// Recovered is the value returned by recover.
+	Recovered any
+}
+
+func (e *PanicError) Error() string {
+	return "panic in func for type `" + e.Type.String() + "`: " + fmt.Sprint(e.Recovered)
+}
+
+// Unwrap returns the recovered value when it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Recovered.(error)
+	return err
+}
+
 const (
 	ErrParseFailure      errors.Msg = "failed to parse"
 	ErrValidationFailure errors.Msg = "failed to validate"
+	ErrNaNNotAllowed     errors.Msg = "NaN is not allowed"
+	ErrInfNotAllowed     errors.Msg = "infinity is not allowed"
 )
 
 func errKind(err error) error {
@@ -41,3 +66,20 @@ func errKind(err error) error {
 	}
 	return nil
 }
+
+// recoverPanic recovers a panic and sets it as *PanicError to err.
+func recoverPanic(err *error, typ reflect.Type, val any) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	typ = indirectType(typ)
+	if rv, ok := val.(reflect.Value); ok {
+		if rv.CanInterface() {
+			val = rv.Interface()
+		} else {
+			val = nil
+		}
+	}
+	*err = errors.WithStack(&PanicError{Type: typ, Value: val, Recovered: r})
+}
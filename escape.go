@@ -0,0 +1,147 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultEscapeChar is the escape character that is used when
+// Options.EscapeSeparators is enabled and Options.EscapeChar is not set.
+const DefaultEscapeChar = '\\'
+
+// escapeChar returns the escape character, or 0 when separators are not
+// escaped.
+func (o Options) escapeChar() rune {
+	if !o.EscapeSeparators {
+		return 0
+	}
+	if o.EscapeChar == 0 {
+		return DefaultEscapeChar
+	}
+	return o.EscapeChar
+}
+
+// escapeSeparators prefixes each occurrence of esc and seps within str with
+// esc.
+func escapeSeparators(str string, esc rune, seps ...string) string {
+	if esc == 0 {
+		return str
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(str))
+outer:
+	for i := 0; i < len(str); {
+		r, n := utf8.DecodeRuneInString(str[i:])
+		if r == esc {
+			sb.WriteRune(esc)
+			sb.WriteRune(esc)
+			i += n
+			continue
+		}
+		for _, sep := range seps {
+			if sep != "" && strings.HasPrefix(str[i:], sep) {
+				sb.WriteRune(esc)
+				sb.WriteString(sep)
+				i += len(sep)
+				continue outer
+			}
+		}
+		sb.WriteString(str[i : i+n])
+		i += n
+	}
+	return sb.String()
+}
+
+// unescape removes all escape characters esc from str, keeping the characters
+// they escape.
+func unescape(str string, esc rune) string {
+	if esc == 0 || !strings.ContainsRune(str, esc) {
+		return str
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(str))
+	for i := 0; i < len(str); {
+		r, n := utf8.DecodeRuneInString(str[i:])
+		i += n
+		if r == esc && i < len(str) {
+			r, n = utf8.DecodeRuneInString(str[i:])
+			i += n
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// indexEscaped returns the index of the first occurrence of sep within str,
+// which is not escaped by esc, or -1 when there is none.
+func indexEscaped(str, sep string, esc rune) int {
+	if esc == 0 {
+		return strings.Index(str, sep)
+	}
+	for i := 0; i < len(str); {
+		r, n := utf8.DecodeRuneInString(str[i:])
+		if r == esc {
+			// skip the escaped character
+			i += n
+			_, n = utf8.DecodeRuneInString(str[i:])
+			i += n
+			continue
+		}
+		if strings.HasPrefix(str[i:], sep) {
+			return i
+		}
+		i += n
+	}
+	return -1
+}
+
+// splitEscaped splits str by sep, ignoring separators which are escaped by
+// esc. The escape characters are kept within the resulting parts.
+func splitEscaped(str, sep string, esc rune) []string {
+	if esc == 0 {
+		return strings.Split(str, sep)
+	}
+
+	var res []string
+	for {
+		i := indexEscaped(str, sep, esc)
+		if i < 0 {
+			return append(res, str)
+		}
+		res = append(res, str[:i])
+		str = str[i+len(sep):]
+	}
+}
+
+// cutEscaped is like strings.Cut, but ignores a separator which is escaped by
+// esc.
+func cutEscaped(str, sep string, esc rune) (before, after string, found bool) {
+	if i := indexEscaped(str, sep, esc); i >= 0 {
+		return str[:i], str[i+len(sep):], true
+	}
+	return str, "", false
+}
+
+// countEscaped returns the number of occurrences of sep within str, which are
+// not escaped by esc.
+func countEscaped(str, sep string, esc rune) int {
+	if esc == 0 {
+		return strings.Count(str, sep)
+	}
+
+	var n int
+	for {
+		i := indexEscaped(str, sep, esc)
+		if i < 0 {
+			return n
+		}
+		n++
+		str = str[i+len(sep):]
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeSeparators(t *testing.T) {
+	tests := map[string]struct {
+		opts  []Option
+		input any
+		want  Value
+	}{
+		"slice": {
+			opts:  []Option{WithEscapeSeparators(0)},
+			input: []string{"a,b", `c\d`, "e"},
+			want:  `a\,b,c\\d,e`,
+		},
+		"map": {
+			opts:  []Option{WithEscapeSeparators(0)},
+			input: map[string]string{"k=1": "v,1", "k2": "a=b"},
+			want:  `k2=a\=b,k\=1=v\,1`,
+		},
+		"custom char": {
+			opts:  []Option{WithEscapeSeparators('^'), WithSeparators(";", "")},
+			input: []string{"a;b", `c^\d`},
+			want:  `a^;b;c^^\d`,
+		},
+		"multi char separator": {
+			opts:  []Option{WithEscapeSeparators(0), WithSeparators("||", "")},
+			input: []string{"a||b", "c|d"},
+			want:  `a\||b||c|d`,
+		},
+		"with newlines": {
+			opts:  []Option{WithEscapeSeparators(0), WithEscapeNewlines()},
+			input: []string{"a,\nb", "c"},
+			want:  `a\\,\nb,c`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := NewMarshaler(tc.opts...)
+			have, err := m.Marshal(reflect.ValueOf(tc.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, have)
+
+			u := NewUnmarshaler(tc.opts...)
+			dest := reflect.New(reflect.TypeOf(tc.input))
+			assert.NoError(t, u.Unmarshal(have, dest))
+			assert.Equal(t, tc.input, dest.Elem().Interface())
+		})
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		var list []string
+		assert.NoError(t, Unmarshal(`a\,b`, &list))
+		assert.Equal(t, []string{`a\`, "b"}, list)
+	})
+	t.Run("limits", func(t *testing.T) {
+		u := NewUnmarshaler(WithEscapeSeparators(0), WithLimits(Limits{MaxItems: 2}))
+		var list []string
+		assert.NoError(t, u.Unmarshal(`a\,b\,c,d`, reflect.ValueOf(&list)))
+		assert.Equal(t, []string{"a,b,c", "d"}, list)
+	})
+	t.Run("trailing escape", func(t *testing.T) {
+		u := NewUnmarshaler(WithEscapeSeparators(0))
+		var list []string
+		assert.NoError(t, u.Unmarshal(`a,b\`, reflect.ValueOf(&list)))
+		assert.Equal(t, []string{"a", `b\`}, list)
+	})
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import "reflect"
+
+// Resolution describes how a type is handled by a Marshaler or Unmarshaler.
+type Resolution uint8
+
+const (
+	// ResolvedUnsupported indicates the type is not supported.
+	ResolvedUnsupported Resolution = iota
+	// ResolvedFunc indicates a func is registered for the type, or for the
+	// type it points to.
+	ResolvedFunc
+	// ResolvedInterface indicates a func is registered for an interface which
+	// is implemented by the type, or a pointer to the type.
+	ResolvedInterface
+	// ResolvedKind indicates the type is handled based on its kind.
+	ResolvedKind
+)
+
+func (r Resolution) String() string {
+	switch r {
+	case ResolvedFunc:
+		return "func"
+	case ResolvedInterface:
+		return "interface"
+	case ResolvedKind:
+		return "kind"
+	default:
+		return "unsupported"
+	}
+}
+
+// Explanation is a structured description of how a type is handled by a
+// Marshaler or Unmarshaler, as returned by their Explain methods.
+type Explanation struct {
+	// Type is the explained type.
+	Type reflect.Type
+	// Resolution describes how Type is handled.
+	Resolution Resolution
+	// Registered is the type or interface the used func is registered with,
+	// when Resolution is ResolvedFunc or ResolvedInterface.
+	Registered reflect.Type
+	// Global indicates the used func is registered globally, instead of with
+	// the Marshaler or Unmarshaler itself.
+	Global bool
+	// Key explains the key type of a map.
+	Key *Explanation
+	// Elem explains the element type of an array, slice or map, or the type
+	// a pointer points to.
+	Elem *Explanation
+}
+
+// Supported indicates if the type, and all its key and element types, are
+// supported.
+func (e Explanation) Supported() bool {
+	if e.Resolution == ResolvedUnsupported {
+		return false
+	}
+	if e.Key != nil && !e.Key.Supported() {
+		return false
+	}
+	return e.Elem == nil || e.Elem.Supported()
+}
+
+// Explain describes how typ would be handled by Marshaler, without actually
+// marshaling a value.
+func (m *Marshaler) Explain(typ reflect.Type) Explanation {
+	return explain(typ, false, func(typ reflect.Type) (bool, funcMatch) {
+		fn, match := m.lookup(typ)
+		return fn != nil, match
+	})
+}
+
+// Explain describes how typ would be handled by Unmarshaler, without actually
+// unmarshaling a Value.
+func (u *Unmarshaler) Explain(typ reflect.Type) Explanation {
+	return explain(typ, false, func(typ reflect.Type) (bool, funcMatch) {
+		fn, match := u.lookup(typ)
+		return fn != nil, match
+	})
+}
+
+func explain(typ reflect.Type, nested bool, lookup func(reflect.Type) (bool, funcMatch)) Explanation {
+	res := Explanation{Type: typ}
+	found, match := lookup(typ)
+	if found {
+		res.Resolution = ResolvedFunc
+		if match.registered.Kind() == reflect.Interface {
+			res.Resolution = ResolvedInterface
+		}
+		res.Registered = match.registered
+		res.Global = match.global
+		return res
+	}
+	if !match.kind {
+		return res
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		elem := explain(typ.Elem(), nested, lookup)
+		res.Resolution = elem.Resolution
+		res.Elem = &elem
+
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		res.Resolution = ResolvedKind
+
+	case reflect.Array, reflect.Slice:
+		if nested {
+			break
+		}
+		elem := explain(typ.Elem(), true, lookup)
+		res.Resolution = ResolvedKind
+		res.Elem = &elem
+
+	case reflect.Map:
+		if nested {
+			break
+		}
+		key := explain(typ.Key(), true, lookup)
+		elem := explain(typ.Elem(), true, lookup)
+		res.Resolution = ResolvedKind
+		res.Key = &key
+		res.Elem = &elem
+	}
+	return res
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplain(t *testing.T) {
+	textMarshaler := reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	intType := reflect.TypeOf(0)
+	bytesType := reflect.TypeOf(Bytes(0))
+
+	var m Marshaler
+	m.Register(bytesType, func(v any) (string, error) { return "", nil })
+
+	tests := map[string]struct {
+		typ  reflect.Type
+		want Explanation
+	}{
+		"kind": {
+			typ:  intType,
+			want: Explanation{Type: intType, Resolution: ResolvedKind},
+		},
+		"global func": {
+			typ:  durationType,
+			want: Explanation{Type: durationType, Resolution: ResolvedFunc, Registered: durationType, Global: true},
+		},
+		"local func": {
+			typ:  bytesType,
+			want: Explanation{Type: bytesType, Resolution: ResolvedFunc, Registered: bytesType},
+		},
+		"interface": {
+			typ:  reflect.TypeOf(UUID{}),
+			want: Explanation{Type: reflect.TypeOf(UUID{}), Resolution: ResolvedInterface, Registered: textMarshaler, Global: true},
+		},
+		"pointer": {
+			typ: reflect.TypeOf(new(int)),
+			want: Explanation{Type: reflect.TypeOf(new(int)), Resolution: ResolvedKind, Elem: &Explanation{
+				Type: intType, Resolution: ResolvedKind,
+			}},
+		},
+		"map": {
+			typ: reflect.TypeOf(map[string]time.Duration{}),
+			want: Explanation{
+				Type:       reflect.TypeOf(map[string]time.Duration{}),
+				Resolution: ResolvedKind,
+				Key:        &Explanation{Type: reflect.TypeOf(""), Resolution: ResolvedKind},
+				Elem:       &Explanation{Type: durationType, Resolution: ResolvedFunc, Registered: durationType, Global: true},
+			},
+		},
+		"nested slice": {
+			typ: reflect.TypeOf([][]int{}),
+			want: Explanation{
+				Type:       reflect.TypeOf([][]int{}),
+				Resolution: ResolvedKind,
+				Elem:       &Explanation{Type: reflect.TypeOf([]int{}), Resolution: ResolvedUnsupported},
+			},
+		},
+		"unsupported": {
+			typ:  reflect.TypeOf(make(chan int)),
+			want: Explanation{Type: reflect.TypeOf(make(chan int))},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have := m.Explain(tc.typ)
+			assert.Equal(t, tc.want, have)
+			assert.Equal(t, name != "unsupported" && name != "nested slice", have.Supported())
+		})
+	}
+
+	t.Run("unmarshaler", func(t *testing.T) {
+		var u Unmarshaler
+		have := u.Explain(reflect.TypeOf(new(UUID)))
+		assert.Equal(t, ResolvedInterface, have.Resolution)
+		assert.Equal(t, "interface", have.Resolution.String())
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+)
+
+// Export returns the minimal set of Values which reproduces current on top of
+// defaults, using the global Marshaler. See Marshaler.Export for additional
+// details.
+func Export(current, defaults any) (Values, error) {
+	return marshaler.Export(current, defaults)
+}
+
+// Export returns the minimal set of Values which reproduces current, when
+// unmarshaled on top of defaults. It contains the keys which are added or
+// changed in current compared to defaults, see Diff. Both current and
+// defaults may be a Source or a struct (or pointer to a struct). When
+// defaults is nil, current is compared to the zero value of its type.
+//
+// A key whose Value is changed to an empty Value is included as such. Use
+// EmptyZero as Options.EmptyMode when unmarshaling, so these keys reset their
+// fields to the zero value. Use Values.Environ to produce env assignments from
+// the result.
+func (m *Marshaler) Export(current, defaults any) (Values, error) {
+	if defaults == nil {
+		if typ := indirectType(reflect.TypeOf(current)); typ != nil && typ.Kind() == reflect.Struct {
+			defaults = reflect.New(typ).Interface()
+		}
+	}
+
+	diff, err := m.Diff(defaults, current)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(Values, len(diff.Added)+len(diff.Changed))
+	for _, c := range diff.Added {
+		res[c.Key] = c.New
+	}
+	for _, c := range diff.Changed {
+		res[c.Key] = c.New
+	}
+	return res, nil
+}
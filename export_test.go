@@ -0,0 +1,60 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	type config struct {
+		Name    string        `rawconv:"NAME"`
+		Port    int           `rawconv:"PORT"`
+		Timeout time.Duration `rawconv:"TIMEOUT"`
+		Debug   bool          `rawconv:"DEBUG"`
+	}
+
+	defaults := config{Name: "app", Port: 8080, Timeout: time.Second}
+
+	t.Run("defaults", func(t *testing.T) {
+		current := defaults
+		current.Port = 9090
+		current.Name = ""
+		current.Debug = true
+
+		have, err := Export(&current, defaults)
+		assert.NoError(t, err)
+		assert.Equal(t, Values{"PORT": "9090", "NAME": "", "DEBUG": "true"}, have)
+
+		// unmarshaling the result on top of defaults reproduces current
+		target := defaults
+		_, err = NewUnmarshaler(WithEmptyMode(EmptyZero)).UnmarshalStruct(have, &target)
+		assert.NoError(t, err)
+		assert.Equal(t, current, target)
+	})
+	t.Run("zero defaults", func(t *testing.T) {
+		have, err := Export(defaults, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, Values{"NAME": "app", "PORT": "8080", "TIMEOUT": "1s"}, have)
+		assert.Equal(t, []string{"APP_NAME=app", "APP_PORT=8080", "APP_TIMEOUT=1s"}, have.Environ("APP_"))
+	})
+	t.Run("source", func(t *testing.T) {
+		have, err := Export(defaults, Values{"NAME": "app", "PORT": "80", "OTHER": "x"})
+		assert.NoError(t, err)
+		assert.Equal(t, Values{"PORT": "8080", "TIMEOUT": "1s", "DEBUG": "false"}, have)
+	})
+	t.Run("unchanged", func(t *testing.T) {
+		have, err := Export(defaults, &defaults)
+		assert.NoError(t, err)
+		assert.Empty(t, have)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, err := Export(1, nil)
+		assert.ErrorIs(t, err, ErrStructExpected)
+	})
+}
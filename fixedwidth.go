@@ -0,0 +1,106 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// FixedWidthBinder binds fixed-width text records to the fields of a struct.
+// The width of a field is set with the "len" tag option, e.g.
+// `rawconv:"amount,len=8"`. The field starts at the byte offset of the "pos"
+// tag option, e.g. `rawconv:"amount,pos=10,len=8"`, or directly after the
+// previous field when it is omitted. Fields without a width are left
+// untouched.
+//
+// Leading and trailing spaces are trimmed from each Value. Integers are
+// parsed using base 10, unless Options.IntBase or the "base" tag option
+// specifies otherwise, so zero padded numbers such as "000012" are not
+// mistaken for octal numbers.
+type FixedWidthBinder struct {
+	u *Unmarshaler
+}
+
+// NewFixedWidthBinder returns a FixedWidthBinder which uses the global
+// Unmarshaler.
+func NewFixedWidthBinder() *FixedWidthBinder {
+	return unmarshaler.FixedWidthBinder()
+}
+
+// FixedWidthBinder returns a FixedWidthBinder which uses the Unmarshaler.
+func (u *Unmarshaler) FixedWidthBinder() *FixedWidthBinder {
+	if u.IntBase == 0 {
+		u = u.with([]Option{WithIntBase(10)})
+	}
+	return &FixedWidthBinder{u: u}
+}
+
+// Bind slices line into Values and unmarshals them into the fields of the
+// struct pointed to by v. Fields which lie (partially) beyond the end of line
+// receive the remaining part of line, if any. Fields are handled as with
+// UnmarshalStruct, including the "required" tag option.
+func (b *FixedWidthBinder) Bind(line string, v any) error {
+	typ := reflect.TypeOf(v)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return errors.New(ErrStructExpected)
+	}
+
+	fields := structFields(typ.Elem(), func(typ reflect.Type) bool {
+		return b.u.Func(typ) != nil
+	})
+
+	vals := make(Values, len(fields))
+	var next int
+	for _, field := range fields {
+		pos, n, err := fixedWidth(field, next)
+		if err != nil {
+			return errors.WithStack(&FieldError{
+				Field: field.path,
+				Key:   field.key,
+				Err:   err,
+			})
+		}
+		if n == 0 {
+			continue
+		}
+
+		next = pos + n
+		if pos >= len(line) {
+			continue
+		}
+		if next > len(line) {
+			vals[field.key] = Value(strings.TrimSpace(line[pos:]))
+		} else {
+			vals[field.key] = Value(strings.TrimSpace(line[pos:next]))
+		}
+	}
+
+	_, err := b.u.UnmarshalStruct(vals, v)
+	return err
+}
+
+// fixedWidth returns the position and width of field, from its "pos" and
+// "len" tag options. The position defaults to next.
+func fixedWidth(field structField, next int) (pos, n int, err error) {
+	str, ok := field.tag.lookup("len")
+	if !ok {
+		return 0, 0, nil
+	}
+	if n, err = strconv.Atoi(str); err != nil || n <= 0 {
+		return 0, 0, errors.Newf("%w `len=%s`", ErrInvalidTagOption, str)
+	}
+
+	pos = next
+	if str, ok = field.tag.lookup("pos"); ok {
+		if pos, err = strconv.Atoi(str); err != nil || pos < 0 {
+			return 0, 0, errors.Newf("%w `pos=%s`", ErrInvalidTagOption, str)
+		}
+	}
+	return pos, n, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fixedWidthRecord struct {
+	Account string  `rawconv:"account,len=6,required"`
+	Amount  int     `rawconv:"amount,len=8"`
+	Rate    float64 `rawconv:"rate,pos=16,len=5"`
+	Code    string  `rawconv:"code,len=3"`
+	Skipped string
+}
+
+func TestFixedWidthBinder(t *testing.T) {
+	b := NewFixedWidthBinder()
+
+	tests := map[string]struct {
+		line string
+		want fixedWidthRecord
+	}{
+		"full": {
+			line: "ACC00100001234  0.125EUR",
+			want: fixedWidthRecord{Account: "ACC001", Amount: 1234, Rate: 0.125, Code: "EUR"},
+		},
+		"padded": {
+			line: "AB         -42    1.5X  ",
+			want: fixedWidthRecord{Account: "AB", Amount: -42, Rate: 1.5, Code: "X"},
+		},
+		"short": {
+			line: "ACC002  000010",
+			want: fixedWidthRecord{Account: "ACC002", Amount: 10},
+		},
+		"explicit position": {
+			line: "ACC003          9.999",
+			want: fixedWidthRecord{Account: "ACC003", Rate: 9.999},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var have fixedWidthRecord
+			assert.NoError(t, b.Bind(tc.line, &have))
+			assert.Equal(t, tc.want, have)
+		})
+	}
+
+	t.Run("errors", func(t *testing.T) {
+		var rec fixedWidthRecord
+		assert.ErrorIs(t, b.Bind("", &rec), ErrMissingValue)
+		assert.ErrorIs(t, b.Bind("ACC001xxxxxxxx", &rec), ErrParseFailure)
+		assert.ErrorIs(t, b.Bind("ACC001", rec), ErrStructExpected)
+
+		var invalid struct {
+			Name string `rawconv:",len=-1"`
+		}
+		assert.ErrorIs(t, b.Bind("foo", &invalid), ErrInvalidTagOption)
+	})
+	t.Run("int base", func(t *testing.T) {
+		var have struct {
+			Hex int `rawconv:",len=4,base=16"`
+			Dec int `rawconv:",len=4"`
+		}
+		assert.NoError(t, b.Bind("00ff0010", &have))
+		assert.Equal(t, 255, have.Hex)
+		assert.Equal(t, 10, have.Dec)
+	})
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"sort"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*FlagExpr)(nil)
+	_ encoding.TextUnmarshaler = (*FlagExpr)(nil)
+)
+
+// FlagExpr is a boolean expression of named flags, e.g. for feature flag
+// targeting. Its raw Value consists of flag names, which may contain letters,
+// digits and the characters "_", "-", "." and ":", combined using the
+// operators "!", "&&" and "||", and parentheses, e.g. "beta && !eu || staff".
+// The operator "!" has the highest precedence, followed by "&&" and "||". The
+// names "true" and "false" are constants.
+type FlagExpr struct {
+	str  string
+	root flagNode
+}
+
+// ParseFlagExpr parses str as a FlagExpr. An empty str results in the zero
+// value, which always evaluates to false.
+func ParseFlagExpr(str string) (FlagExpr, error) {
+	if strings.TrimSpace(str) == "" {
+		return FlagExpr{}, nil
+	}
+
+	p := flagParser{str: str}
+	root, err := p.parseOr()
+	if err == nil && p.next() != "" {
+		err = p.errorf("unexpected `%s`", p.tok)
+	}
+	if err != nil {
+		return FlagExpr{}, err
+	}
+	return FlagExpr{str: strings.TrimSpace(str), root: root}, nil
+}
+
+// Eval evaluates the expression, where the value of each flag is looked up in
+// flags. Flags which do not exist in flags are false.
+func (fe FlagExpr) Eval(flags map[string]bool) bool {
+	if fe.root == nil {
+		return false
+	}
+	return fe.root.eval(flags)
+}
+
+// Flags returns the names of all flags within the expression, in sorted order.
+func (fe FlagExpr) Flags() []string {
+	seen := make(map[string]struct{})
+	if fe.root != nil {
+		fe.root.flags(seen)
+	}
+	res := make([]string, 0, len(seen))
+	for name := range seen {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// IsZero indicates if FlagExpr is the zero value.
+func (fe FlagExpr) IsZero() bool { return fe.root == nil }
+
+// String returns the expression as it is parsed, without leading and trailing
+// whitespace.
+func (fe FlagExpr) String() string { return fe.str }
+
+// MarshalText returns the result of String.
+func (fe FlagExpr) MarshalText() ([]byte, error) { return []byte(fe.str), nil }
+
+// UnmarshalText parses text using ParseFlagExpr.
+func (fe *FlagExpr) UnmarshalText(text []byte) error {
+	x, err := ParseFlagExpr(string(text))
+	if err != nil {
+		return err
+	}
+	*fe = x
+	return nil
+}
+
+type flagNode interface {
+	eval(flags map[string]bool) bool
+	flags(seen map[string]struct{})
+}
+
+type (
+	flagConst bool
+	flagName  string
+	flagNot   struct{ x flagNode }
+	flagAnd   struct{ l, r flagNode }
+	flagOr    struct{ l, r flagNode }
+)
+
+func (n flagConst) eval(map[string]bool) bool { return bool(n) }
+func (n flagConst) flags(map[string]struct{}) {}
+
+func (n flagName) eval(flags map[string]bool) bool { return flags[string(n)] }
+func (n flagName) flags(seen map[string]struct{})  { seen[string(n)] = struct{}{} }
+
+func (n flagNot) eval(flags map[string]bool) bool { return !n.x.eval(flags) }
+func (n flagNot) flags(seen map[string]struct{})  { n.x.flags(seen) }
+
+func (n flagAnd) eval(flags map[string]bool) bool { return n.l.eval(flags) && n.r.eval(flags) }
+func (n flagAnd) flags(seen map[string]struct{}) {
+	n.l.flags(seen)
+	n.r.flags(seen)
+}
+
+func (n flagOr) eval(flags map[string]bool) bool { return n.l.eval(flags) || n.r.eval(flags) }
+func (n flagOr) flags(seen map[string]struct{}) {
+	n.l.flags(seen)
+	n.r.flags(seen)
+}
+
+// flagParser is a recursive descent parser for FlagExpr.
+type flagParser struct {
+	str  string
+	pos  int
+	tok  string
+	peek bool
+}
+
+func (p *flagParser) errorf(format string, args ...any) error {
+	return errors.Wrap(errors.Newf(format+" in flag expression `%s`", append(args, p.str)...), ErrParseFailure)
+}
+
+// next returns the next token, or an empty string at the end of str.
+func (p *flagParser) next() string {
+	if p.peek {
+		p.peek = false
+		return p.tok
+	}
+	for p.pos < len(p.str) && (p.str[p.pos] == ' ' || p.str[p.pos] == '\t') {
+		p.pos++
+	}
+	if p.pos >= len(p.str) {
+		p.tok = ""
+		return p.tok
+	}
+
+	start := p.pos
+	switch c := p.str[p.pos]; {
+	case c == '!' || c == '(' || c == ')':
+		p.pos++
+	case c == '&' || c == '|':
+		p.pos++
+		if p.pos < len(p.str) && p.str[p.pos] == c {
+			p.pos++
+		}
+	case isFlagNameChar(c):
+		for p.pos < len(p.str) && isFlagNameChar(p.str[p.pos]) {
+			p.pos++
+		}
+	default:
+		p.pos++
+	}
+	p.tok = p.str[start:p.pos]
+	return p.tok
+}
+
+func (p *flagParser) backup() { p.peek = true }
+
+func isFlagNameChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') ||
+		c == '_' || c == '-' || c == '.' || c == ':'
+}
+
+func (p *flagParser) parseOr() (flagNode, error) {
+	l, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.next() == "||" {
+		r, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l = flagOr{l, r}
+	}
+	p.backup()
+	return l, nil
+}
+
+func (p *flagParser) parseAnd() (flagNode, error) {
+	l, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.next() == "&&" {
+		r, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		l = flagAnd{l, r}
+	}
+	p.backup()
+	return l, nil
+}
+
+func (p *flagParser) parseUnary() (flagNode, error) {
+	switch tok := p.next(); {
+	case tok == "":
+		return nil, p.errorf("unexpected end")
+	case tok == "!":
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return flagNot{x}, nil
+	case tok == "(":
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, p.errorf("missing `)`")
+		}
+		return x, nil
+	case tok == "true":
+		return flagConst(true), nil
+	case tok == "false":
+		return flagConst(false), nil
+	case isFlagNameChar(tok[0]):
+		return flagName(tok), nil
+	default:
+		return nil, p.errorf("unexpected `%s`", tok)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFlagExpr(t *testing.T) {
+	tests := map[string]struct {
+		flags map[string]bool
+		want  bool
+	}{
+		"beta && !eu || staff":     {map[string]bool{"beta": true}, true},
+		"beta && !eu || staff ":    {map[string]bool{"beta": true, "eu": true}, false},
+		" beta && !eu || staff":    {map[string]bool{"beta": true, "eu": true, "staff": true}, true},
+		"beta && (!eu || staff)":   {map[string]bool{"beta": true, "eu": true, "staff": true}, true},
+		"!(a || b)":                {map[string]bool{}, true},
+		"!!a":                      {map[string]bool{"a": true}, true},
+		"true && region:us-east.1": {map[string]bool{"region:us-east.1": true}, true},
+		"false || a_b":             {map[string]bool{}, false},
+	}
+	for input, tc := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseFlagExpr(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, tc.want, have.Eval(tc.flags))
+
+			var dest FlagExpr
+			assert.NoError(t, Unmarshal(MustMarshal(have), &dest))
+			assert.Equal(t, have, dest)
+		})
+	}
+
+	errs := []string{"a &&", "a & b", "a || || b", "(a", "a)", "a b", "!", "a == b"}
+	for _, input := range errs {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseFlagExpr(input)
+			assert.ErrorIs(t, haveErr, ErrParseFailure)
+		})
+	}
+}
+
+func TestFlagExpr_Flags(t *testing.T) {
+	fe, err := ParseFlagExpr("staff || beta && !eu || beta")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"beta", "eu", "staff"}, fe.Flags())
+	assert.Equal(t, "staff || beta && !eu || beta", fe.String())
+
+	var zero FlagExpr
+	assert.True(t, zero.IsZero())
+	assert.False(t, zero.Eval(map[string]bool{"a": true}))
+	assert.Empty(t, zero.Flags())
+}
+
+func TestFlagExpr_zero(t *testing.T) {
+	have, haveErr := ParseFlagExpr(" ")
+	assert.NoError(t, haveErr)
+	assert.True(t, have.IsZero())
+	assert.False(t, have.Eval(map[string]bool{"beta": true}))
+	assert.Equal(t, Value(""), MustMarshal(FlagExpr{}))
+
+	fe, _ := ParseFlagExpr("beta")
+	assert.NoError(t, fe.UnmarshalText(nil))
+	assert.True(t, fe.IsZero())
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const ErrUnknownFlag errors.Msg = "unknown flag"
+
+// Flag is the name of one or more bits of a bitmask.
+type Flag struct {
+	Name string
+	Bits uint64
+}
+
+// FlagTable is a set of Flag(s) which is used to parse and format integer
+// bitmasks as pipe separated flag names, e.g. "READ|WRITE". Use its
+// MarshalFunc and UnmarshalFunc to register it for an integer type:
+//
+//	type Perm uint8
+//	flags := rawconv.NewFlagTable(
+//		rawconv.Flag{Name: "READ", Bits: 1},
+//		rawconv.Flag{Name: "WRITE", Bits: 2},
+//	)
+//	rawconv.RegisterMarshalFunc(reflect.TypeOf(Perm(0)), flags.MarshalFunc())
+//	rawconv.RegisterUnmarshalFunc(reflect.TypeOf(Perm(0)), flags.UnmarshalFunc())
+type FlagTable struct {
+	flags []Flag
+}
+
+// FlagSeparator separates the flag names of a bitmask.
+const FlagSeparator = "|"
+
+// NewFlagTable returns a FlagTable with the provided Flag(s). It panics when a
+// Flag has an empty name, no bits or a name which is already used.
+func NewFlagTable(flags ...Flag) *FlagTable {
+	ft := FlagTable{flags: make([]Flag, 0, len(flags))}
+	for _, f := range flags {
+		if f.Name == "" || f.Bits == 0 || strings.Contains(f.Name, FlagSeparator) {
+			panic("rawconv: invalid flag " + strconv.Quote(f.Name))
+		}
+		if _, ok := ft.lookup(f.Name); ok {
+			panic("rawconv: duplicate flag " + strconv.Quote(f.Name))
+		}
+		ft.flags = append(ft.flags, f)
+	}
+	return &ft
+}
+
+func (ft *FlagTable) lookup(name string) (uint64, bool) {
+	for _, f := range ft.flags {
+		if strings.EqualFold(f.Name, name) {
+			return f.Bits, true
+		}
+	}
+	return 0, false
+}
+
+// Parse parses str as pipe separated flag names, e.g. "READ|WRITE", and
+// returns the combined bits. Names are case-insensitive. A part may also be an
+// integer, e.g. "READ|0x10", for bits without name. An empty str results in 0.
+func (ft *FlagTable) Parse(str string) (uint64, error) {
+	if str = strings.TrimSpace(str); str == "" {
+		return 0, nil
+	}
+
+	var mask uint64
+	for _, part := range strings.Split(str, FlagSeparator) {
+		part = strings.TrimSpace(part)
+		if bits, ok := ft.lookup(part); ok {
+			mask |= bits
+			continue
+		}
+
+		bits, err := strconv.ParseUint(part, 0, 64)
+		if err != nil {
+			return 0, errors.Wrap(errors.Newf("%w `%s`", ErrUnknownFlag, part), ErrParseFailure)
+		}
+		mask |= bits
+	}
+	return mask, nil
+}
+
+// Format formats mask as pipe separated flag names, in the order the Flag(s)
+// are provided to NewFlagTable. A Flag is only used when all of its bits are
+// set and at least one of them is not yet covered by a previous Flag, so
+// combined flags, e.g. "ALL", are preferred when they are provided first.
+// Remaining bits without name are formatted as a hexadecimal integer. A zero
+// mask results in an empty string.
+func (ft *FlagTable) Format(mask uint64) string {
+	var names []string
+	rest := mask
+	for _, f := range ft.flags {
+		if mask&f.Bits == f.Bits && rest&f.Bits != 0 {
+			names = append(names, f.Name)
+			rest &^= f.Bits
+		}
+	}
+	if rest != 0 {
+		names = append(names, "0x"+strconv.FormatUint(rest, 16))
+	}
+	return strings.Join(names, FlagSeparator)
+}
+
+// MarshalFunc returns a MarshalFunc which formats integer types using Format.
+func (ft *FlagTable) MarshalFunc() MarshalFunc {
+	return func(v any) (string, error) {
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if rv.Int() < 0 {
+				return "", errors.Wrap(errors.Newf("negative bitmask `%d`", rv.Int()), ErrValidationFailure)
+			}
+			return ft.Format(uint64(rv.Int())), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return ft.Format(rv.Uint()), nil
+		default:
+			return "", errors.WithStack(&UnsupportedTypeError{Type: rv.Type()})
+		}
+	}
+}
+
+// UnmarshalFunc returns an UnmarshalFunc which parses a Value using Parse, and
+// sets the result to integer types. The result must fit the integer type,
+// otherwise an ErrValidationFailure is returned.
+func (ft *FlagTable) UnmarshalFunc() UnmarshalFunc {
+	return func(val Value, dest any) error {
+		if val.IsEmpty() {
+			return nil
+		}
+
+		mask, err := ft.Parse(val.String())
+		if err != nil {
+			return err
+		}
+
+		rv := reflect.ValueOf(dest).Elem()
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if int64(mask) < 0 || rv.OverflowInt(int64(mask)) {
+				return errors.Wrap(errors.Newf("bitmask `%s` out of range", val), ErrValidationFailure)
+			}
+			rv.SetInt(int64(mask))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if rv.OverflowUint(mask) {
+				return errors.Wrap(errors.Newf("bitmask `%s` out of range", val), ErrValidationFailure)
+			}
+			rv.SetUint(mask)
+		default:
+			return errors.WithStack(&UnsupportedTypeError{Type: rv.Type()})
+		}
+		return nil
+	}
+}
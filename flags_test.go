@@ -0,0 +1,103 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPerm uint8
+
+var testPermFlags = NewFlagTable(
+	Flag{Name: "ALL", Bits: 7},
+	Flag{Name: "READ", Bits: 1},
+	Flag{Name: "WRITE", Bits: 2},
+	Flag{Name: "EXEC", Bits: 4},
+)
+
+func TestNewFlagTable(t *testing.T) {
+	assert.PanicsWithValue(t, `rawconv: invalid flag ""`, func() { NewFlagTable(Flag{Bits: 1}) })
+	assert.PanicsWithValue(t, `rawconv: invalid flag "A"`, func() { NewFlagTable(Flag{Name: "A"}) })
+	assert.PanicsWithValue(t, `rawconv: invalid flag "A|B"`, func() { NewFlagTable(Flag{Name: "A|B", Bits: 1}) })
+	assert.PanicsWithValue(t, `rawconv: duplicate flag "a"`, func() {
+		NewFlagTable(Flag{Name: "A", Bits: 1}, Flag{Name: "a", Bits: 2})
+	})
+}
+
+func TestFlagTable_Parse(t *testing.T) {
+	tests := map[string]uint64{
+		"":                  0,
+		"READ":              1,
+		"READ|WRITE":        3,
+		" read | exec ":     5,
+		"ALL":               7,
+		"READ|0x10":         17,
+		"WRITE|WRITE":       2,
+		"READ|WRITE|EXEC":   7,
+		"8":                 8,
+		"READ|WRITE|0b1000": 11,
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, err := testPermFlags.Parse(input)
+			assert.NoError(t, err)
+			assert.Equal(t, want, have)
+		})
+	}
+
+	_, err := testPermFlags.Parse("READ|DELETE")
+	assert.ErrorIs(t, err, ErrUnknownFlag)
+	assert.ErrorIs(t, err, ErrParseFailure)
+}
+
+func TestFlagTable_Format(t *testing.T) {
+	tests := map[uint64]string{
+		0:  "",
+		1:  "READ",
+		3:  "READ|WRITE",
+		5:  "READ|EXEC",
+		7:  "ALL",
+		15: "ALL|0x8",
+		16: "0x10",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, testPermFlags.Format(input))
+	}
+}
+
+func TestFlagTable_Funcs(t *testing.T) {
+	defer Scope()()
+
+	typ := reflect.TypeOf(testPerm(0))
+	RegisterMarshalFunc(typ, testPermFlags.MarshalFunc())
+	RegisterUnmarshalFunc(typ, testPermFlags.UnmarshalFunc())
+
+	var have testPerm
+	assert.NoError(t, Unmarshal("READ|EXEC", &have))
+	assert.Equal(t, testPerm(5), have)
+
+	val, err := Marshal(have)
+	assert.NoError(t, err)
+	assert.Equal(t, Value("READ|EXEC"), val)
+
+	assert.ErrorIs(t, Unmarshal("0x100", &have), ErrValidationFailure)
+
+	t.Run("int", func(t *testing.T) {
+		var i int
+		fn := testPermFlags.UnmarshalFunc()
+		assert.NoError(t, fn("WRITE", &i))
+		assert.Equal(t, 2, i)
+
+		str, err := testPermFlags.MarshalFunc()(i)
+		assert.NoError(t, err)
+		assert.Equal(t, "WRITE", str)
+
+		_, err = testPermFlags.MarshalFunc()(-1)
+		assert.ErrorIs(t, err, ErrValidationFailure)
+	})
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*FormatString)(nil)
+	_ encoding.TextUnmarshaler = (*FormatString)(nil)
+)
+
+// FormatArg is the kind of argument of a FormatString, which determines the
+// verbs that may be used for it.
+type FormatArg uint8
+
+const (
+	// FormatArgAny allows only the verbs %v and %T, which are valid for any
+	// argument.
+	FormatArgAny FormatArg = iota
+	// FormatArgString allows %s, %q, %x and %X.
+	FormatArgString
+	// FormatArgInt allows %b, %c, %d, %o, %O, %q, %x, %X and %U.
+	FormatArgInt
+	// FormatArgFloat allows %b, %e, %E, %f, %F, %g, %G, %x and %X.
+	FormatArgFloat
+	// FormatArgBool allows %t.
+	FormatArgBool
+)
+
+var formatVerbs = map[FormatArg]string{
+	FormatArgAny:    "vT",
+	FormatArgString: "vTsqxX",
+	FormatArgInt:    "vTbcdoOqxXU",
+	FormatArgFloat:  "vTbeEfFgGxX",
+	FormatArgBool:   "vTt",
+}
+
+func (a FormatArg) String() string {
+	switch a {
+	case FormatArgAny:
+		return "any"
+	case FormatArgString:
+		return "string"
+	case FormatArgInt:
+		return "int"
+	case FormatArgFloat:
+		return "float"
+	case FormatArgBool:
+		return "bool"
+	default:
+		return "FormatArg(" + strconv.Itoa(int(a)) + ")"
+	}
+}
+
+// FormatString is a printf-style format, e.g. "%s has %d items", which is
+// checked against the declared kinds of its arguments when unmarshaling. Set
+// Args before unmarshaling to declare them, e.g. within the default value of
+// a struct field. The format must use each argument, using verbs which are
+// valid for its kind. When Args is nil, only the syntax of the format is
+// checked.
+type FormatString struct {
+	// Args are the declared kinds of the arguments of the format.
+	Args []FormatArg
+	str  string
+}
+
+// ParseFormatString checks str against args and returns it as FormatString.
+func ParseFormatString(str string, args ...FormatArg) (FormatString, error) {
+	if err := checkFormat(str, args); err != nil {
+		return FormatString{}, errors.Wrap(errors.Newf("%s in format `%s`", err.Error(), str), ErrValidationFailure)
+	}
+	return FormatString{Args: args, str: str}, nil
+}
+
+// Sprintf formats args according to the FormatString, see fmt.Sprintf.
+func (fs FormatString) Sprintf(args ...any) string { return fmt.Sprintf(fs.str, args...) }
+
+// String returns the FormatString as string.
+func (fs FormatString) String() string { return fs.str }
+
+// MarshalText returns the FormatString as text.
+func (fs FormatString) MarshalText() ([]byte, error) { return []byte(fs.str), nil }
+
+// UnmarshalText checks text against FormatString.Args and sets it as
+// FormatString.
+func (fs *FormatString) UnmarshalText(text []byte) error {
+	x, err := ParseFormatString(string(text), fs.Args...)
+	if err != nil {
+		return err
+	}
+	*fs = x
+	return nil
+}
+
+// checkFormat checks the syntax of format and, when args is not nil, whether
+// its verbs match args.
+func checkFormat(format string, args []FormatArg) error {
+	used := make([]bool, len(args))
+	var argNum int
+
+	// arg checks whether the argument at argNum is allowed for verb and
+	// advances to the next argument
+	arg := func(verb rune, allowed func(FormatArg) bool) error {
+		if args == nil {
+			return nil
+		}
+		if argNum >= len(args) {
+			return errors.Newf("missing argument for `%%%c`", verb)
+		}
+		if !allowed(args[argNum]) {
+			return errors.Newf("invalid verb `%%%c` for %s argument #%d", verb, args[argNum], argNum+1)
+		}
+		used[argNum] = true
+		argNum++
+		return nil
+	}
+
+	for i := 0; i < len(format); {
+		if format[i] != '%' {
+			i++
+			continue
+		}
+		i++
+
+		// flags
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		// width and precision
+		for part := 0; part < 2; part++ {
+			var err error
+			if i, err = readArgIndex(format, i, &argNum); err != nil {
+				return err
+			}
+			if i < len(format) && format[i] == '*' {
+				i++
+				err = arg('*', func(a FormatArg) bool { return a == FormatArgInt })
+				if err != nil {
+					return err
+				}
+			} else {
+				for i < len(format) && format[i] >= '0' && format[i] <= '9' {
+					i++
+				}
+			}
+			if part == 0 && i < len(format) && format[i] == '.' {
+				i++
+				continue
+			}
+			break
+		}
+
+		var err error
+		if i, err = readArgIndex(format, i, &argNum); err != nil {
+			return err
+		}
+		if i >= len(format) {
+			return errors.New("missing verb")
+		}
+
+		verb, n := utf8.DecodeRuneInString(format[i:])
+		i += n
+		if verb == '%' {
+			continue
+		}
+
+		var known bool
+		for _, verbs := range formatVerbs {
+			if strings.ContainsRune(verbs, verb) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return errors.Newf("unknown verb `%%%c`", verb)
+		}
+		if err = arg(verb, func(a FormatArg) bool {
+			return strings.ContainsRune(formatVerbs[a], verb)
+		}); err != nil {
+			return err
+		}
+	}
+
+	for i, ok := range used {
+		if !ok {
+			return errors.Newf("unused %s argument #%d", args[i], i+1)
+		}
+	}
+	return nil
+}
+
+// readArgIndex reads an explicit argument index, e.g. "[2]", at position i of
+// format and sets argNum accordingly.
+func readArgIndex(format string, i int, argNum *int) (int, error) {
+	if i >= len(format) || format[i] != '[' {
+		return i, nil
+	}
+
+	end := strings.IndexByte(format[i:], ']')
+	if end < 0 {
+		return i, errors.New("missing `]`")
+	}
+
+	n, err := strconv.Atoi(format[i+1 : i+end])
+	if err != nil || n < 1 {
+		return i, errors.Newf("invalid argument index `%s`", format[i:i+end+1])
+	}
+	*argNum = n - 1
+	return i + end + 1, nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFormatString(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		args    []FormatArg
+		wantErr bool
+	}{
+		"no args":          {input: "100%% done"},
+		"syntax only":      {input: "%s: %d"},
+		"string and int":   {input: "%s has %d items", args: []FormatArg{FormatArgString, FormatArgInt}},
+		"flags":            {input: "%-10s|%+05d|%#x", args: []FormatArg{FormatArgString, FormatArgInt, FormatArgInt}},
+		"precision":        {input: "%8.3f", args: []FormatArg{FormatArgFloat}},
+		"star width":       {input: "%*d", args: []FormatArg{FormatArgInt, FormatArgInt}},
+		"any":              {input: "%v (%T)", args: []FormatArg{FormatArgAny, FormatArgAny}},
+		"bool":             {input: "enabled=%t", args: []FormatArg{FormatArgBool}},
+		"explicit index":   {input: "%[2]d %[1]s", args: []FormatArg{FormatArgString, FormatArgInt}},
+		"reused index":     {input: "%s %[1]q", args: []FormatArg{FormatArgString}},
+		"wrong verb":       {input: "%d items", args: []FormatArg{FormatArgString}, wantErr: true},
+		"bool as string":   {input: "%s", args: []FormatArg{FormatArgBool}, wantErr: true},
+		"missing argument": {input: "%s %s", args: []FormatArg{FormatArgString}, wantErr: true},
+		"unused argument":  {input: "%s", args: []FormatArg{FormatArgString, FormatArgInt}, wantErr: true},
+		"unknown verb":     {input: "%y", wantErr: true},
+		"missing verb":     {input: "50%", wantErr: true},
+		"star not int":     {input: "%*s", args: []FormatArg{FormatArgString, FormatArgString}, wantErr: true},
+		"bad index":        {input: "%[0]d", args: []FormatArg{FormatArgInt}, wantErr: true},
+		"unclosed index":   {input: "%[1d", args: []FormatArg{FormatArgInt}, wantErr: true},
+		"empty no args":    {input: "", args: []FormatArg{}},
+		"verb without arg": {input: "%d", args: []FormatArg{}, wantErr: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := ParseFormatString(tc.input, tc.args...)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrValidationFailure)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.input, have.String())
+		})
+	}
+}
+
+func TestFormatString(t *testing.T) {
+
+	fs := FormatString{Args: []FormatArg{FormatArgString, FormatArgInt}}
+	assert.NoError(t, Unmarshal("%s has %d items", &fs))
+	assert.Equal(t, "cart has 3 items", fs.Sprintf("cart", 3))
+
+	have, err := Marshal(fs)
+	assert.NoError(t, err)
+	assert.Equal(t, Value("%s has %d items"), have)
+
+	assert.ErrorIs(t, Unmarshal("%d items in %s", &fs), ErrValidationFailure)
+	assert.Equal(t, "%s has %d items", fs.String())
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const (
+	ErrInvalidPattern  errors.Msg = "invalid pattern"
+	ErrPatternMismatch errors.Msg = "value does not match pattern"
+)
+
+// Formatter binds a struct type to a single raw Value, using a pattern such
+// as "{host}:{port}/{path}". Each placeholder between curly braces refers to
+// the key of a struct field, as used by UnmarshalStruct. All other text is
+// literal and must be matched exactly. Use "{{" and "}}" for literal braces.
+// Placeholders must be separated by literal text, so each value ends at the
+// first occurrence of the literal text that follows it.
+type Formatter struct {
+	pattern  string
+	segments []formatSegment
+}
+
+type formatSegment struct {
+	literal string
+	key     string
+}
+
+// CompileFormatter compiles pattern into a Formatter. It returns an error
+// wrapping ErrInvalidPattern when pattern is invalid.
+func CompileFormatter(pattern string) (*Formatter, error) {
+	f := Formatter{pattern: pattern}
+	keys := make(map[string]struct{})
+
+	var lit strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case (c == '{' || c == '}') && i+1 < len(pattern) && pattern[i+1] == c:
+			lit.WriteByte(c)
+			i++
+
+		case c == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end < 0 {
+				return nil, f.invalid("unclosed placeholder")
+			}
+
+			key := strings.TrimSpace(pattern[i+1 : i+end])
+			if key == "" {
+				return nil, f.invalid("empty placeholder")
+			}
+			if _, ok := keys[key]; ok {
+				return nil, f.invalid("duplicate placeholder `" + key + "`")
+			}
+			if lit.Len() == 0 && len(f.segments) != 0 {
+				return nil, f.invalid("placeholders must be separated by literal text")
+			}
+
+			if lit.Len() != 0 {
+				f.segments = append(f.segments, formatSegment{literal: lit.String()})
+				lit.Reset()
+			}
+			f.segments = append(f.segments, formatSegment{key: key})
+			keys[key] = struct{}{}
+			i += end
+
+		case c == '}':
+			return nil, f.invalid("unexpected `}`")
+
+		default:
+			lit.WriteByte(c)
+		}
+	}
+	if lit.Len() != 0 {
+		f.segments = append(f.segments, formatSegment{literal: lit.String()})
+	}
+	if len(keys) == 0 {
+		return nil, f.invalid("no placeholders")
+	}
+	return &f, nil
+}
+
+// MustCompileFormatter is like CompileFormatter but panics when the pattern
+// is invalid. It simplifies the initialization of package level variables.
+func MustCompileFormatter(pattern string) *Formatter {
+	f, err := CompileFormatter(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func (f *Formatter) invalid(reason string) error {
+	return errors.Newf("%w `%s`: %s", ErrInvalidPattern, f.pattern, reason)
+}
+
+func (f *Formatter) mismatch() error {
+	return errors.Wrap(errors.Newf("%w `%s`", ErrPatternMismatch, f.pattern), ErrParseFailure)
+}
+
+// String returns the pattern of the Formatter.
+func (f *Formatter) String() string { return f.pattern }
+
+// Keys returns the keys of the placeholders within the pattern, in order of
+// appearance.
+func (f *Formatter) Keys() []string {
+	res := make([]string, 0, len(f.segments))
+	for _, seg := range f.segments {
+		if seg.key != "" {
+			res = append(res, seg.key)
+		}
+	}
+	return res
+}
+
+// Split splits str into the Values of the placeholders within the pattern.
+func (f *Formatter) Split(str string) (Values, error) {
+	res := make(Values, len(f.segments))
+	for i, seg := range f.segments {
+		if seg.key == "" {
+			if !strings.HasPrefix(str, seg.literal) {
+				return nil, f.mismatch()
+			}
+			str = str[len(seg.literal):]
+			continue
+		}
+
+		if i == len(f.segments)-1 {
+			res[seg.key] = Value(str)
+			return res, nil
+		}
+
+		// the next segment is always a literal
+		end := strings.Index(str, f.segments[i+1].literal)
+		if end < 0 {
+			return nil, f.mismatch()
+		}
+		res[seg.key] = Value(str[:end])
+		str = str[end:]
+	}
+	if str != "" {
+		return nil, f.mismatch()
+	}
+	return res, nil
+}
+
+// Join formats the Values of src according to the pattern. Each placeholder
+// key must exist within src.
+func (f *Formatter) Join(src Source) (string, error) {
+	var buf strings.Builder
+	for i, seg := range f.segments {
+		if seg.key == "" {
+			buf.WriteString(seg.literal)
+			continue
+		}
+
+		val, ok := src.Lookup(seg.key)
+		if !ok {
+			return "", errors.WithStack(&UnknownKeyError{Keys: []string{seg.key}})
+		}
+		// the value must not contain the literal text which ends it, or it
+		// cannot be split again
+		if i < len(f.segments)-1 && strings.Contains(val.String(), f.segments[i+1].literal) {
+			return "", errors.Newf("%w `%s`: value of `%s` contains `%s`",
+				ErrPatternMismatch, f.pattern, seg.key, f.segments[i+1].literal,
+			)
+		}
+		buf.WriteString(val.String())
+	}
+	return buf.String(), nil
+}
+
+// MarshalFunc returns a MarshalFunc which formats a struct according to the
+// pattern, using the key of each field as placeholder key.
+func (f *Formatter) MarshalFunc() MarshalFunc {
+	return func(v any) (string, error) {
+		vals, err := marshaler.structValues(reflect.ValueOf(v))
+		if err != nil {
+			return "", err
+		}
+		return f.Join(vals)
+	}
+}
+
+// UnmarshalFunc returns an UnmarshalFunc which parses a Value according to
+// the pattern and unmarshals it into a struct using UnmarshalStruct. A
+// placeholder without a matching struct field results in an
+// UnknownKeyError.
+func (f *Formatter) UnmarshalFunc() UnmarshalFunc {
+	return func(val Value, dest any) error {
+		vals, err := f.Split(val.String())
+		if err != nil {
+			return err
+		}
+
+		u := Unmarshaler{Options: unmarshaler.Options}
+		u.UnknownKeys = RejectUnknownKeys
+		_, err = u.UnmarshalStruct(vals, dest)
+		return err
+	}
+}
+
+// RegisterFormatter registers the MarshalFunc and UnmarshalFunc of Formatter
+// f for struct type typ, making it globally available.
+func RegisterFormatter(typ reflect.Type, f *Formatter) {
+	RegisterMarshalFunc(typ, f.MarshalFunc())
+	RegisterUnmarshalFunc(typ, f.UnmarshalFunc())
+}
+
+// structValues marshals the fields of struct rv to Values, using the key of
+// each field.
+func (m *Marshaler) structValues(rv reflect.Value) (Values, error) {
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errors.New(ErrStructExpected)
+	}
+
+	fields := structFields(rv.Type(), func(typ reflect.Type) bool {
+		return m.Func(typ) != nil
+	})
+
+	res := make(Values, len(fields))
+	for _, field := range fields {
+		fm, err := m.forField(field.tag)
+		if err == nil {
+			res[field.key], err = fm.Marshal(rv.FieldByIndex(field.index))
+		}
+		if err != nil {
+			return nil, errors.WithStack(&FieldError{
+				Field: field.path,
+				Key:   field.key,
+				Err:   err,
+			})
+		}
+	}
+	return res, nil
+}
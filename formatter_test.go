@@ -0,0 +1,128 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type formatterTarget struct {
+	Host string `rawconv:"host"`
+	Port uint16 `rawconv:"port"`
+	Path string `rawconv:"path"`
+}
+
+func TestCompileFormatter(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		f, err := CompileFormatter("{{{host}:{ port }/{path}}}")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"host", "port", "path"}, f.Keys())
+		assert.Equal(t, "{{{host}:{ port }/{path}}}", f.String())
+	})
+
+	tests := map[string]string{
+		"empty":        "",
+		"literal only": "host",
+		"unclosed":     "{host",
+		"unexpected":   "host}",
+		"empty key":    "{}:{port}",
+		"duplicate":    "{host}:{host}",
+		"adjacent":     "{host}{port}",
+	}
+	for name, pattern := range tests {
+		t.Run(name, func(t *testing.T) {
+			f, err := CompileFormatter(pattern)
+			assert.Nil(t, f)
+			assert.ErrorIs(t, err, ErrInvalidPattern)
+		})
+	}
+
+	assert.Panics(t, func() { MustCompileFormatter("{") })
+}
+
+func TestFormatter_Split(t *testing.T) {
+	f := MustCompileFormatter("{host}:{port}/{path}")
+
+	tests := map[string]struct {
+		input   string
+		want    Values
+		wantErr error
+	}{
+		"full":        {input: "localhost:8080/api/v1", want: Values{"host": "localhost", "port": "8080", "path": "api/v1"}},
+		"empty parts": {input: ":/", want: Values{"host": "", "port": "", "path": ""}},
+		"missing":     {input: "localhost:8080", wantErr: ErrPatternMismatch},
+		"no literal":  {input: "localhost", wantErr: ErrPatternMismatch},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := f.Split(tc.input)
+			assert.Equal(t, tc.want, have)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.ErrorIs(t, err, ErrParseFailure)
+			}
+		})
+	}
+
+	t.Run("trailing literal", func(t *testing.T) {
+		f := MustCompileFormatter("[{a}]")
+		have, err := f.Split("[x]")
+		assert.NoError(t, err)
+		assert.Equal(t, Values{"a": "x"}, have)
+
+		_, err = f.Split("[x]y")
+		assert.ErrorIs(t, err, ErrPatternMismatch)
+	})
+}
+
+func TestFormatter_Join(t *testing.T) {
+	f := MustCompileFormatter("{host}:{port}")
+
+	have, err := f.Join(Values{"host": "localhost", "port": "80"})
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost:80", have)
+
+	_, err = f.Join(Values{"host": "localhost"})
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	_, err = f.Join(Values{"host": "::1", "port": "80"})
+	assert.ErrorIs(t, err, ErrPatternMismatch)
+}
+
+func TestRegisterFormatter(t *testing.T) {
+	defer Scope()()
+
+	RegisterFormatter(reflect.TypeOf(formatterTarget{}), MustCompileFormatter("{host}:{port}/{path}"))
+
+	want := formatterTarget{Host: "localhost", Port: 8080, Path: "api"}
+	have, err := Marshal(want)
+	assert.NoError(t, err)
+	assert.Equal(t, Value("localhost:8080/api"), have)
+
+	var target formatterTarget
+	assert.NoError(t, Unmarshal(have, &target))
+	assert.Equal(t, want, target)
+
+	assert.ErrorIs(t, Unmarshal("localhost:x/api", &target), ErrParseFailure)
+
+	t.Run("struct field", func(t *testing.T) {
+		var config struct {
+			Server formatterTarget `rawconv:"server"`
+		}
+		_, err := UnmarshalStruct(Values{"server": "example.com:443/"}, &config)
+		assert.NoError(t, err)
+		assert.Equal(t, formatterTarget{Host: "example.com", Port: 443}, config.Server)
+	})
+	t.Run("unknown placeholder", func(t *testing.T) {
+		defer Scope()()
+		RegisterFormatter(reflect.TypeOf(formatterTarget{}), MustCompileFormatter("{host}:{other}"))
+		assert.ErrorIs(t, Unmarshal("localhost:1", &target), ErrUnknownKey)
+	})
+}
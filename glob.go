@@ -0,0 +1,182 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"path"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Glob)(nil)
+	_ encoding.TextUnmarshaler = (*Glob)(nil)
+)
+
+// Glob is a slash separated glob pattern, e.g. "src/**/*.{go,mod}". Each path
+// segment uses the syntax of path.Match. Additionally, a "**" segment matches
+// zero or more segments and "{a,b}" matches either alternative. The pattern's
+// syntax is checked when unmarshaling.
+type Glob string
+
+// ParseGlob checks the syntax of str and returns it as a Glob.
+func ParseGlob(str string) (Glob, error) {
+	if _, err := expandBraces(str); err != nil {
+		return "", errors.Wrap(err, ErrParseFailure)
+	}
+	return Glob(str), nil
+}
+
+// Match reports whether name matches the Glob. An error is only returned when
+// the Glob contains a syntax error.
+func (g Glob) Match(name string) (bool, error) {
+	patterns, err := expandBraces(string(g))
+	if err != nil {
+		return false, errors.Wrap(err, ErrParseFailure)
+	}
+
+	names := strings.Split(name, "/")
+	for _, p := range patterns {
+		if matchSegments(strings.Split(p, "/"), names) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// String returns the Glob as string.
+func (g Glob) String() string { return string(g) }
+
+// MarshalText returns the Glob as text.
+func (g Glob) MarshalText() ([]byte, error) { return []byte(g), nil }
+
+// UnmarshalText checks the syntax of text and sets it as Glob.
+func (g *Glob) UnmarshalText(text []byte) error {
+	x, err := ParseGlob(string(text))
+	if err != nil {
+		return err
+	}
+	*g = x
+	return nil
+}
+
+func matchSegments(pattern, names []string) bool {
+	if len(pattern) == 0 {
+		return len(names) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(names); i++ {
+			if matchSegments(pattern[1:], names[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(names) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], names[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], names[1:])
+}
+
+// expandBraces expands all "{a,b}" alternatives of pattern and checks the
+// syntax of each segment of the resulting patterns. Braces within a character
+// class, e.g. "[{]", are not alternatives.
+func expandBraces(pattern string) ([]string, error) {
+	start := -1
+	depth := 0
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '[':
+			i = skipClass(pattern, i)
+		case '{':
+			if depth == 0 {
+				start = i
+			}
+			depth++
+		case '}':
+			if depth == 0 {
+				return nil, errors.Newf("unexpected `}` in `%s`", pattern)
+			}
+			if depth--; depth > 0 {
+				continue
+			}
+
+			var res []string
+			for _, alt := range splitAlternatives(pattern[start+1 : i]) {
+				x, err := expandBraces(pattern[:start] + alt + pattern[i+1:])
+				if err != nil {
+					return nil, err
+				}
+				res = append(res, x...)
+			}
+			return res, nil
+		}
+	}
+	if depth != 0 {
+		return nil, errors.Newf("missing `}` in `%s`", pattern)
+	}
+
+	for _, seg := range strings.Split(pattern, "/") {
+		if seg == "**" {
+			continue
+		}
+		if _, err := path.Match(seg, ""); err != nil {
+			return nil, errors.Wrap(err, "invalid pattern `"+pattern+"`")
+		}
+	}
+	return []string{pattern}, nil
+}
+
+// splitAlternatives splits str on each top level comma, which is not part of a
+// character class.
+func splitAlternatives(str string) []string {
+	var res []string
+	var depth, last int
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			i++
+		case '[':
+			i = skipClass(str, i)
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				res = append(res, str[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(res, str[last:])
+}
+
+// skipClass returns the index of the "]" which closes the character class
+// that starts at index i of str, following the syntax of path.Match. It
+// returns the last index of str when the class is not closed, which is then
+// reported as syntax error by path.Match.
+func skipClass(str string, i int) int {
+	i++
+	if i < len(str) && str[i] == '^' {
+		i++
+	}
+	for ; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			i++
+		case ']':
+			return i
+		}
+	}
+	return len(str) - 1
+}
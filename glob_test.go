@@ -0,0 +1,88 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseGlob(t *testing.T) {
+	for _, input := range []string{"*.go", "src/**/*.go", "**", "*.{go,mod}", "{a,{b,c}}/x", `\{literal\}`, "[{]", "{[,],b}"} {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseGlob(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, Glob(input), have)
+		})
+	}
+	for _, input := range []string{"[a-", "*.{go,mod", "a}", "src/[]/x", "{a,[}"} {
+		t.Run(input, func(t *testing.T) {
+			var have Glob
+			assert.ErrorIs(t, Unmarshal(Value(input), &have), ErrParseFailure)
+			assert.Equal(t, Glob(""), have)
+		})
+	}
+}
+
+func TestParseGlob_error(t *testing.T) {
+	_, haveErr := ParseGlob("a[")
+	assert.ErrorIs(t, haveErr, ErrParseFailure)
+	assert.ErrorIs(t, haveErr, path.ErrBadPattern)
+
+	msg := fmt.Sprintf("%v", haveErr)
+	assert.LessOrEqual(t, strings.Count(msg, path.ErrBadPattern.Error()), 1, msg)
+}
+
+func TestGlob_Match(t *testing.T) {
+	tests := map[Glob]map[string]bool{
+		"*.go": {
+			"main.go":     true,
+			"src/main.go": false,
+		},
+		"src/**/*.go": {
+			"src/main.go":       true,
+			"src/a/b/c/main.go": true,
+			"lib/main.go":       false,
+		},
+		"**/test": {
+			"test":       true,
+			"a/b/test":   true,
+			"a/b/test/c": false,
+		},
+		"*.{go,mod}": {
+			"go.mod":  true,
+			"main.go": true,
+			"go.sum":  false,
+		},
+		"[{}]*.{go,mod}": {
+			"{a.go":  true,
+			"}b.mod": true,
+			"c.go":   false,
+		},
+		"{[,],b}.txt": {
+			",.txt": true,
+			"b.txt": true,
+			"[.txt": false,
+		},
+		"{cmd,internal/{a,b}}/*": {
+			"cmd/x":        true,
+			"internal/b/y": true,
+			"internal/c/y": false,
+		},
+	}
+	for glob, names := range tests {
+		for name, want := range names {
+			t.Run(string(glob)+" "+name, func(t *testing.T) {
+				have, haveErr := glob.Match(name)
+				assert.NoError(t, haveErr)
+				assert.Equal(t, want, have)
+			})
+		}
+	}
+}
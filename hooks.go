@@ -0,0 +1,43 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"time"
+)
+
+// Hooks receive instrumentation events about conversions, e.g. to export
+// metrics about conversion volume and failure rates. Hooks are only called
+// for top level conversions, not for the items of an array, slice or map. Set
+// Options.Hooks to enable them.
+type Hooks interface {
+	// OnMarshal is called after a Marshaler has marshaled a value of type
+	// typ, with the duration it took and its resulting error, if any.
+	OnMarshal(typ reflect.Type, dur time.Duration, err error)
+	// OnUnmarshal is called after an Unmarshaler has unmarshaled a Value to
+	// type typ, with the duration it took and its resulting error, if any.
+	OnUnmarshal(typ reflect.Type, dur time.Duration, err error)
+}
+
+// indirectType returns typ without any pointers.
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ
+}
+
+func hookMarshal(h Hooks, val reflect.Value, start time.Time, err *error) {
+	var typ reflect.Type
+	if val.IsValid() {
+		typ = indirectType(val.Type())
+	}
+	h.OnMarshal(typ, time.Since(start), *err)
+}
+
+func hookUnmarshal(h Hooks, dest reflect.Value, start time.Time, err *error) {
+	h.OnUnmarshal(indirectType(dest.Type()), time.Since(start), *err)
+}
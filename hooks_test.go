@@ -0,0 +1,49 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type hookEvent struct {
+	unmarshal bool
+	typ       reflect.Type
+	err       error
+}
+
+type recordHooks struct{ events []hookEvent }
+
+func (r *recordHooks) OnMarshal(typ reflect.Type, _ time.Duration, err error) {
+	r.events = append(r.events, hookEvent{typ: typ, err: err})
+}
+
+func (r *recordHooks) OnUnmarshal(typ reflect.Type, _ time.Duration, err error) {
+	r.events = append(r.events, hookEvent{unmarshal: true, typ: typ, err: err})
+}
+
+func TestOptions_Hooks(t *testing.T) {
+	var hooks recordHooks
+	opts := Options{Hooks: &hooks}
+	m := Marshaler{Options: opts}
+	u := Unmarshaler{Options: opts}
+
+	var list []time.Duration
+	assert.NoError(t, u.Unmarshal("1s,2s", reflect.ValueOf(&list)))
+	var i int
+	haveErr := u.Unmarshal("nope", reflect.ValueOf(&i))
+	assert.Error(t, haveErr)
+	_, _ = m.Marshal(reflect.ValueOf(list))
+
+	assert.Equal(t, []hookEvent{
+		{unmarshal: true, typ: reflect.TypeOf(list)},
+		{unmarshal: true, typ: reflect.TypeOf(i), err: haveErr},
+		{typ: reflect.TypeOf(list)},
+	}, hooks.events)
+}
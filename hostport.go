@@ -0,0 +1,83 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"net"
+	"strconv"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*HostPort)(nil)
+	_ encoding.TextUnmarshaler = (*HostPort)(nil)
+)
+
+// HostPort is a host and port pair, e.g. "localhost:8080" or "[::1]:443".
+// IPv6 hosts must be enclosed in square brackets. The host may be empty, e.g.
+// ":8080", which is common for listen addresses.
+type HostPort struct {
+	host string
+	port uint16
+}
+
+// NewHostPort creates a new HostPort from host and port.
+func NewHostPort(host string, port uint16) HostPort {
+	return HostPort{host: host, port: port}
+}
+
+// ParseHostPort parses str as a HostPort using net.SplitHostPort. The port
+// must be numeric.
+func ParseHostPort(str string) (HostPort, error) {
+	host, port, err := net.SplitHostPort(str)
+	if err != nil {
+		return HostPort{}, errors.Wrap(err, ErrParseFailure)
+	}
+
+	p, err := strconv.ParseUint(port, 10, 16)
+	if kind := errKind(err); kind != nil {
+		return HostPort{}, errors.Wrap(err, kind)
+	}
+	return HostPort{host: host, port: uint16(p)}, nil
+}
+
+// Host returns the host without square brackets, e.g. "::1" for "[::1]:443".
+func (hp HostPort) Host() string { return hp.host }
+
+// Port returns the port.
+func (hp HostPort) Port() uint16 { return hp.port }
+
+// IsZero indicates if HostPort is the zero value.
+func (hp HostPort) IsZero() bool { return hp == HostPort{} }
+
+// String returns the host and port joined using net.JoinHostPort, or an empty
+// string when HostPort is the zero value.
+func (hp HostPort) String() string {
+	if hp.IsZero() {
+		return ""
+	}
+	return net.JoinHostPort(hp.host, strconv.FormatUint(uint64(hp.port), 10))
+}
+
+// MarshalText returns HostPort formatted as text.
+func (hp HostPort) MarshalText() ([]byte, error) { return []byte(hp.String()), nil }
+
+// UnmarshalText parses text as a HostPort. An empty text results in the zero
+// value.
+func (hp *HostPort) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*hp = HostPort{}
+		return nil
+	}
+
+	x, err := ParseHostPort(string(text))
+	if err != nil {
+		return err
+	}
+	*hp = x
+	return nil
+}
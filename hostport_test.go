@@ -0,0 +1,66 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHostPort(t *testing.T) {
+	tests := map[string]struct {
+		wantHost string
+		wantPort uint16
+	}{
+		"localhost:8080":   {wantHost: "localhost", wantPort: 8080},
+		"127.0.0.1:80":     {wantHost: "127.0.0.1", wantPort: 80},
+		"[::1]:443":        {wantHost: "::1", wantPort: 443},
+		"[fe80::1%en0]:22": {wantHost: "fe80::1%en0", wantPort: 22},
+		":8080":            {wantPort: 8080},
+	}
+	for input, tc := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseHostPort(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, tc.wantHost, have.Host())
+			assert.Equal(t, tc.wantPort, have.Port())
+			assert.Equal(t, input, have.String())
+		})
+	}
+
+	invalid := map[string]error{
+		"localhost":         ErrParseFailure,
+		"::1:443":           ErrParseFailure,
+		"localhost:http":    ErrParseFailure,
+		"localhost:":        ErrParseFailure,
+		"localhost:-1":      ErrParseFailure,
+		"localhost:1e3":     ErrParseFailure,
+		"localhost:9999999": ErrValidationFailure,
+	}
+	for input, wantErr := range invalid {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseHostPort(input)
+			assert.ErrorIs(t, haveErr, wantErr)
+		})
+	}
+}
+
+func TestHostPort(t *testing.T) {
+	var have HostPort
+	assert.NoError(t, Unmarshal("[::1]:443", &have))
+	assert.Equal(t, NewHostPort("::1", 443), have)
+	assert.Equal(t, Value("[::1]:443"), MustMarshal(have))
+}
+
+func TestHostPort_zero(t *testing.T) {
+	assert.Equal(t, Value(""), MustMarshal(HostPort{}))
+
+	hp := NewHostPort("localhost", 8080)
+	assert.NoError(t, hp.UnmarshalText(nil))
+	assert.True(t, hp.IsZero())
+	assert.NoError(t, Unmarshal(MustMarshal(HostPort{}), &hp, WithEmptyMode(EmptyZero)))
+	assert.True(t, hp.IsZero())
+}
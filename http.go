@@ -0,0 +1,153 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*HTTPMethod)(nil)
+	_ encoding.TextUnmarshaler = (*HTTPMethod)(nil)
+	_ encoding.TextMarshaler   = (*HTTPStatus)(nil)
+	_ encoding.TextUnmarshaler = (*HTTPStatus)(nil)
+)
+
+var httpMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodConnect,
+	http.MethodOptions,
+	http.MethodTrace,
+}
+
+// HTTPMethod is a validated HTTP request method. Standard methods (e.g. "GET"
+// or "post") are matched case-insensitively and normalized to uppercase.
+// Extension methods (e.g. "PROPFIND") must be valid tokens as defined in
+// RFC 9110 and are kept as is.
+type HTTPMethod string
+
+// ParseHTTPMethod parses str as a HTTPMethod.
+func ParseHTTPMethod(str string) (HTTPMethod, error) {
+	for _, m := range httpMethods {
+		if strings.EqualFold(str, m) {
+			return HTTPMethod(m), nil
+		}
+	}
+	if str == "" || strings.IndexFunc(str, func(r rune) bool { return !isTokenChar(r) }) >= 0 {
+		return "", errors.Wrap(errors.Newf("invalid http method `%s`", str), ErrParseFailure)
+	}
+	return HTTPMethod(str), nil
+}
+
+func isTokenChar(r rune) bool {
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return true
+	}
+	return strings.ContainsRune("!#$%&'*+-.^_`|~", r)
+}
+
+// String returns the HTTPMethod as string.
+func (m HTTPMethod) String() string { return string(m) }
+
+// MarshalText returns the HTTPMethod as text.
+func (m HTTPMethod) MarshalText() ([]byte, error) { return []byte(m), nil }
+
+// UnmarshalText parses text as a HTTPMethod. An empty text results in the zero
+// value.
+func (m *HTTPMethod) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*m = ""
+		return nil
+	}
+
+	x, err := ParseHTTPMethod(string(text))
+	if err != nil {
+		return err
+	}
+	*m = x
+	return nil
+}
+
+// HTTPMethods is a list of HTTPMethod.
+type HTTPMethods []HTTPMethod
+
+// Contains indicates if HTTPMethods contains method m.
+func (ms HTTPMethods) Contains(m string) bool {
+	for _, x := range ms {
+		if string(x) == m {
+			return true
+		}
+	}
+	return false
+}
+
+// HTTPStatus is a validated HTTP status code within the range 100-599.
+type HTTPStatus int
+
+// ParseHTTPStatus parses str as a HTTPStatus.
+func ParseHTTPStatus(str string) (HTTPStatus, error) {
+	i, err := strconv.Atoi(str)
+	if err != nil {
+		return 0, errors.Wrap(err, ErrParseFailure)
+	}
+	if i < 100 || i > 599 {
+		return 0, errors.Wrap(errors.Newf("invalid http status `%d`", i), ErrValidationFailure)
+	}
+	return HTTPStatus(i), nil
+}
+
+// Text returns the text of the HTTPStatus as returned by http.StatusText.
+func (s HTTPStatus) Text() string { return http.StatusText(int(s)) }
+
+// Class returns the class of the HTTPStatus, e.g. 4 for 404.
+func (s HTTPStatus) Class() int { return int(s) / 100 }
+
+// MarshalText returns the HTTPStatus as text, or an empty text when HTTPStatus
+// is the zero value.
+func (s HTTPStatus) MarshalText() ([]byte, error) {
+	if s == 0 {
+		return nil, nil
+	}
+	return []byte(strconv.Itoa(int(s))), nil
+}
+
+// UnmarshalText parses text as a HTTPStatus. An empty text results in the zero
+// value.
+func (s *HTTPStatus) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*s = 0
+		return nil
+	}
+
+	x, err := ParseHTTPStatus(string(text))
+	if err != nil {
+		return err
+	}
+	*s = x
+	return nil
+}
+
+// HTTPStatuses is a list of HTTPStatus.
+type HTTPStatuses []HTTPStatus
+
+// Contains indicates if HTTPStatuses contains status code s.
+func (ss HTTPStatuses) Contains(s int) bool {
+	for _, x := range ss {
+		if int(x) == s {
+			return true
+		}
+	}
+	return false
+}
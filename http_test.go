@@ -0,0 +1,81 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHTTPMethod(t *testing.T) {
+	tests := map[string]HTTPMethod{
+		"GET":      http.MethodGet,
+		"post":     http.MethodPost,
+		"Options":  http.MethodOptions,
+		"PROPFIND": "PROPFIND",
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseHTTPMethod(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, want, have)
+		})
+	}
+
+	for _, input := range []string{"", "GE T", "GET/"} {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseHTTPMethod(input)
+			assert.ErrorIs(t, haveErr, ErrParseFailure)
+		})
+	}
+}
+
+func TestParseHTTPStatus(t *testing.T) {
+	have, haveErr := ParseHTTPStatus("404")
+	assert.NoError(t, haveErr)
+	assert.Equal(t, HTTPStatus(http.StatusNotFound), have)
+	assert.Equal(t, "Not Found", have.Text())
+	assert.Equal(t, 4, have.Class())
+
+	_, haveErr = ParseHTTPStatus("4o4")
+	assert.ErrorIs(t, haveErr, ErrParseFailure)
+	_, haveErr = ParseHTTPStatus("600")
+	assert.ErrorIs(t, haveErr, ErrValidationFailure)
+}
+
+func TestHTTPLists(t *testing.T) {
+	var methods HTTPMethods
+	assert.NoError(t, Unmarshal("get,Post,PUT", &methods))
+	assert.Equal(t, HTTPMethods{http.MethodGet, http.MethodPost, http.MethodPut}, methods)
+	assert.True(t, methods.Contains(http.MethodPost))
+	assert.False(t, methods.Contains(http.MethodDelete))
+	assert.Equal(t, Value("GET,POST,PUT"), MustMarshal(methods))
+
+	var statuses HTTPStatuses
+	assert.NoError(t, Unmarshal("502,503,504", &statuses))
+	assert.True(t, statuses.Contains(http.StatusServiceUnavailable))
+	assert.False(t, statuses.Contains(http.StatusInternalServerError))
+
+	assert.ErrorIs(t, Unmarshal("500,99", &statuses), ErrValidationFailure)
+}
+
+func TestHTTP_zero(t *testing.T) {
+	assert.Equal(t, Value(""), MustMarshal(HTTPMethod("")))
+	assert.Equal(t, Value(""), MustMarshal(HTTPStatus(0)))
+
+	m := HTTPMethod("GET")
+	assert.NoError(t, m.UnmarshalText(nil))
+	assert.Equal(t, HTTPMethod(""), m)
+	assert.NoError(t, Unmarshal(MustMarshal(HTTPMethod("")), &m, WithEmptyMode(EmptyZero)))
+	assert.Equal(t, HTTPMethod(""), m)
+
+	s := HTTPStatus(404)
+	assert.NoError(t, s.UnmarshalText(nil))
+	assert.Equal(t, HTTPStatus(0), s)
+	assert.NoError(t, Unmarshal(MustMarshal(HTTPStatus(0)), &s, WithEmptyMode(EmptyZero)))
+	assert.Equal(t, HTTPStatus(0), s)
+}
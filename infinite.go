@@ -0,0 +1,72 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*InfiniteDuration)(nil)
+	_ encoding.TextUnmarshaler = (*InfiniteDuration)(nil)
+)
+
+// Infinite is the InfiniteDuration without limit. It equals the maximum
+// time.Duration, so it can be used as is in comparisons and with timers.
+const Infinite = InfiniteDuration(math.MaxInt64)
+
+// InfiniteDuration is a time.Duration which can also be infinite, e.g. to
+// disable a timeout. The raw Values "infinite", "inf", "never" and "0" (in
+// any case) result in Infinite, which is marshaled as "infinite". All other
+// Values are parsed using time.ParseDuration.
+type InfiniteDuration time.Duration
+
+// ParseInfiniteDuration parses str as an InfiniteDuration.
+func ParseInfiniteDuration(str string) (InfiniteDuration, error) {
+	switch strings.ToLower(strings.TrimSpace(str)) {
+	case "infinite", "inf", "never", "0":
+		return Infinite, nil
+	}
+
+	d, err := Value(str).Duration()
+	if err != nil {
+		return 0, err
+	}
+	return InfiniteDuration(d), nil
+}
+
+// IsInfinite indicates if the InfiniteDuration is Infinite.
+func (d InfiniteDuration) IsInfinite() bool { return d == Infinite }
+
+// Duration returns the InfiniteDuration as time.Duration. Infinite results in
+// the maximum time.Duration.
+func (d InfiniteDuration) Duration() time.Duration { return time.Duration(d) }
+
+// String returns "infinite" when the InfiniteDuration is Infinite, or the
+// formatted time.Duration otherwise.
+func (d InfiniteDuration) String() string {
+	if d.IsInfinite() {
+		return "infinite"
+	}
+	return time.Duration(d).String()
+}
+
+// MarshalText marshals InfiniteDuration into text.
+func (d InfiniteDuration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText unmarshals text into InfiniteDuration.
+func (d *InfiniteDuration) UnmarshalText(text []byte) error {
+	x, err := ParseInfiniteDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = x
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInfiniteDuration(t *testing.T) {
+	tests := map[string]struct {
+		input string
+		want  InfiniteDuration
+	}{
+		"infinite": {input: "infinite", want: Infinite},
+		"inf":      {input: "Inf", want: Infinite},
+		"never":    {input: " NEVER ", want: Infinite},
+		"zero":     {input: "0", want: Infinite},
+		"duration": {input: "1m30s", want: InfiniteDuration(90 * time.Second)},
+		"zero ms":  {input: "0ms", want: 0},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := ParseInfiniteDuration(tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, have)
+		})
+	}
+
+	_, err := ParseInfiniteDuration("forever")
+	assert.ErrorIs(t, err, ErrParseFailure)
+}
+
+func TestInfiniteDuration(t *testing.T) {
+	assert.True(t, Infinite.IsInfinite())
+	assert.Equal(t, time.Duration(math.MaxInt64), Infinite.Duration())
+	assert.Equal(t, "infinite", Infinite.String())
+	assert.Equal(t, "1m0s", InfiniteDuration(time.Minute).String())
+
+	var have struct {
+		Timeout InfiniteDuration `rawconv:"timeout"`
+		TTL     InfiniteDuration `rawconv:"ttl"`
+	}
+	_, err := UnmarshalStruct(Values{"timeout": "never", "ttl": "5s"}, &have)
+	assert.NoError(t, err)
+	assert.Equal(t, Infinite, have.Timeout)
+	assert.Equal(t, InfiniteDuration(5*time.Second), have.TTL)
+
+	val, err := Marshal(have.Timeout)
+	assert.NoError(t, err)
+	assert.Equal(t, Value("infinite"), val)
+}
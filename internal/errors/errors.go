@@ -0,0 +1,22 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package errors is the single place the rawconv packages create, wrap and
+// inspect their errors. By default, it uses github.com/go-pogo/errors, which
+// adds stack traces to errors. Build with the rawconv_stderrors tag, e.g.
+// "go build -tags rawconv_stderrors", to use the standard library errors and
+// fmt packages instead, so github.com/go-pogo/errors is not compiled into the
+// binary.
+package errors
+
+import stderrors "errors"
+
+// Is is an alias of errors.Is.
+func Is(err, target error) bool { return stderrors.Is(err, target) }
+
+// As is an alias of errors.As.
+func As(err error, target any) bool { return stderrors.As(err, target) }
+
+// Unwrap is an alias of errors.Unwrap.
+func Unwrap(err error) error { return stderrors.Unwrap(err) }
@@ -0,0 +1,49 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package errors
+
+import (
+	stderrors "errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	const msg Msg = "some error"
+
+	assert.Nil(t, New(nil))
+	assert.ErrorIs(t, New(msg), msg)
+	assert.Equal(t, "some error", New(msg).Error())
+	assert.Equal(t, "other error", New("other error").Error())
+}
+
+func TestNewf(t *testing.T) {
+	const msg Msg = "some error"
+
+	err := Newf("%w `%s`", msg, "foo")
+	assert.ErrorIs(t, err, msg)
+	assert.Equal(t, "some error `foo`", err.Error())
+}
+
+func TestWrap(t *testing.T) {
+	const msg Msg = "some error"
+	cause := stderrors.New("cause")
+
+	assert.Nil(t, Wrap(nil, msg))
+
+	err := Wrap(cause, msg)
+	assert.ErrorIs(t, err, msg)
+	assert.ErrorIs(t, err, cause)
+	assert.Same(t, cause, Unwrap(err))
+}
+
+func TestWithStack(t *testing.T) {
+	cause := stderrors.New("cause")
+
+	assert.Nil(t, WithStack(nil))
+	assert.ErrorIs(t, WithStack(cause), cause)
+	assert.Equal(t, "cause", WithStack(cause).Error())
+}
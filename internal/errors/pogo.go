@@ -0,0 +1,31 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !rawconv_stderrors
+
+package errors
+
+import "github.com/go-pogo/errors"
+
+// Msg is an error message which can be used as a constant error.
+type Msg = errors.Msg
+
+// New creates a new error from msg, which can be either a string or Msg.
+func New(msg any) error { return errors.New(msg) }
+
+// Newf formats an error message according to a format specifier and provided
+// arguments, the %w verb wraps an error.
+func Newf(format string, args ...any) error { return errors.Newf(format, args...) }
+
+// Wrap creates a new error with msg, which can be either a string or Msg,
+// that wraps around cause. It returns nil when cause is nil.
+func Wrap(cause error, msg any) error { return errors.Wrap(cause, msg) }
+
+// WithStack adds a stack trace to err. It returns nil when err is nil.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	return errors.WithStack(err)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build rawconv_stderrors
+
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+)
+
+// Msg is an error message which can be used as a constant error.
+type Msg string
+
+func (m Msg) String() string { return string(m) }
+
+func (m Msg) Error() string { return string(m) }
+
+func (m Msg) GoString() string { return `errors.Msg("` + string(m) + `")` }
+
+// New creates a new error from msg, which can be either a string or Msg.
+func New(msg any) error {
+	switch v := msg.(type) {
+	case nil:
+		return nil
+	case Msg:
+		return &msgError{msg: v}
+	case string:
+		return stderrors.New(v)
+	default:
+		panic(fmt.Sprintf("errors.New: unsupported type %T", msg))
+	}
+}
+
+// Newf formats an error message according to a format specifier and provided
+// arguments, the %w verb wraps an error.
+func Newf(format string, args ...any) error { return fmt.Errorf(format, args...) }
+
+// Wrap creates a new error with msg, which can be either a string or Msg,
+// that wraps around cause. It returns nil when cause is nil.
+func Wrap(cause error, msg any) error {
+	if cause == nil {
+		return nil
+	}
+
+	switch v := msg.(type) {
+	case nil:
+		return cause
+	case Msg:
+		return &msgError{msg: v, cause: cause}
+	case string:
+		return &msgError{msg: Msg(v), cause: cause}
+	default:
+		panic(fmt.Sprintf("errors.Wrap: unsupported type %T", msg))
+	}
+}
+
+// WithStack returns err as is, no stack traces are recorded.
+func WithStack(err error) error { return err }
+
+// msgError is an error with a Msg which matches using errors.Is, optionally
+// wrapping a cause.
+type msgError struct {
+	msg   Msg
+	cause error
+}
+
+func (e *msgError) Is(target error) bool {
+	//goland:noinspection GoTypeAssertionOnErrors
+	m, ok := target.(Msg)
+	return ok && e.msg == m
+}
+
+func (e *msgError) Unwrap() error { return e.cause }
+
+func (e *msgError) Error() string { return e.msg.String() }
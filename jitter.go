@@ -0,0 +1,114 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*JitteredDuration)(nil)
+	_ encoding.TextUnmarshaler = (*JitteredDuration)(nil)
+)
+
+// JitteredDuration is a duration which varies randomly between Min and Max,
+// e.g. to spread retries or polling over time and prevent thundering herds.
+// Its raw Value is either a base with jitter, e.g. "30s±5s" or "30s+/-5s", a
+// range, e.g. "25s..35s", or a single duration without jitter, e.g. "30s".
+type JitteredDuration struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// ParseJitteredDuration parses str as a JitteredDuration. Both Min and Max
+// must not be negative, and Min must not exceed Max.
+func ParseJitteredDuration(str string) (JitteredDuration, error) {
+	var jd JitteredDuration
+	var err error
+
+	if base, jitter, ok := cutJitter(str); ok {
+		var b, j time.Duration
+		if b, err = Value(strings.TrimSpace(base)).Duration(); err != nil {
+			return jd, err
+		}
+		if j, err = Value(strings.TrimSpace(jitter)).Duration(); err != nil {
+			return jd, err
+		}
+		if j < 0 {
+			return jd, errors.Wrap(errors.Newf("negative jitter `%s`", jitter), ErrValidationFailure)
+		}
+		jd.Min, jd.Max = b-j, b+j
+	} else if min, max, ok := strings.Cut(str, ".."); ok {
+		if jd.Min, err = Value(strings.TrimSpace(min)).Duration(); err != nil {
+			return jd, err
+		}
+		if jd.Max, err = Value(strings.TrimSpace(max)).Duration(); err != nil {
+			return jd, err
+		}
+	} else {
+		if jd.Min, err = Value(strings.TrimSpace(str)).Duration(); err != nil {
+			return jd, err
+		}
+		jd.Max = jd.Min
+	}
+
+	if jd.Min < 0 {
+		return jd, errors.Wrap(errors.Newf("negative duration in `%s`", str), ErrValidationFailure)
+	}
+	if jd.Min > jd.Max {
+		return jd, errors.Wrap(errors.Newf("min exceeds max in `%s`", str), ErrValidationFailure)
+	}
+	return jd, nil
+}
+
+func cutJitter(str string) (before, after string, found bool) {
+	if before, after, found = strings.Cut(str, "±"); found {
+		return
+	}
+	return strings.Cut(str, "+/-")
+}
+
+// Base returns the duration halfway between Min and Max.
+func (jd JitteredDuration) Base() time.Duration { return jd.Min + jd.Jitter() }
+
+// Jitter returns the maximum deviation from Base.
+func (jd JitteredDuration) Jitter() time.Duration { return (jd.Max - jd.Min) / 2 }
+
+// Rand returns a random duration between Min and Max, inclusive.
+func (jd JitteredDuration) Rand() time.Duration {
+	if jd.Max <= jd.Min {
+		return jd.Min
+	}
+	return jd.Min + time.Duration(rand.Int63n(int64(jd.Max-jd.Min)+1))
+}
+
+// String returns JitteredDuration as a range, e.g. "25s..35s", or as a single
+// duration when Min equals Max.
+func (jd JitteredDuration) String() string {
+	if jd.Min == jd.Max {
+		return jd.Min.String()
+	}
+	return jd.Min.String() + ".." + jd.Max.String()
+}
+
+// MarshalText returns the result of String.
+func (jd JitteredDuration) MarshalText() ([]byte, error) {
+	return []byte(jd.String()), nil
+}
+
+// UnmarshalText parses text using ParseJitteredDuration.
+func (jd *JitteredDuration) UnmarshalText(text []byte) error {
+	x, err := ParseJitteredDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*jd = x
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJitteredDuration(t *testing.T) {
+	tests := map[string]struct {
+		want    JitteredDuration
+		wantStr string
+	}{
+		"30s±5s":      {JitteredDuration{25 * time.Second, 35 * time.Second}, "25s..35s"},
+		"30s +/- 5s":  {JitteredDuration{25 * time.Second, 35 * time.Second}, "25s..35s"},
+		"30s..45s":    {JitteredDuration{30 * time.Second, 45 * time.Second}, "30s..45s"},
+		"1m":          {JitteredDuration{time.Minute, time.Minute}, "1m0s"},
+		"100ms±100ms": {JitteredDuration{0, 200 * time.Millisecond}, "0s..200ms"},
+	}
+	for input, tc := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseJitteredDuration(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, tc.want, have)
+			assert.Equal(t, tc.wantStr, have.String())
+
+			var dest JitteredDuration
+			assert.NoError(t, Unmarshal(MustMarshal(have), &dest))
+			assert.Equal(t, have, dest)
+		})
+	}
+
+	errs := map[string]error{
+		"":         ErrParseFailure,
+		"30s±":     ErrParseFailure,
+		"foo..45s": ErrParseFailure,
+		"1s±5s":    ErrValidationFailure,
+		"30s±-5s":  ErrValidationFailure,
+		"45s..30s": ErrValidationFailure,
+		"-1s":      ErrValidationFailure,
+	}
+	for input, wantErr := range errs {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseJitteredDuration(input)
+			assert.ErrorIs(t, haveErr, wantErr)
+		})
+	}
+}
+
+func TestJitteredDuration_Rand(t *testing.T) {
+	jd := JitteredDuration{Min: time.Second, Max: 2 * time.Second}
+	assert.Equal(t, 1500*time.Millisecond, jd.Base())
+	assert.Equal(t, 500*time.Millisecond, jd.Jitter())
+
+	for i := 0; i < 100; i++ {
+		d := jd.Rand()
+		assert.GreaterOrEqual(t, d, jd.Min)
+		assert.LessOrEqual(t, d, jd.Max)
+	}
+	assert.Equal(t, time.Second, JitteredDuration{Min: time.Second, Max: time.Second}.Rand())
+}
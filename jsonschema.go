@@ -0,0 +1,251 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// JSONSchemaDraft is the JSON Schema draft JSONSchema generates schemas for.
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchemaProperty is a property of a JSONSchemaObject. Each property
+// describes the raw string Value a struct field accepts.
+type JSONSchemaProperty struct {
+	Type        string   `json:"type"`
+	Description string   `json:"description,omitempty"`
+	Default     *Value   `json:"default,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
+	Format      string   `json:"format,omitempty"`
+	Enum        []string `json:"enum,omitempty"`
+	MaxLength   *int     `json:"maxLength,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty"`
+}
+
+// JSONSchemaObject is a JSON Schema which describes an object of raw string
+// values, indexed by their keys.
+type JSONSchemaObject struct {
+	Schema     string                        `json:"$schema"`
+	Type       string                        `json:"type"`
+	Properties map[string]JSONSchemaProperty `json:"properties"`
+	Required   []string                      `json:"required,omitempty"`
+}
+
+// JSONSchema generates a JSON Schema for struct v, using the global
+// Marshaler. See Marshaler.JSONSchema for additional details.
+func JSONSchema(v any) ([]byte, error) { return marshaler.JSONSchema(v) }
+
+// JSONSchema generates a JSON Schema which describes the raw string values
+// that are accepted by the fields of struct v. See DescribeStruct for details
+// on how fields, defaults and documentation are derived from v.
+func (m *Marshaler) JSONSchema(v any) ([]byte, error) {
+	obj, err := m.JSONSchemaObject(v)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(obj)
+	return b, errors.WithStack(err)
+}
+
+// JSONSchemaObject is like JSONSchema but returns the JSONSchemaObject
+// instead of its JSON encoding.
+func (m *Marshaler) JSONSchemaObject(v any) (*JSONSchemaObject, error) {
+	fields, descs, err := m.describeStruct(v)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := JSONSchemaObject{
+		Schema:     JSONSchemaDraft,
+		Type:       "object",
+		Properties: make(map[string]JSONSchemaProperty, len(descs)),
+	}
+	for i, desc := range descs {
+		fm, err := m.forField(fields[i].tag)
+		if err != nil {
+			return nil, errors.WithStack(&FieldError{
+				Field: desc.Field,
+				Key:   desc.Key,
+				Err:   err,
+			})
+		}
+
+		prop := fm.jsonSchemaProperty(fields[i].typ)
+		prop.Description = desc.Doc
+		prop.Deprecated = desc.Deprecated
+		if desc.Default != "" && desc.Default != Redacted {
+			prop.Default = &descs[i].Default
+		}
+
+		obj.Properties[desc.Key] = prop
+		if desc.Required {
+			obj.Required = append(obj.Required, desc.Key)
+		}
+	}
+	return &obj, nil
+}
+
+const (
+	intPattern      = `^[+-]?(0[xX][0-9a-fA-F_]+|0[bB][01_]+|0[oO]?[0-7_]+|[0-9_]+)$`
+	uintPattern     = `^(0[xX][0-9a-fA-F_]+|0[bB][01_]+|0[oO]?[0-7_]+|[0-9_]+)$`
+	floatPattern    = `^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([eE][+-]?[0-9]+)?$`
+	durationPattern = `^([+-]?(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|ms|s|m|h))+|0)$`
+	decimalPattern  = `^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`
+
+	nanPattern = `[nN][aA][nN]`
+	infPattern = `[+-]?[iI][nN][fF]([iI][nN][iI][tT][yY])?`
+)
+
+var (
+	runeType     = reflect.TypeOf(rune(0))
+	durationType = reflect.TypeOf(time.Nanosecond)
+	weekdayType  = reflect.TypeOf(time.Sunday)
+	monthType    = reflect.TypeOf(time.January)
+	timeType     = reflect.TypeOf(time.Time{})
+	urlType      = reflect.TypeOf(url.URL{})
+)
+
+// jsonSchemaProperty returns the JSONSchemaProperty of typ, of which the
+// pattern matches the values an Unmarshaler with the Options of Marshaler m
+// accepts. Patterns based on the kind of typ are only used when typ is
+// handled by its kind, types handled by a (registered) func or interface may
+// accept any string.
+func (m *Marshaler) jsonSchemaProperty(typ reflect.Type) JSONSchemaProperty {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	prop := JSONSchemaProperty{Type: "string"}
+	switch typ {
+	case runeType:
+		one := 1
+		prop.MaxLength = &one
+		return prop
+	case durationType:
+		if m.DurationFormat == DurationDefault {
+			prop.Pattern = durationPattern
+		} else {
+			prop.Pattern = decimalPattern
+		}
+		return prop
+	case weekdayType:
+		prop.Enum = enumValues(weekdayNames(), 0)
+		return prop
+	case monthType:
+		prop.Enum = enumValues(monthNames(), 1)
+		return prop
+	case timeType:
+		prop.Format = "date-time"
+		return prop
+	case urlType:
+		prop.Format = "uri"
+		return prop
+	}
+
+	if _, match := m.lookup(typ); !match.kind {
+		return prop
+	}
+	u := Unmarshaler{Options: m.Options}
+	if _, match := u.lookup(typ); !match.kind {
+		return prop
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		prop.Enum = []string{"1", "t", "T", "TRUE", "true", "True", "0", "f", "F", "FALSE", "false", "False"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		prop.Pattern = intBasePattern(m.IntBase, true)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		prop.Pattern = intBasePattern(m.IntBase, false)
+	case reflect.Float32, reflect.Float64:
+		prop.Pattern = floatSpecialPattern(m.Options)
+	}
+	return prop
+}
+
+func weekdayNames() []string {
+	names := make([]string, 0, 7)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		names = append(names, d.String())
+	}
+	return names
+}
+
+func monthNames() []string {
+	names := make([]string, 0, 12)
+	for m := time.January; m <= time.December; m++ {
+		names = append(names, m.String())
+	}
+	return names
+}
+
+// enumValues returns the values accepted for an enum with names, which are
+// matched case-insensitively by their full or abbreviated (3 letter) name, or
+// by their number starting at first.
+func enumValues(names []string, first int) []string {
+	res := make([]string, 0, len(names)*7)
+	for _, name := range names {
+		res = append(res, name, strings.ToLower(name), strings.ToUpper(name))
+		if abbr := name[:3]; abbr != name {
+			res = append(res, abbr, strings.ToLower(abbr), strings.ToUpper(abbr))
+		}
+	}
+	for i := range names {
+		res = append(res, strconv.Itoa(first+i))
+	}
+	return res
+}
+
+// intBasePattern returns the pattern of an (un)signed integer in base. Base 0
+// results in intPattern or uintPattern, as the base is then derived from the
+// prefix of the value.
+func intBasePattern(base int, signed bool) string {
+	if base < 2 || base > 36 {
+		if signed {
+			return intPattern
+		}
+		return uintPattern
+	}
+
+	var class strings.Builder
+	if base <= 10 {
+		class.WriteString("0-" + strconv.Itoa(base-1))
+	} else {
+		last := rune('a' + base - 11)
+		class.WriteString("0-9a-" + string(last) + "A-" + string(unicode.ToUpper(last)))
+	}
+
+	sign := ""
+	if signed {
+		sign = "[+-]?"
+	}
+	return "^" + sign + "[" + class.String() + "]+$"
+}
+
+// floatSpecialPattern returns floatPattern, extended with the special values
+// NaN and (+/-) Inf when they are not rejected by opts.
+func floatSpecialPattern(opts Options) string {
+	if opts.RejectNaN && opts.RejectInf {
+		return floatPattern
+	}
+
+	alts := []string{floatPattern[1 : len(floatPattern)-1]}
+	if !opts.RejectNaN {
+		alts = append(alts, nanPattern)
+	}
+	if !opts.RejectInf {
+		alts = append(alts, infPattern)
+	}
+	return "^(" + strings.Join(alts, "|") + ")$"
+}
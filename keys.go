@@ -0,0 +1,247 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding"
+	"encoding/base64"
+	"encoding/pem"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// Redacted is the text secret values are replaced with when they are marshaled.
+const Redacted = "xxxxx"
+
+// Redacter is implemented by types which contain secrets. Its Redacted method
+// returns a representation of the value which is safe to display. It is used
+// by DescribeStruct and MarshalIndentedTable, regardless of the field's key
+// or tag options.
+type Redacter interface {
+	Redacted() string
+}
+
+const (
+	ErrNoPEMBlock    errors.Msg = "no PEM block found"
+	ErrUnexpectedPEM errors.Msg = "unexpected PEM block type"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*PublicKey)(nil)
+	_ encoding.TextUnmarshaler = (*PublicKey)(nil)
+	_ encoding.TextMarshaler   = (*PrivateKey)(nil)
+	_ encoding.TextUnmarshaler = (*PrivateKey)(nil)
+	_ Redacter                 = (*PrivateKey)(nil)
+	_ encoding.TextMarshaler   = (*CACertPool)(nil)
+	_ encoding.TextUnmarshaler = (*CACertPool)(nil)
+)
+
+// decodePEM decodes all PEM blocks in str. When str is not PEM encoded, it is
+// decoded as base64 which may contain either PEM blocks or a single DER
+// encoded block, in which case a block with an empty type is returned.
+func decodePEM(str string) ([]*pem.Block, error) {
+	data := []byte(strings.TrimSpace(str))
+	if len(data) == 0 {
+		return nil, errors.Wrap(errors.New(ErrNoPEMBlock), ErrParseFailure)
+	}
+	if !bytes.HasPrefix(data, []byte("-----BEGIN")) {
+		var err error
+		if data, err = base64.StdEncoding.DecodeString(string(data)); err != nil {
+			return nil, errors.Wrap(err, ErrParseFailure)
+		}
+		if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN")) {
+			return []*pem.Block{{Bytes: data}}, nil
+		}
+	}
+
+	var blocks []*pem.Block
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) == 0 {
+		return nil, errors.Wrap(errors.New(ErrNoPEMBlock), ErrParseFailure)
+	}
+	return blocks, nil
+}
+
+// PublicKey is a PEM or base64 encoded public key. Supported are PKIX
+// ("PUBLIC KEY") and PKCS #1 ("RSA PUBLIC KEY") encoded keys. It is
+// marshaled as a PEM encoded PKIX public key.
+type PublicKey struct {
+	Key crypto.PublicKey
+}
+
+// ParsePublicKey parses str as a PublicKey.
+func ParsePublicKey(str string) (PublicKey, error) {
+	blocks, err := decodePEM(str)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	var key crypto.PublicKey
+	switch blocks[0].Type {
+	case "", "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(blocks[0].Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(blocks[0].Bytes)
+	default:
+		return PublicKey{}, errors.Wrap(
+			errors.Newf("%w `%s`", ErrUnexpectedPEM, blocks[0].Type),
+			ErrValidationFailure,
+		)
+	}
+	if err != nil {
+		return PublicKey{}, errors.Wrap(err, ErrValidationFailure)
+	}
+	return PublicKey{Key: key}, nil
+}
+
+// MarshalText returns the PublicKey as a PEM encoded PKIX public key.
+func (pk PublicKey) MarshalText() ([]byte, error) {
+	if pk.Key == nil {
+		return nil, nil
+	}
+	der, err := x509.MarshalPKIXPublicKey(pk.Key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// UnmarshalText parses text as a PublicKey.
+func (pk *PublicKey) UnmarshalText(text []byte) error {
+	x, err := ParsePublicKey(string(text))
+	if err != nil {
+		return err
+	}
+	*pk = x
+	return nil
+}
+
+// PrivateKey is a PEM or base64 encoded private key. Supported are PKCS #8
+// ("PRIVATE KEY"), PKCS #1 ("RSA PRIVATE KEY") and SEC 1 ("EC PRIVATE KEY")
+// encoded keys. A PrivateKey is always marshaled as Redacted.
+type PrivateKey struct {
+	Key crypto.PrivateKey
+}
+
+// ParsePrivateKey parses str as a PrivateKey.
+func ParsePrivateKey(str string) (PrivateKey, error) {
+	blocks, err := decodePEM(str)
+	if err != nil {
+		return PrivateKey{}, err
+	}
+
+	var key crypto.PrivateKey
+	switch blocks[0].Type {
+	case "", "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(blocks[0].Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(blocks[0].Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(blocks[0].Bytes)
+	default:
+		return PrivateKey{}, errors.Wrap(
+			errors.Newf("%w `%s`", ErrUnexpectedPEM, blocks[0].Type),
+			ErrValidationFailure,
+		)
+	}
+	if err != nil {
+		return PrivateKey{}, errors.Wrap(err, ErrValidationFailure)
+	}
+	return PrivateKey{Key: key}, nil
+}
+
+// Public returns the PublicKey of the PrivateKey.
+func (pk PrivateKey) Public() PublicKey {
+	if s, ok := pk.Key.(crypto.Signer); ok {
+		return PublicKey{Key: s.Public()}
+	}
+	return PublicKey{}
+}
+
+// String returns Redacted.
+func (pk PrivateKey) String() string { return Redacted }
+
+// Redacted returns Redacted.
+func (pk PrivateKey) Redacted() string { return Redacted }
+
+// MarshalText returns Redacted so the key never leaks when marshaled.
+func (pk PrivateKey) MarshalText() ([]byte, error) { return []byte(Redacted), nil }
+
+// UnmarshalText parses text as a PrivateKey.
+func (pk *PrivateKey) UnmarshalText(text []byte) error {
+	x, err := ParsePrivateKey(string(text))
+	if err != nil {
+		return err
+	}
+	*pk = x
+	return nil
+}
+
+// CACertPool is a pool of one or more PEM or base64 encoded CA certificates.
+type CACertPool struct {
+	Pool  *x509.CertPool
+	Certs []*x509.Certificate
+}
+
+// ParseCACertPool parses str as a CACertPool. All PEM blocks must be
+// certificates and at least one certificate is required.
+func ParseCACertPool(str string) (CACertPool, error) {
+	blocks, err := decodePEM(str)
+	if err != nil {
+		return CACertPool{}, err
+	}
+
+	res := CACertPool{
+		Pool:  x509.NewCertPool(),
+		Certs: make([]*x509.Certificate, 0, len(blocks)),
+	}
+	for _, block := range blocks {
+		if block.Type != "" && block.Type != "CERTIFICATE" {
+			return CACertPool{}, errors.Wrap(
+				errors.Newf("%w `%s`", ErrUnexpectedPEM, block.Type),
+				ErrValidationFailure,
+			)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return CACertPool{}, errors.Wrap(err, ErrValidationFailure)
+		}
+		res.Pool.AddCert(cert)
+		res.Certs = append(res.Certs, cert)
+	}
+	return res, nil
+}
+
+// MarshalText returns the certificates of the CACertPool PEM encoded.
+func (cp CACertPool) MarshalText() ([]byte, error) {
+	var buf bytes.Buffer
+	for _, cert := range cp.Certs {
+		if err := pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText parses text as a CACertPool.
+func (cp *CACertPool) UnmarshalText(text []byte) error {
+	x, err := ParseCACertPool(string(text))
+	if err != nil {
+		return err
+	}
+	*cp = x
+	return nil
+}
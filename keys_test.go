@@ -0,0 +1,113 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	privDer, _ := x509.MarshalPKCS8PrivateKey(key)
+	pubDer, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	ecDer, _ := x509.MarshalECPrivateKey(key)
+
+	tmpl := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ca"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	certDer, _ := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+
+	privPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privDer}))
+	pubPEM := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDer}))
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer}))
+
+	t.Run("PrivateKey", func(t *testing.T) {
+		inputs := map[string]string{
+			"pem":        privPEM,
+			"base64 pem": base64.StdEncoding.EncodeToString([]byte(privPEM)),
+			"base64 der": base64.StdEncoding.EncodeToString(privDer),
+			"ec pem":     string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDer})),
+		}
+		for name, input := range inputs {
+			t.Run(name, func(t *testing.T) {
+				var have PrivateKey
+				assert.NoError(t, Unmarshal(Value(input), &have))
+				assert.True(t, key.Equal(have.Key))
+				assert.True(t, key.PublicKey.Equal(have.Public().Key))
+				assert.Equal(t, Value(Redacted), MustMarshal(have))
+			})
+		}
+	})
+	t.Run("PublicKey", func(t *testing.T) {
+		var have PublicKey
+		assert.NoError(t, Unmarshal(Value(base64.StdEncoding.EncodeToString(pubDer)), &have))
+		assert.True(t, key.PublicKey.Equal(have.Key))
+		assert.Equal(t, Value(pubPEM), MustMarshal(have))
+	})
+	t.Run("CACertPool", func(t *testing.T) {
+		var have CACertPool
+		assert.NoError(t, Unmarshal(Value(certPEM+certPEM), &have))
+		assert.Len(t, have.Certs, 2)
+		assert.NotNil(t, have.Pool)
+		assert.Equal(t, Value(certPEM+certPEM), MustMarshal(have))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tests := map[string]struct {
+			parse   func(string) error
+			input   string
+			wantErr error
+		}{
+			"empty": {
+				parse:   func(s string) error { _, err := ParsePrivateKey(s); return err },
+				wantErr: ErrParseFailure,
+			},
+			"invalid base64": {
+				parse:   func(s string) error { _, err := ParsePublicKey(s); return err },
+				input:   "not base64!",
+				wantErr: ErrParseFailure,
+			},
+			"invalid der": {
+				parse:   func(s string) error { _, err := ParsePrivateKey(s); return err },
+				input:   base64.StdEncoding.EncodeToString([]byte("garbage")),
+				wantErr: ErrValidationFailure,
+			},
+			"unexpected block": {
+				parse:   func(s string) error { _, err := ParsePublicKey(s); return err },
+				input:   certPEM,
+				wantErr: ErrUnexpectedPEM,
+			},
+			"private key as cert": {
+				parse:   func(s string) error { _, err := ParseCACertPool(s); return err },
+				input:   privPEM,
+				wantErr: ErrValidationFailure,
+			},
+		}
+		for name, tc := range tests {
+			t.Run(name, func(t *testing.T) {
+				assert.ErrorIs(t, tc.parse(tc.input), tc.wantErr)
+			})
+		}
+	})
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import "sort"
+
+// Layer is a named Source within Layered.
+type Layer struct {
+	Name   string
+	Source Source
+	// Default indicates the layer contains default values.
+	Default bool
+}
+
+var _ OriginSource = (*Layered)(nil)
+
+// Layered merges multiple Source(s) into a single Source. Layers which are
+// added later take precedence over layers which are added before them. A
+// common order would be to add defaults first, followed by values from a
+// file, the environment and finally flags.
+type Layered struct {
+	layers []Layer
+}
+
+// NewLayered creates a new Layered with the provided layers, ordered from
+// lowest to highest precedence.
+func NewLayered(layers ...Layer) *Layered {
+	return &Layered{layers: layers}
+}
+
+// Add Source src as a new Layer with name. It takes precedence over all
+// previously added layers.
+func (l *Layered) Add(name string, src Source) *Layered {
+	l.layers = append(l.layers, Layer{Name: name, Source: src})
+	return l
+}
+
+// Layers returns the layers of Layered, ordered from lowest to highest
+// precedence.
+func (l *Layered) Layers() []Layer { return l.layers }
+
+// Lookup returns the Value of key from the layer with the highest precedence
+// which contains key.
+func (l *Layered) Lookup(key string) (Value, bool) {
+	v, _, ok := l.LookupLayer(key)
+	return v, ok
+}
+
+// LookupLayer is like Lookup but also returns the name of the layer which
+// supplied the Value.
+func (l *Layered) LookupLayer(key string) (Value, string, bool) {
+	for i := len(l.layers) - 1; i >= 0; i-- {
+		if l.layers[i].Source == nil {
+			continue
+		}
+		if v, ok := l.layers[i].Source.Lookup(key); ok {
+			return v, l.layers[i].Name, true
+		}
+	}
+	return "", "", false
+}
+
+// LookupOrigin is like Lookup but also returns the Origin of the Value. When
+// the layer's Source is an OriginSource, its Origin is used. An empty
+// Origin.Source is set to the name of the layer.
+func (l *Layered) LookupOrigin(key string) (Value, Origin, bool) {
+	for i := len(l.layers) - 1; i >= 0; i-- {
+		layer := l.layers[i]
+		if layer.Source == nil {
+			continue
+		}
+
+		var v Value
+		var origin Origin
+		var ok bool
+		if src, isOrigin := layer.Source.(OriginSource); isOrigin {
+			v, origin, ok = src.LookupOrigin(key)
+		} else {
+			v, ok = layer.Source.Lookup(key)
+		}
+		if !ok {
+			continue
+		}
+
+		if origin.Source == "" {
+			origin.Source = layer.Name
+		}
+		if layer.Default {
+			origin.Default = true
+		}
+		return v, origin, true
+	}
+	return "", Origin{}, false
+}
+
+// Keys returns the keys of all layers in sorted order.
+func (l *Layered) Keys() []string {
+	set := make(map[string]struct{})
+	for _, layer := range l.layers {
+		if layer.Source == nil {
+			continue
+		}
+		for _, k := range layer.Source.Keys() {
+			set[k] = struct{}{}
+		}
+	}
+
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Values merges all layers into a single Values map, respecting the
+// precedence of the layers.
+func (l *Layered) Values() Values {
+	res := make(Values)
+	for _, layer := range l.layers {
+		if layer.Source == nil {
+			continue
+		}
+		for _, k := range layer.Source.Keys() {
+			res[k], _ = layer.Source.Lookup(k)
+		}
+	}
+	return res
+}
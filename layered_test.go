@@ -0,0 +1,104 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayered(t *testing.T) {
+	l := NewLayered(Layer{Name: "defaults", Source: Values{
+		"host": "localhost",
+		"port": "80",
+	}}).
+		Add("env", Values{"port": "8080"}).
+		Add("empty", nil).
+		Add("flags", Values{"debug": "true"})
+
+	tests := map[string]struct {
+		want      Value
+		wantLayer string
+		wantOk    bool
+	}{
+		"host":    {want: "localhost", wantLayer: "defaults", wantOk: true},
+		"port":    {want: "8080", wantLayer: "env", wantOk: true},
+		"debug":   {want: "true", wantLayer: "flags", wantOk: true},
+		"unknown": {},
+	}
+
+	for key, tc := range tests {
+		t.Run(key, func(t *testing.T) {
+			have, haveLayer, haveOk := l.LookupLayer(key)
+			assert.Equal(t, tc.want, have)
+			assert.Equal(t, tc.wantLayer, haveLayer)
+			assert.Equal(t, tc.wantOk, haveOk)
+
+			have, haveOk = l.Lookup(key)
+			assert.Equal(t, tc.want, have)
+			assert.Equal(t, tc.wantOk, haveOk)
+		})
+	}
+
+	t.Run("LookupOrigin", func(t *testing.T) {
+		_, haveOrigin, _ := l.LookupOrigin("port")
+		assert.Equal(t, Origin{Source: "env"}, haveOrigin)
+	})
+	t.Run("Keys", func(t *testing.T) {
+		assert.Equal(t, []string{"debug", "host", "port"}, l.Keys())
+	})
+	t.Run("Values", func(t *testing.T) {
+		assert.Equal(t, Values{
+			"host":  "localhost",
+			"port":  "8080",
+			"debug": "true",
+		}, l.Values())
+	})
+}
+
+func TestLayered_LookupOrigin(t *testing.T) {
+	l := NewLayered(Layer{
+		Name:    "defaults",
+		Default: true,
+		Source:  Values{"host": "localhost", "port": "80"},
+	}).Add("file", AnnotatedValues{
+		"port": {Value: "8080", Origin: Origin{Source: "config.env", Line: 3}},
+		"host": {Value: "example.com"},
+	})
+
+	tests := map[string]struct {
+		want       Value
+		wantOrigin Origin
+		wantOk     bool
+	}{
+		"host": {
+			want:       "example.com",
+			wantOrigin: Origin{Source: "file"},
+			wantOk:     true,
+		},
+		"port": {
+			want:       "8080",
+			wantOrigin: Origin{Source: "config.env", Line: 3},
+			wantOk:     true,
+		},
+		"unknown": {},
+	}
+
+	for key, tc := range tests {
+		t.Run(key, func(t *testing.T) {
+			have, haveOrigin, haveOk := l.LookupOrigin(key)
+			assert.Equal(t, tc.want, have)
+			assert.Equal(t, tc.wantOrigin, haveOrigin)
+			assert.Equal(t, tc.wantOk, haveOk)
+		})
+	}
+
+	t.Run("default", func(t *testing.T) {
+		l := NewLayered(Layer{Name: "defaults", Default: true, Source: Values{"host": "localhost"}})
+		_, haveOrigin, _ := l.LookupOrigin("host")
+		assert.Equal(t, Origin{Source: "defaults", Default: true}, haveOrigin)
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Limit)(nil)
+	_ encoding.TextUnmarshaler = (*Limit)(nil)
+)
+
+// Unlimited is the Limit without limit. It equals the maximum int64, so it
+// can be used as is in comparisons.
+const Unlimited = Limit(math.MaxInt64)
+
+// Limit is a non-negative count which can also be unlimited, e.g. for
+// connection or queue limits. The raw Values "unlimited" and "max" (in any
+// case) result in Unlimited, which is marshaled as "unlimited". All other
+// Values must be non-negative integers.
+type Limit int64
+
+// ParseLimit parses str as a Limit.
+func ParseLimit(str string) (Limit, error) {
+	str = strings.TrimSpace(str)
+	if strings.EqualFold(str, "unlimited") || strings.EqualFold(str, "max") {
+		return Unlimited, nil
+	}
+
+	x, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, errKind(err))
+	}
+	if x < 0 {
+		return 0, errors.Wrap(errors.Newf("negative limit `%s`", str), ErrValidationFailure)
+	}
+	return Limit(x), nil
+}
+
+// IsUnlimited indicates if the Limit is Unlimited.
+func (l Limit) IsUnlimited() bool { return l == Unlimited }
+
+// Int64 returns the Limit as int64. Unlimited results in math.MaxInt64.
+func (l Limit) Int64() int64 { return int64(l) }
+
+// Allows indicates if n is within the Limit.
+func (l Limit) Allows(n int64) bool { return l.IsUnlimited() || n <= int64(l) }
+
+// String returns "unlimited" when the Limit is Unlimited, or the formatted
+// integer otherwise.
+func (l Limit) String() string {
+	if l.IsUnlimited() {
+		return "unlimited"
+	}
+	return strconv.FormatInt(int64(l), 10)
+}
+
+// MarshalText marshals Limit into text.
+func (l Limit) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText unmarshals text into Limit.
+func (l *Limit) UnmarshalText(text []byte) error {
+	x, err := ParseLimit(string(text))
+	if err != nil {
+		return err
+	}
+	*l = x
+	return nil
+}
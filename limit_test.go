@@ -0,0 +1,63 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := map[string]Limit{
+		"0":         0,
+		"100":       100,
+		" 42 ":      42,
+		"unlimited": Unlimited,
+		"Unlimited": Unlimited,
+		"MAX":       Unlimited,
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, err := ParseLimit(input)
+			assert.NoError(t, err)
+			assert.Equal(t, want, have)
+		})
+	}
+
+	errs := map[string]error{
+		"":                    ErrParseFailure,
+		"infinite":            ErrParseFailure,
+		"1.5":                 ErrParseFailure,
+		"-1":                  ErrValidationFailure,
+		"9223372036854775808": ErrValidationFailure,
+	}
+	for input, wantErr := range errs {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseLimit(input)
+			assert.ErrorIs(t, err, wantErr)
+		})
+	}
+}
+
+func TestLimit(t *testing.T) {
+	assert.True(t, Unlimited.IsUnlimited())
+	assert.Equal(t, int64(math.MaxInt64), Unlimited.Int64())
+	assert.True(t, Unlimited.Allows(math.MaxInt64))
+	assert.True(t, Limit(10).Allows(10))
+	assert.False(t, Limit(10).Allows(11))
+	assert.Equal(t, "unlimited", Unlimited.String())
+	assert.Equal(t, "10", Limit(10).String())
+
+	for _, l := range []Limit{0, 25, Unlimited} {
+		val, err := Marshal(l)
+		assert.NoError(t, err)
+
+		var have Limit
+		assert.NoError(t, Unmarshal(val, &have))
+		assert.Equal(t, l, have)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const (
+	ErrValueTooLong   errors.Msg = "value exceeds maximum length"
+	ErrTooManyItems   errors.Msg = "value exceeds maximum number of items"
+	ErrTooManyEntries errors.Msg = "value exceeds maximum number of entries"
+)
+
+// Limits guard an Unmarshaler against adversarial input, e.g. untrusted
+// headers or query parameters. A zero value means no limit is applied. Arrays,
+// slices and maps cannot be nested, so the nesting depth of a Value is always
+// limited to a single level.
+type Limits struct {
+	// MaxLength is the maximum length of a Value in bytes.
+	MaxLength int
+	// MaxItems is the maximum number of items when unmarshaling to an array
+	// or slice.
+	MaxItems int
+	// MaxEntries is the maximum number of entries when unmarshaling to a map.
+	MaxEntries int
+}
+
+func (l Limits) checkLength(v Value) error {
+	if l.MaxLength > 0 && len(v) > l.MaxLength {
+		return errors.Newf("%w of %d bytes", ErrValueTooLong, l.MaxLength)
+	}
+	return nil
+}
+
+// checkLen checks if n does not exceed max.
+func checkLen(n, max int, msg errors.Msg) error {
+	if max > 0 && n > max {
+		return errors.Newf("%w (%d)", msg, max)
+	}
+	return nil
+}
+
+// checkCount checks the number of parts str would be split into by sep,
+// ignoring separators which are escaped by esc, without actually splitting it.
+func checkCount(str, sep string, esc rune, max int, msg errors.Msg) error {
+	if max <= 0 {
+		return nil
+	}
+	return checkLen(countEscaped(str, sep, esc)+1, max, msg)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimits(t *testing.T) {
+	u := Unmarshaler{Options: Options{Limits: Limits{
+		MaxLength:  16,
+		MaxItems:   3,
+		MaxEntries: 2,
+	}}}
+
+	tests := map[string]struct {
+		input   Value
+		target  any
+		wantErr error
+	}{
+		"string":          {input: "short", target: new(string)},
+		"string too long": {input: Value(strings.Repeat("x", 17)), target: new(string), wantErr: ErrValueTooLong},
+		"slice":           {input: "1,2,3", target: new([]int)},
+		"slice too many":  {input: "1,2,3,4", target: new([]int), wantErr: ErrTooManyItems},
+		"array too many":  {input: "1,2,3,4", target: new([5]int), wantErr: ErrTooManyItems},
+		"map":             {input: "a=1,b=2", target: new(map[string]int)},
+		"map too many":    {input: "a=1,b=2,c=3", target: new(map[string]int), wantErr: ErrTooManyEntries},
+		"func":            {input: "1h30m", target: new(time.Duration)},
+		"func too long":   {input: Value(strings.Repeat("1", 17) + "s"), target: new(time.Duration), wantErr: ErrValueTooLong},
+		"length precedes": {input: Value(strings.Repeat(",", 20)), target: new([]string), wantErr: ErrValueTooLong},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			haveErr := u.Unmarshal(tc.input, reflect.ValueOf(tc.target))
+			if tc.wantErr == nil {
+				assert.NoError(t, haveErr)
+			} else {
+				assert.ErrorIs(t, haveErr, tc.wantErr)
+			}
+		})
+	}
+
+	t.Run("no limits", func(t *testing.T) {
+		var list []int
+		assert.NoError(t, Unmarshal(Value(strings.Repeat("1,", 100)+"1"), &list))
+		assert.Len(t, list, 101)
+	})
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"net"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*ListenAddr)(nil)
+	_ encoding.TextUnmarshaler = (*ListenAddr)(nil)
+)
+
+// ListenAddr is a network and address pair which can be used with net.Listen.
+// Its raw Value has the format "network://address", e.g.
+// "tcp://0.0.0.0:8080" or "unix:///var/run/app.sock". Supported networks are
+// "tcp", "tcp4", "tcp6", "unix" and "unixpacket". A raw Value without network,
+// e.g. ":8080", defaults to "tcp".
+type ListenAddr struct {
+	Network string
+	Address string
+}
+
+// ParseListenAddr parses str as a ListenAddr.
+func ParseListenAddr(str string) (ListenAddr, error) {
+	network, address, ok := strings.Cut(str, "://")
+	if !ok {
+		network, address = "tcp", str
+	}
+
+	la := ListenAddr{Network: strings.ToLower(network), Address: address}
+	switch la.Network {
+	case "tcp", "tcp4", "tcp6":
+		if _, err := ParseHostPort(address); err != nil {
+			return ListenAddr{}, err
+		}
+	case "unix", "unixpacket":
+		if address == "" {
+			return ListenAddr{}, errors.Wrap(errors.Newf("missing socket path in `%s`", str), ErrParseFailure)
+		}
+	default:
+		return ListenAddr{}, errors.Wrap(errors.Newf("unsupported network `%s`", network), ErrParseFailure)
+	}
+	return la, nil
+}
+
+// IsZero indicates if ListenAddr is the zero value.
+func (la ListenAddr) IsZero() bool { return la == ListenAddr{} }
+
+// String returns ListenAddr formatted as "network://address", or an empty
+// string when ListenAddr is the zero value.
+func (la ListenAddr) String() string {
+	if la.IsZero() {
+		return ""
+	}
+	return la.Network + "://" + la.Address
+}
+
+// Listen announces on ListenAddr using net.Listen.
+func (la ListenAddr) Listen() (net.Listener, error) {
+	return net.Listen(la.Network, la.Address)
+}
+
+// MarshalText returns ListenAddr formatted as text.
+func (la ListenAddr) MarshalText() ([]byte, error) { return []byte(la.String()), nil }
+
+// UnmarshalText parses text as a ListenAddr. An empty text results in the
+// zero value.
+func (la *ListenAddr) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*la = ListenAddr{}
+		return nil
+	}
+
+	x, err := ParseListenAddr(string(text))
+	if err != nil {
+		return err
+	}
+	*la = x
+	return nil
+}
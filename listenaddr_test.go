@@ -0,0 +1,57 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseListenAddr(t *testing.T) {
+	tests := map[string]ListenAddr{
+		"tcp://0.0.0.0:8080":       {Network: "tcp", Address: "0.0.0.0:8080"},
+		"TCP6://[::1]:443":         {Network: "tcp6", Address: "[::1]:443"},
+		"unix:///var/run/app.sock": {Network: "unix", Address: "/var/run/app.sock"},
+		"unixpacket://app.sock":    {Network: "unixpacket", Address: "app.sock"},
+		":8080":                    {Network: "tcp", Address: ":8080"},
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseListenAddr(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, want, have)
+		})
+	}
+
+	for _, input := range []string{"", "tcp://localhost", "unix://", "udp://:53", "localhost"} {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseListenAddr(input)
+			assert.ErrorIs(t, haveErr, ErrParseFailure)
+		})
+	}
+}
+
+func TestListenAddr(t *testing.T) {
+	var have ListenAddr
+	assert.NoError(t, Unmarshal(":0", &have))
+	assert.Equal(t, Value("tcp://:0"), MustMarshal(have))
+
+	t.Run("zero", func(t *testing.T) {
+		assert.Equal(t, Value(""), MustMarshal(ListenAddr{}))
+
+		la := have
+		assert.NoError(t, la.UnmarshalText(nil))
+		assert.True(t, la.IsZero())
+		assert.NoError(t, Unmarshal(MustMarshal(ListenAddr{}), &la, WithEmptyMode(EmptyZero)))
+		assert.True(t, la.IsZero())
+	})
+
+	l, err := have.Listen()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "tcp", l.Addr().Network())
+		assert.NoError(t, l.Close())
+	}
+}
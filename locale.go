@@ -0,0 +1,202 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Locale)(nil)
+	_ encoding.TextUnmarshaler = (*Locale)(nil)
+)
+
+// Locale is a BCP 47 language tag, e.g. "en-US" or "zh-Hant-TW". Unmarshaling
+// a Locale validates the structure of the tag and normalizes the casing of its
+// subtags, e.g. "EN-us" becomes "en-US". Underscores are accepted as
+// separators, e.g. "en_US". It does not validate if the subtags are
+// registered.
+type Locale string
+
+// ParseLocale parses str as a Locale.
+func ParseLocale(str string) (Locale, error) {
+	p, err := parseLocale(str)
+	if err != nil {
+		return "", errors.Wrap(err, ErrParseFailure)
+	}
+	return Locale(p.String()), nil
+}
+
+// String returns Locale as a raw string.
+func (l Locale) String() string { return string(l) }
+
+// Language returns the primary language subtag, e.g. "en" for "en-US".
+func (l Locale) Language() string {
+	p, _ := parseLocale(string(l))
+	return p.language
+}
+
+// Script returns the script subtag, e.g. "Hant" for "zh-Hant-TW", or an
+// empty string when there is none.
+func (l Locale) Script() string {
+	p, _ := parseLocale(string(l))
+	return p.script
+}
+
+// Region returns the region subtag, e.g. "US" for "en-US", or an empty string
+// when there is none.
+func (l Locale) Region() string {
+	p, _ := parseLocale(string(l))
+	return p.region
+}
+
+// MarshalText returns Locale as text.
+func (l Locale) MarshalText() ([]byte, error) { return []byte(l), nil }
+
+// UnmarshalText parses text as a Locale. An empty text results in the zero
+// value.
+func (l *Locale) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*l = ""
+		return nil
+	}
+
+	x, err := ParseLocale(string(text))
+	if err != nil {
+		return err
+	}
+	*l = x
+	return nil
+}
+
+type localeParts struct {
+	language string
+	script   string
+	region   string
+	rest     []string
+}
+
+func (p localeParts) String() string {
+	parts := make([]string, 0, 3+len(p.rest))
+	if p.language != "" {
+		parts = append(parts, p.language)
+	}
+	if p.script != "" {
+		parts = append(parts, p.script)
+	}
+	if p.region != "" {
+		parts = append(parts, p.region)
+	}
+	return strings.Join(append(parts, p.rest...), "-")
+}
+
+func parseLocale(str string) (p localeParts, err error) {
+	subtags := strings.Split(strings.ReplaceAll(str, "_", "-"), "-")
+	for i, s := range subtags {
+		if s == "" || len(s) > 8 || !isAlnum(s) {
+			return p, errors.Newf("invalid subtag `%s` in locale `%s`", s, str)
+		}
+		subtags[i] = strings.ToLower(s)
+	}
+
+	i, n := 0, len(subtags)
+	if subtags[0] == "x" {
+		p.rest = subtags
+		return p, validatePrivateUse(str, subtags)
+	}
+
+	// language
+	if l := len(subtags[0]); l < 2 || l == 4 || !isAlpha(subtags[0]) {
+		return p, errors.Newf("invalid language `%s` in locale `%s`", subtags[0], str)
+	}
+	p.language = subtags[0]
+	i++
+	// extlang
+	for j := 0; j < 3 && i < n && len(p.language) <= 3 && len(subtags[i]) == 3 && isAlpha(subtags[i]); j++ {
+		p.language += "-" + subtags[i]
+		i++
+	}
+	// script
+	if i < n && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		p.script = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+		i++
+	}
+	// region
+	if i < n && ((len(subtags[i]) == 2 && isAlpha(subtags[i])) || (len(subtags[i]) == 3 && isDigits(subtags[i]))) {
+		p.region = strings.ToUpper(subtags[i])
+		i++
+	}
+	// variants
+	for ; i < n; i++ {
+		s := subtags[i]
+		if !(len(s) >= 5 || (len(s) == 4 && s[0] >= '0' && s[0] <= '9')) {
+			break
+		}
+		p.rest = append(p.rest, s)
+	}
+	// extensions
+	for i < n && len(subtags[i]) == 1 && subtags[i] != "x" {
+		p.rest = append(p.rest, subtags[i])
+		i++
+
+		start := i
+		for ; i < n && len(subtags[i]) >= 2; i++ {
+			p.rest = append(p.rest, subtags[i])
+		}
+		if i == start {
+			return p, errors.Newf("empty extension in locale `%s`", str)
+		}
+	}
+	// private use
+	if i < n && subtags[i] == "x" {
+		if err = validatePrivateUse(str, subtags[i:]); err != nil {
+			return p, err
+		}
+		p.rest = append(p.rest, subtags[i:]...)
+		i = n
+	}
+	if i < n {
+		return p, errors.Newf("invalid subtag `%s` in locale `%s`", subtags[i], str)
+	}
+	return p, nil
+}
+
+func validatePrivateUse(str string, subtags []string) error {
+	if len(subtags) < 2 {
+		return errors.Newf("empty private use in locale `%s`", str)
+	}
+	return nil
+}
+
+func isAlnum(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i] | 0x20 // lowercase ascii letters
+		if !(c >= 'a' && c <= 'z') && !(s[i] >= '0' && s[i] <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlpha(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
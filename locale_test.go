@@ -0,0 +1,66 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := map[string]Locale{
+		"en":                 "en",
+		"en-US":              "en-US",
+		"EN_us":              "en-US",
+		"zh-hant-tw":         "zh-Hant-TW",
+		"es-419":             "es-419",
+		"sl-rozaj-biske":     "sl-rozaj-biske",
+		"de-CH-1901":         "de-CH-1901",
+		"zh-yue-HK":          "zh-yue-HK",
+		"en-US-u-ca-gregory": "en-US-u-ca-gregory",
+		"en-x-private":       "en-x-private",
+		"x-whatever":         "x-whatever",
+		"und":                "und",
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseLocale(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, want, have)
+		})
+	}
+
+	for _, input := range []string{
+		"", "e", "engl", "en-", "en--US", "en-US-u", "en-x", "x", "en-toolongsubtag", "en-Ü", "123",
+	} {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseLocale(input)
+			assert.ErrorIs(t, haveErr, ErrParseFailure)
+		})
+	}
+}
+
+func TestLocale(t *testing.T) {
+	var have Locale
+	assert.NoError(t, Unmarshal("zh_hant_tw", &have))
+	assert.Equal(t, Locale("zh-Hant-TW"), have)
+	assert.Equal(t, "zh", have.Language())
+	assert.Equal(t, "Hant", have.Script())
+	assert.Equal(t, "TW", have.Region())
+	assert.Equal(t, Value("zh-Hant-TW"), MustMarshal(have))
+
+	assert.Equal(t, "", Locale("en").Region())
+}
+
+func TestLocale_zero(t *testing.T) {
+	assert.Equal(t, Value(""), MustMarshal(Locale("")))
+
+	l := Locale("en-US")
+	assert.NoError(t, l.UnmarshalText(nil))
+	assert.Equal(t, Locale(""), l)
+	assert.NoError(t, Unmarshal(MustMarshal(Locale("")), &l, WithEmptyMode(EmptyZero)))
+	assert.Equal(t, Locale(""), l)
+}
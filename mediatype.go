@@ -0,0 +1,88 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"mime"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*MediaType)(nil)
+	_ encoding.TextUnmarshaler = (*MediaType)(nil)
+)
+
+// MediaType is a MIME media type with its parameters, e.g.
+// "text/html; charset=utf-8". It is parsed using mime.ParseMediaType and
+// formatted canonically using mime.FormatMediaType, which lowercases the type
+// and parameter names and sorts the parameters.
+type MediaType struct {
+	// Type is the lowercase media type, e.g. "text/html".
+	Type string
+	// Params contains the parameters with lowercase names.
+	Params map[string]string
+}
+
+// ParseMediaType parses str as a MediaType.
+func ParseMediaType(str string) (MediaType, error) {
+	typ, params, err := mime.ParseMediaType(str)
+	if err != nil {
+		return MediaType{}, errors.Wrap(err, ErrParseFailure)
+	}
+	if !strings.Contains(typ, "/") {
+		return MediaType{}, errors.Wrap(errors.Newf("missing subtype in `%s`", str), ErrParseFailure)
+	}
+	if len(params) == 0 {
+		params = nil
+	}
+	return MediaType{Type: typ, Params: params}, nil
+}
+
+// Subtype returns the part of Type after the "/", e.g. "html" for "text/html".
+func (mt MediaType) Subtype() string {
+	_, sub, _ := strings.Cut(mt.Type, "/")
+	return sub
+}
+
+// Param returns the value of parameter name and whether it exists.
+func (mt MediaType) Param(name string) (string, bool) {
+	v, ok := mt.Params[strings.ToLower(name)]
+	return v, ok
+}
+
+// String returns the canonical form of MediaType.
+func (mt MediaType) String() string { return mime.FormatMediaType(mt.Type, mt.Params) }
+
+// MarshalText returns the canonical form of MediaType as text.
+func (mt MediaType) MarshalText() ([]byte, error) {
+	if mt.Type == "" {
+		return nil, nil
+	}
+
+	str := mt.String()
+	if str == "" {
+		return nil, errors.Wrap(errors.Newf("invalid media type `%s`", mt.Type), ErrValidationFailure)
+	}
+	return []byte(str), nil
+}
+
+// UnmarshalText parses text as a MediaType. An empty text results in the zero
+// value.
+func (mt *MediaType) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*mt = MediaType{}
+		return nil
+	}
+
+	x, err := ParseMediaType(string(text))
+	if err != nil {
+		return err
+	}
+	*mt = x
+	return nil
+}
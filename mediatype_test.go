@@ -0,0 +1,65 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMediaType(t *testing.T) {
+	tests := map[string]struct {
+		want      MediaType
+		canonical Value
+	}{
+		"application/json": {
+			want:      MediaType{Type: "application/json"},
+			canonical: "application/json",
+		},
+		"Text/HTML; Charset=UTF-8": {
+			want:      MediaType{Type: "text/html", Params: map[string]string{"charset": "UTF-8"}},
+			canonical: "text/html; charset=UTF-8",
+		},
+		`multipart/form-data; boundary="a b"; charset=utf-8`: {
+			want: MediaType{Type: "multipart/form-data", Params: map[string]string{
+				"boundary": "a b",
+				"charset":  "utf-8",
+			}},
+			canonical: `multipart/form-data; boundary="a b"; charset=utf-8`,
+		},
+	}
+	for input, tc := range tests {
+		t.Run(input, func(t *testing.T) {
+			var have MediaType
+			assert.NoError(t, Unmarshal(Value(input), &have))
+			assert.Equal(t, tc.want, have)
+			assert.Equal(t, tc.canonical, MustMarshal(have))
+		})
+	}
+
+	have, _ := ParseMediaType("text/plain; charset=utf-8")
+	assert.Equal(t, "plain", have.Subtype())
+	charset, ok := have.Param("Charset")
+	assert.True(t, ok)
+	assert.Equal(t, "utf-8", charset)
+
+	for _, input := range []string{"", "text", "text/html; charset"} {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseMediaType(input)
+			assert.ErrorIs(t, haveErr, ErrParseFailure)
+		})
+	}
+}
+
+func TestMediaType_zero(t *testing.T) {
+	assert.Equal(t, Value(""), MustMarshal(MediaType{}))
+
+	mt := MediaType{Type: "text/html"}
+	assert.NoError(t, mt.UnmarshalText(nil))
+	assert.Equal(t, MediaType{}, mt)
+	assert.NoError(t, Unmarshal(MustMarshal(MediaType{}), &mt, WithEmptyMode(EmptyZero)))
+	assert.Equal(t, MediaType{}, mt)
+}
@@ -0,0 +1,111 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package nettypes registers conversions for types of package net, which do
+// not implement encoding.TextMarshaler and encoding.TextUnmarshaler, with
+// package rawconv. Import it for its side effects only:
+//
+//	import _ "github.com/go-pogo/rawconv/nettypes"
+//
+// The following types are registered:
+//   - net.IPNet, e.g. "192.168.0.0/16"
+//   - net.TCPAddr, e.g. "127.0.0.1:8080"
+//   - net.UDPAddr, e.g. "[::1]:53"
+//
+// Addresses must contain an ip address; host names are not resolved.
+package nettypes
+
+import (
+	"net"
+	"net/netip"
+	"reflect"
+
+	"github.com/go-pogo/rawconv"
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+func init() {
+	ipNet := reflect.TypeOf(net.IPNet{})
+	rawconv.RegisterUnmarshalFunc(ipNet, unmarshalIPNet)
+	rawconv.RegisterMarshalFunc(ipNet, marshalIPNet)
+
+	tcpAddr := reflect.TypeOf(net.TCPAddr{})
+	rawconv.RegisterUnmarshalFunc(tcpAddr, unmarshalTCPAddr)
+	rawconv.RegisterMarshalFunc(tcpAddr, marshalTCPAddr)
+
+	udpAddr := reflect.TypeOf(net.UDPAddr{})
+	rawconv.RegisterUnmarshalFunc(udpAddr, unmarshalUDPAddr)
+	rawconv.RegisterMarshalFunc(udpAddr, marshalUDPAddr)
+}
+
+func unmarshalIPNet(val rawconv.Value, dest any) error {
+	if val.IsEmpty() {
+		return nil
+	}
+
+	_, x, err := net.ParseCIDR(val.String())
+	if err != nil {
+		return errors.Wrap(err, rawconv.ErrParseFailure)
+	}
+	*dest.(*net.IPNet) = *x
+	return nil
+}
+
+func marshalIPNet(v any) (string, error) {
+	x := v.(net.IPNet)
+	if x.IP == nil {
+		return "", nil
+	}
+	return x.String(), nil
+}
+
+func parseAddrPort(val rawconv.Value) (netip.AddrPort, error) {
+	x, err := netip.ParseAddrPort(val.String())
+	if err != nil {
+		return x, errors.Wrap(err, rawconv.ErrParseFailure)
+	}
+	return x, nil
+}
+
+func unmarshalTCPAddr(val rawconv.Value, dest any) error {
+	if val.IsEmpty() {
+		return nil
+	}
+
+	x, err := parseAddrPort(val)
+	if err != nil {
+		return err
+	}
+	*dest.(*net.TCPAddr) = *net.TCPAddrFromAddrPort(x)
+	return nil
+}
+
+func marshalTCPAddr(v any) (string, error) {
+	x := v.(net.TCPAddr)
+	if x.IP == nil && x.Port == 0 {
+		return "", nil
+	}
+	return x.String(), nil
+}
+
+func unmarshalUDPAddr(val rawconv.Value, dest any) error {
+	if val.IsEmpty() {
+		return nil
+	}
+
+	x, err := parseAddrPort(val)
+	if err != nil {
+		return err
+	}
+	*dest.(*net.UDPAddr) = *net.UDPAddrFromAddrPort(x)
+	return nil
+}
+
+func marshalUDPAddr(v any) (string, error) {
+	x := v.(net.UDPAddr)
+	if x.IP == nil && x.Port == 0 {
+		return "", nil
+	}
+	return x.String(), nil
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package nettypes
+
+import (
+	"net"
+	"testing"
+
+	"github.com/go-pogo/rawconv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPNet(t *testing.T) {
+	var have net.IPNet
+	assert.NoError(t, rawconv.Unmarshal("192.168.1.1/16", &have))
+	assert.Equal(t, "192.168.0.0/16", have.String())
+	assert.Equal(t, rawconv.Value("192.168.0.0/16"), rawconv.MustMarshal(have))
+	assert.Equal(t, rawconv.Value(""), rawconv.MustMarshal(net.IPNet{}))
+
+	assert.ErrorIs(t, rawconv.Unmarshal("192.168.1.1", &have), rawconv.ErrParseFailure)
+}
+
+func TestTCPAddr(t *testing.T) {
+	tests := map[string]string{
+		"127.0.0.1:8080": "127.0.0.1:8080",
+		"[::1]:443":      "[::1]:443",
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			var tcp net.TCPAddr
+			assert.NoError(t, rawconv.Unmarshal(rawconv.Value(input), &tcp))
+			assert.Equal(t, rawconv.Value(want), rawconv.MustMarshal(tcp))
+
+			var udp *net.UDPAddr
+			assert.NoError(t, rawconv.Unmarshal(rawconv.Value(input), &udp))
+			assert.Equal(t, rawconv.Value(want), rawconv.MustMarshal(udp))
+		})
+	}
+
+	t.Run("host name", func(t *testing.T) {
+		var have net.TCPAddr
+		assert.ErrorIs(t, rawconv.Unmarshal("localhost:80", &have), rawconv.ErrParseFailure)
+	})
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import "strings"
+
+var (
+	newlineEscaper   = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`)
+	newlineUnescaper = strings.NewReplacer(`\\`, `\`, `\n`, "\n", `\r`, "\r")
+)
+
+// escapeNewlines escapes all newlines and carriage returns in str as \n and
+// \r, and backslashes as \\, so the result fits on a single line.
+func escapeNewlines(str string) string {
+	if strings.IndexAny(str, "\\\n\r") < 0 {
+		return str
+	}
+	return newlineEscaper.Replace(str)
+}
+
+// unescapeNewlines reverses escapeNewlines. Other escape sequences, e.g. \t,
+// are left untouched.
+func unescapeNewlines(str string) string {
+	if strings.IndexByte(str, '\\') < 0 {
+		return str
+	}
+	return newlineUnescaper.Replace(str)
+}
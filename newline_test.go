@@ -0,0 +1,61 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeNewlines(t *testing.T) {
+	tests := map[string]string{
+		"":               "",
+		"single line":    "single line",
+		"line\nbreak":    `line\nbreak`,
+		"crlf\r\n":       `crlf\r\n`,
+		`C:\new\dir`:     `C:\\new\\dir`,
+		"mixed\\n\nreal": `mixed\\n\nreal`,
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have := escapeNewlines(input)
+			assert.Equal(t, want, have)
+			assert.Equal(t, input, unescapeNewlines(have))
+		})
+	}
+
+	assert.Equal(t, `keep\t`, unescapeNewlines(`keep\t`))
+}
+
+func TestOptions_EscapeNewlines(t *testing.T) {
+	opts := Options{EscapeNewlines: true}
+	m := Marshaler{Options: opts}
+	u := Unmarshaler{Options: opts}
+
+	const input = "-----BEGIN X-----\nabc\n-----END X-----\n"
+
+	have, err := m.Marshal(reflect.ValueOf(input))
+	assert.NoError(t, err)
+	assert.Equal(t, Value(`-----BEGIN X-----\nabc\n-----END X-----\n`), have)
+
+	var str string
+	assert.NoError(t, u.Unmarshal(have, reflect.ValueOf(&str)))
+	assert.Equal(t, input, str)
+
+	t.Run("literal by default", func(t *testing.T) {
+		assert.Equal(t, Value(input), MustMarshal(input))
+
+		var str string
+		assert.NoError(t, Unmarshal(`a\nb`, &str))
+		assert.Equal(t, `a\nb`, str)
+	})
+	t.Run("slice", func(t *testing.T) {
+		var list []string
+		assert.NoError(t, u.Unmarshal(`a\nb,c`, reflect.ValueOf(&list)))
+		assert.Equal(t, []string{"a\nb", "c"}, list)
+	})
+}
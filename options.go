@@ -4,6 +4,12 @@
 
 package rawconv
 
+import (
+	"math"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
 const (
 	DefaultItemsSeparator    = ","
 	DefaultKeyValueSeparator = "="
@@ -12,8 +18,156 @@ const (
 type Options struct {
 	ItemsSeparator    string // ,
 	KeyValueSeparator string // =
+
+	// EscapeSeparators makes a Marshaler escape separators, and the escape
+	// character itself, within the items of an array, slice or map using
+	// EscapeChar. An Unmarshaler with this option does not split on escaped
+	// separators and removes the escape characters from the items.
+	EscapeSeparators bool
+	// EscapeChar is the escape character used by EscapeSeparators. It
+	// defaults to DefaultEscapeChar.
+	EscapeChar rune
+	// QuoteItems makes a Marshaler quote each item, key and value of an
+	// array, slice or map using double quotes, e.g. `"a","b,c"`. An
+	// Unmarshaler with this option requires each of these to be quoted and
+	// does not trim any whitespace. This guarantees an unambiguous round-trip,
+	// regardless of their contents. It takes precedence over EscapeSeparators.
+	QuoteItems bool
+
+	// Tokenizer, when set, splits and joins the items of arrays, slices and
+	// maps instead of the separators. It takes precedence over both
+	// EscapeSeparators and QuoteItems, and no whitespace is trimmed from the
+	// items it returns.
+	Tokenizer Tokenizer
+
+	// EmptyMode determines how an Unmarshaler handles an empty Value.
+	EmptyMode EmptyMode
+
+	// RejectNaN rejects NaN floats and complex numbers with an
+	// ErrNaNNotAllowed error, instead of accepting or emitting "NaN".
+	RejectNaN bool
+	// RejectInf rejects infinite floats and complex numbers with an
+	// ErrInfNotAllowed error, instead of accepting or emitting "+Inf" and
+	// "-Inf".
+	RejectInf bool
+
+	// IntBase is the base, between 2 and 36, which is used to format and
+	// parse integers. When 0, a Marshaler formats integers using base 10 and
+	// an Unmarshaler derives the base from the prefix, e.g. "0x" for base 16.
+	IntBase int
+
+	// ComplexFormat determines how a Marshaler formats complex numbers.
+	ComplexFormat ComplexFormat
+	// DurationFormat determines how a Marshaler and Unmarshaler format and
+	// parse time.Duration values.
+	DurationFormat DurationFormat
+	// NoExponent forbids a Marshaler to format floats and complex numbers
+	// using exponent notation, e.g. "1000000" instead of "1e+06".
+	NoExponent bool
+
+	// EscapeNewlines makes a Marshaler escape newlines and carriage returns
+	// as \n and \r, and backslashes as \\, so each Value fits on a single
+	// line, which is required by line based formats such as env files. An
+	// Unmarshaler with this option reverses the escaping before parsing a
+	// Value. By default, newlines are preserved literally.
+	EscapeNewlines bool
+
+	// Compress makes a Marshaler compress each Value which is at least
+	// CompressThreshold bytes long, using Compress, whenever this results in
+	// a shorter Value, e.g. "gz:<base64>". This keeps large values, such as
+	// JSON or PEM bundles, within the length limits of environment variables
+	// on some platforms. An Unmarshaler with this option decompresses such
+	// values, up to Limits.MaxLength bytes, before parsing them.
+	Compress bool
+	// CompressThreshold is the minimum length of a Value which is compressed.
+	// It defaults to DefaultCompressThreshold.
+	CompressThreshold int
+
+	// ShellQuote makes a Marshaler quote its output using single quotes
+	// whenever it contains characters which are special to a POSIX shell,
+	// e.g. "'hello world'". The output can be safely used in generated env
+	// files or scripts. It is ignored by an Unmarshaler.
+	ShellQuote bool
+
+	// DockerCompat makes an Unmarshaler accept durations and sizes in the
+	// syntax of Docker and compose files. Durations are case-insensitive and
+	// may contain spaces, e.g. "1H 30M". Sizes of types Bytes, KiB, MiB and
+	// GiB accept the binary units b, k, m, g, t and p, optionally followed by
+	// "b", in any case and with spaces, e.g. "10gb", "512M" or "1.5 GB".
+	DockerCompat bool
+
+	// KeyProvider, when set, makes an Unmarshaler decrypt a Value which is an
+	// encrypted envelope, see Encrypt, before it is parsed. Without it, an
+	// envelope is parsed as is. It is ignored by a Marshaler, use Encrypt to
+	// create an envelope instead.
+	KeyProvider KeyProvider
+
+	// Verifier verifies the signatures of fields with the "signed" tag option
+	// before UnmarshalStruct unmarshals them. A signature is looked up using
+	// the key of the field followed by SignatureSuffix.
+	Verifier Verifier
+	// SignatureSuffix is the suffix of the key of a signature. It defaults to
+	// DefaultSignatureSuffix.
+	SignatureSuffix string
+
+	// Stages determine the order in which a Marshaler or Unmarshaler tries to
+	// resolve how a type is handled. Stages which are not listed are
+	// disabled. A nil value results in DefaultStages.
+	Stages []Stage
+
+	// Logger, when set, receives debug messages about how each type is
+	// handled, e.g. which registered func is used or if the type's kind is
+	// used as fallback. Values are never logged as they may contain secrets.
+	Logger Logger
+
+	// FoldKeys makes UnmarshalStruct match keys case-insensitively, and
+	// without differences between '-' and '_'.
+	FoldKeys bool
+
+	// UnknownKeys determines how UnmarshalStruct handles keys without a
+	// matching struct field.
+	UnknownKeys UnknownKeyPolicy
+
+	// Warner, when set, receives Warning(s) about non-fatal issues which are
+	// found while unmarshaling.
+	Warner Warner
+
+	// Hooks receive instrumentation events about conversions.
+	Hooks Hooks
+
+	// RecoverPanics recovers panics raised by registered MarshalFunc and
+	// UnmarshalFunc funcs and returns them as a *PanicError instead.
+	RecoverPanics bool
+
+	// Limits guard an Unmarshaler against adversarial input.
+	Limits Limits
+
+	// PathMode determines how an Unmarshaler expands and validates a Path.
+	PathMode PathMode
+
+	// Marshaler is used by an Unmarshaler which needs to marshal values, e.g.
+	// to compare the old and new values of fields in Reload, or by
+	// Canonicalize. When nil, a Marshaler with the same Options, that only
+	// knows the globally registered MarshalFunc funcs, is used. It is ignored
+	// by a Marshaler.
+	Marshaler *Marshaler
 }
 
+// EmptyMode determines how an Unmarshaler handles an empty Value.
+type EmptyMode uint8
+
+const (
+	// EmptyDefault leaves the handling of an empty Value to the registered
+	// UnmarshalFunc of the target's type. Builtin kinds are left untouched.
+	EmptyDefault EmptyMode = iota
+	// EmptySkip always leaves the target untouched.
+	EmptySkip
+	// EmptyZero sets the target to its zero value.
+	EmptyZero
+	// EmptyError returns an ErrEmptyValue error.
+	EmptyError
+)
+
 func (o Options) itemSeparator() string {
 	if o.ItemsSeparator == "" {
 		return DefaultItemsSeparator
@@ -27,3 +181,213 @@ func (o Options) keyValueSeparator() string {
 	}
 	return o.KeyValueSeparator
 }
+
+func (o Options) intBase(marshal bool) int {
+	if o.IntBase == 0 && marshal {
+		return 10
+	}
+	return o.IntBase
+}
+
+func (o Options) floatFormat() byte {
+	if o.NoExponent {
+		return 'f'
+	}
+	return 'g'
+}
+
+func (o Options) checkFloat(f float64) error {
+	if o.RejectNaN && math.IsNaN(f) {
+		return errors.New(ErrNaNNotAllowed)
+	}
+	if o.RejectInf && math.IsInf(f, 0) {
+		return errors.New(ErrInfNotAllowed)
+	}
+	return nil
+}
+
+func (o Options) checkComplex(c complex128) error {
+	if err := o.checkFloat(real(c)); err != nil {
+		return err
+	}
+	return o.checkFloat(imag(c))
+}
+
+// Option is a functional option which modifies Options. Use it with
+// NewMarshaler or NewUnmarshaler to construct an instance in a single step.
+type Option func(o *Options)
+
+// NewMarshaler returns a new Marshaler with the provided Option(s) applied to
+// its Options.
+func NewMarshaler(opts ...Option) *Marshaler {
+	var m Marshaler
+	m.Options.apply(opts)
+	return &m
+}
+
+// NewUnmarshaler returns a new Unmarshaler with the provided Option(s) applied
+// to its Options.
+func NewUnmarshaler(opts ...Option) *Unmarshaler {
+	var u Unmarshaler
+	u.Options.apply(opts)
+	return &u
+}
+
+func (o *Options) apply(opts []Option) {
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+}
+
+// WithOptions replaces all Options with opts. Option(s) which are provided
+// after WithOptions modify the result.
+func WithOptions(opts Options) Option {
+	return func(o *Options) { *o = opts }
+}
+
+// WithSeparators sets Options.ItemsSeparator and Options.KeyValueSeparator.
+// An empty string results in the default separator.
+func WithSeparators(items, keyValue string) Option {
+	return func(o *Options) {
+		o.ItemsSeparator = items
+		o.KeyValueSeparator = keyValue
+	}
+}
+
+// WithEscapeSeparators enables Options.EscapeSeparators, using esc as
+// Options.EscapeChar.
+func WithEscapeSeparators(esc rune) Option {
+	return func(o *Options) {
+		o.EscapeSeparators = true
+		o.EscapeChar = esc
+	}
+}
+
+// WithQuoteItems enables Options.QuoteItems.
+func WithQuoteItems() Option {
+	return func(o *Options) { o.QuoteItems = true }
+}
+
+// WithTokenizer sets Options.Tokenizer.
+func WithTokenizer(t Tokenizer) Option {
+	return func(o *Options) { o.Tokenizer = t }
+}
+
+// WithEmptyMode sets Options.EmptyMode.
+func WithEmptyMode(mode EmptyMode) Option {
+	return func(o *Options) { o.EmptyMode = mode }
+}
+
+// WithStrictFloats enables both Options.RejectNaN and Options.RejectInf.
+func WithStrictFloats() Option {
+	return func(o *Options) {
+		o.RejectNaN = true
+		o.RejectInf = true
+	}
+}
+
+// WithIntBase sets Options.IntBase.
+func WithIntBase(base int) Option {
+	return func(o *Options) { o.IntBase = base }
+}
+
+// WithComplexFormat sets Options.ComplexFormat.
+func WithComplexFormat(format ComplexFormat) Option {
+	return func(o *Options) { o.ComplexFormat = format }
+}
+
+// WithDurationFormat sets Options.DurationFormat.
+func WithDurationFormat(format DurationFormat) Option {
+	return func(o *Options) { o.DurationFormat = format }
+}
+
+// WithNoExponent enables Options.NoExponent.
+func WithNoExponent() Option {
+	return func(o *Options) { o.NoExponent = true }
+}
+
+// WithEscapeNewlines enables Options.EscapeNewlines.
+func WithEscapeNewlines() Option {
+	return func(o *Options) { o.EscapeNewlines = true }
+}
+
+// WithCompress enables Options.Compress, using threshold as
+// Options.CompressThreshold.
+func WithCompress(threshold int) Option {
+	return func(o *Options) {
+		o.Compress = true
+		o.CompressThreshold = threshold
+	}
+}
+
+// WithShellQuote enables Options.ShellQuote.
+func WithShellQuote() Option {
+	return func(o *Options) { o.ShellQuote = true }
+}
+
+// WithDockerCompat enables Options.DockerCompat.
+func WithDockerCompat() Option {
+	return func(o *Options) { o.DockerCompat = true }
+}
+
+// WithKeyProvider sets Options.KeyProvider.
+func WithKeyProvider(kp KeyProvider) Option {
+	return func(o *Options) { o.KeyProvider = kp }
+}
+
+// WithVerifier sets Options.Verifier.
+func WithVerifier(v Verifier) Option {
+	return func(o *Options) { o.Verifier = v }
+}
+
+// WithStages sets Options.Stages.
+func WithStages(stages ...Stage) Option {
+	return func(o *Options) { o.Stages = stages }
+}
+
+// WithLogger sets Options.Logger.
+func WithLogger(l Logger) Option {
+	return func(o *Options) { o.Logger = l }
+}
+
+// WithFoldKeys enables Options.FoldKeys.
+func WithFoldKeys() Option {
+	return func(o *Options) { o.FoldKeys = true }
+}
+
+// WithUnknownKeys sets Options.UnknownKeys.
+func WithUnknownKeys(policy UnknownKeyPolicy) Option {
+	return func(o *Options) { o.UnknownKeys = policy }
+}
+
+// WithWarner sets Options.Warner.
+func WithWarner(w Warner) Option {
+	return func(o *Options) { o.Warner = w }
+}
+
+// WithHooks sets Options.Hooks.
+func WithHooks(h Hooks) Option {
+	return func(o *Options) { o.Hooks = h }
+}
+
+// WithRecoverPanics enables Options.RecoverPanics.
+func WithRecoverPanics() Option {
+	return func(o *Options) { o.RecoverPanics = true }
+}
+
+// WithLimits sets Options.Limits.
+func WithLimits(l Limits) Option {
+	return func(o *Options) { o.Limits = l }
+}
+
+// WithPathMode sets Options.PathMode.
+func WithPathMode(mode PathMode) Option {
+	return func(o *Options) { o.PathMode = mode }
+}
+
+// WithMarshaler sets Options.Marshaler.
+func WithMarshaler(m *Marshaler) Option {
+	return func(o *Options) { o.Marshaler = m }
+}
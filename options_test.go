@@ -0,0 +1,76 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMarshaler(t *testing.T) {
+	m := NewMarshaler(
+		WithSeparators(";", ":"),
+		WithNoExponent(),
+		WithShellQuote(),
+		nil,
+	)
+	assert.Equal(t, Options{
+		ItemsSeparator:    ";",
+		KeyValueSeparator: ":",
+		NoExponent:        true,
+		ShellQuote:        true,
+	}, m.Options)
+
+	have, err := m.Marshal(reflect.ValueOf(map[string]float64{"a b": 1e6}))
+	assert.NoError(t, err)
+	assert.Equal(t, Value("'a b:1000000'"), have)
+}
+
+func TestNewUnmarshaler(t *testing.T) {
+	u := NewUnmarshaler(
+		WithOptions(Options{ItemsSeparator: ";", RecoverPanics: true}),
+		WithEmptyMode(EmptyError),
+		WithStrictFloats(),
+		WithLimits(Limits{MaxItems: 2}),
+	)
+	assert.Equal(t, Options{
+		ItemsSeparator: ";",
+		EmptyMode:      EmptyError,
+		RejectNaN:      true,
+		RejectInf:      true,
+		RecoverPanics:  true,
+		Limits:         Limits{MaxItems: 2},
+	}, u.Options)
+
+	var have []int
+	assert.NoError(t, u.Unmarshal("1;2", reflect.ValueOf(&have)))
+	assert.Equal(t, []int{1, 2}, have)
+	assert.ErrorIs(t, u.Unmarshal("1;2;3", reflect.ValueOf(&have)), ErrTooManyItems)
+}
+
+func TestPerCallOptions(t *testing.T) {
+	t.Run("marshal", func(t *testing.T) {
+		have, err := Marshal([]int{1, 2}, WithSeparators(";", ""))
+		assert.NoError(t, err)
+		assert.Equal(t, Value("1;2"), have)
+
+		// the global Marshaler is left untouched
+		have, err = Marshal([]int{1, 2})
+		assert.NoError(t, err)
+		assert.Equal(t, Value("1,2"), have)
+	})
+	t.Run("unmarshal", func(t *testing.T) {
+		u := NewUnmarshaler(WithSeparators(";", ""))
+
+		var have []int
+		assert.NoError(t, u.Unmarshal("1|2", reflect.ValueOf(&have), WithSeparators("|", "")))
+		assert.Equal(t, []int{1, 2}, have)
+		assert.Equal(t, ";", u.ItemsSeparator)
+
+		assert.ErrorIs(t, Unmarshal("", &have, WithEmptyMode(EmptyError)), ErrEmptyValue)
+	})
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"sort"
+	"strconv"
+)
+
+// Origin describes where a Value originates from.
+type Origin struct {
+	// Source is the name of the source, e.g. a file name or "env".
+	Source string
+	// Line is the line number within Source, or 0 when unknown.
+	Line int
+	// Default indicates the Value is a default value.
+	Default bool
+}
+
+// String returns a human-readable representation of Origin, e.g.
+// "config.env:12".
+func (o Origin) String() string {
+	str := o.Source
+	if o.Line > 0 {
+		str += ":" + strconv.Itoa(o.Line)
+	}
+	if o.Default {
+		if str == "" {
+			return "default"
+		}
+		str += " (default)"
+	}
+	return str
+}
+
+// OriginSource is a Source which is able to provide the Origin of its values.
+type OriginSource interface {
+	Source
+	// LookupOrigin is like Lookup but also returns the Origin of the Value.
+	LookupOrigin(key string) (Value, Origin, bool)
+}
+
+// AnnotatedValue is a Value with its Origin.
+type AnnotatedValue struct {
+	Value  Value
+	Origin Origin
+}
+
+var _ OriginSource = (AnnotatedValues)(nil)
+
+// AnnotatedValues is a map of AnnotatedValue(s) indexed by their key.
+type AnnotatedValues map[string]AnnotatedValue
+
+// Lookup returns the Value of key and a boolean indicating if the key exists.
+func (av AnnotatedValues) Lookup(key string) (Value, bool) {
+	v, ok := av[key]
+	return v.Value, ok
+}
+
+// LookupOrigin returns the Value and Origin of key, and a boolean indicating if
+// the key exists.
+func (av AnnotatedValues) LookupOrigin(key string) (Value, Origin, bool) {
+	v, ok := av[key]
+	return v.Value, v.Origin, ok
+}
+
+// Keys returns all keys of AnnotatedValues in sorted order.
+func (av AnnotatedValues) Keys() []string {
+	keys := make([]string, 0, len(av))
+	for k := range av {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Values returns the Value(s) of AnnotatedValues without their Origin.
+func (av AnnotatedValues) Values() Values {
+	res := make(Values, len(av))
+	for k, v := range av {
+		res[k] = v.Value
+	}
+	return res
+}
+
+// Annotate returns AnnotatedValues which contain all Values, each with the
+// provided Origin.
+func (vs Values) Annotate(origin Origin) AnnotatedValues {
+	res := make(AnnotatedValues, len(vs))
+	for k, v := range vs {
+		res[k] = AnnotatedValue{Value: v, Origin: origin}
+	}
+	return res
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrigin_String(t *testing.T) {
+	tests := map[string]Origin{
+		"":                    {},
+		"env":                 {Source: "env"},
+		"config.env:12":       {Source: "config.env", Line: 12},
+		"default":             {Default: true},
+		"defaults (default)":  {Source: "defaults", Default: true},
+		"app.env:3 (default)": {Source: "app.env", Line: 3, Default: true},
+	}
+	for want, origin := range tests {
+		t.Run(want, func(t *testing.T) {
+			assert.Equal(t, want, origin.String())
+		})
+	}
+}
+
+func TestAnnotatedValues(t *testing.T) {
+	origin := Origin{Source: "config.env", Line: 2}
+	av := Values{"foo": "bar"}.Annotate(origin)
+
+	have, haveOrigin, haveOk := av.LookupOrigin("foo")
+	assert.Equal(t, Value("bar"), have)
+	assert.Equal(t, origin, haveOrigin)
+	assert.True(t, haveOk)
+
+	assert.Equal(t, []string{"foo"}, av.Keys())
+	assert.Equal(t, Values{"foo": "bar"}, av.Values())
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Pairs)(nil)
+	_ encoding.TextUnmarshaler = (*Pairs)(nil)
+)
+
+// Pair is a key-value pair of Pairs.
+type Pair struct {
+	Key   string
+	Value string
+}
+
+// Pairs is a list of key-value pairs. Unlike a map, it preserves the order and
+// duplicates of its keys, e.g. for headers or arguments. Its raw Value has the
+// format "k1=v1,k2=v2". Separators and backslashes within keys and values are
+// escaped with DefaultEscapeChar, e.g. "k1=x\,y".
+type Pairs []Pair
+
+// ParsePairs parses str as Pairs. Each pair must contain a
+// DefaultKeyValueSeparator, an empty str results in empty Pairs.
+func ParsePairs(str string) (Pairs, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	parts := splitEscaped(str, DefaultItemsSeparator, DefaultEscapeChar)
+	res := make(Pairs, 0, len(parts))
+	for _, part := range parts {
+		k, v, ok := cutEscaped(part, DefaultKeyValueSeparator, DefaultEscapeChar)
+		if !ok {
+			return nil, errors.Wrap(errors.New(ErrMapInvalidFormat), ErrParseFailure)
+		}
+		res = append(res, Pair{
+			Key:   unescape(strings.TrimSpace(k), DefaultEscapeChar),
+			Value: unescape(v, DefaultEscapeChar),
+		})
+	}
+	return res, nil
+}
+
+// Get returns the value of the first pair with key, and a boolean indicating
+// if such a pair exists.
+func (ps Pairs) Get(key string) (string, bool) {
+	for _, p := range ps {
+		if p.Key == key {
+			return p.Value, true
+		}
+	}
+	return "", false
+}
+
+// GetAll returns the values of all pairs with key, in order.
+func (ps Pairs) GetAll(key string) []string {
+	var res []string
+	for _, p := range ps {
+		if p.Key == key {
+			res = append(res, p.Value)
+		}
+	}
+	return res
+}
+
+// Values returns Pairs as Values. When a key has duplicates, the value of the
+// last pair is used.
+func (ps Pairs) Values() Values {
+	res := make(Values, len(ps))
+	for _, p := range ps {
+		res[p.Key] = Value(p.Value)
+	}
+	return res
+}
+
+// String returns Pairs formatted as "k1=v1,k2=v2", with the separators within
+// keys and values escaped.
+func (ps Pairs) String() string {
+	var sb strings.Builder
+	for i, p := range ps {
+		if i > 0 {
+			sb.WriteString(DefaultItemsSeparator)
+		}
+		sb.WriteString(escapeSeparators(p.Key, DefaultEscapeChar,
+			DefaultKeyValueSeparator, DefaultItemsSeparator))
+		sb.WriteString(DefaultKeyValueSeparator)
+		sb.WriteString(escapeSeparators(p.Value, DefaultEscapeChar,
+			DefaultKeyValueSeparator, DefaultItemsSeparator))
+	}
+	return sb.String()
+}
+
+// MarshalText returns the result of String.
+func (ps Pairs) MarshalText() ([]byte, error) { return []byte(ps.String()), nil }
+
+// UnmarshalText parses text using ParsePairs.
+func (ps *Pairs) UnmarshalText(text []byte) error {
+	x, err := ParsePairs(string(text))
+	if err != nil {
+		return err
+	}
+	*ps = x
+	return nil
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePairs(t *testing.T) {
+	tests := map[string]Pairs{
+		"":               nil,
+		"k1=v1,k2=v2":    {{"k1", "v1"}, {"k2", "v2"}},
+		"b=2,a=1,b=3":    {{"b", "2"}, {"a", "1"}, {"b", "3"}},
+		`empty=,eq=a\=b`: {{"empty", ""}, {"eq", "a=b"}},
+		`a=x\,y,b\\=z`:   {{"a", "x,y"}, {`b\`, "z"}},
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParsePairs(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, want, have)
+			assert.Equal(t, input, have.String())
+
+			var dest Pairs
+			assert.NoError(t, Unmarshal(MustMarshal(have), &dest))
+			assert.Equal(t, have, dest)
+		})
+	}
+
+	have, haveErr := ParsePairs("eq=a=b=c")
+	assert.NoError(t, haveErr)
+	assert.Equal(t, Pairs{{"eq", "a=b=c"}}, have)
+
+	_, haveErr = ParsePairs("k1=v1,k2")
+	assert.ErrorIs(t, haveErr, ErrMapInvalidFormat)
+	assert.ErrorIs(t, haveErr, ErrParseFailure)
+}
+
+func TestPairs(t *testing.T) {
+	ps := Pairs{{"b", "2"}, {"a", "1"}, {"b", "3"}}
+
+	v, ok := ps.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, "2", v)
+	_, ok = ps.Get("c")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"2", "3"}, ps.GetAll("b"))
+	assert.Nil(t, ps.GetAll("c"))
+	assert.Equal(t, Values{"a": "1", "b": "3"}, ps.Values())
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type panicky struct{ x int }
+
+func TestOptions_RecoverPanics(t *testing.T) {
+	typ := reflect.TypeOf(panicky{})
+	opts := Options{RecoverPanics: true}
+
+	var u Unmarshaler
+	u.Options = opts
+	u.Register(typ, func(val Value, dest any) error {
+		panic("boom")
+	})
+
+	var m Marshaler
+	m.Options = opts
+	m.Register(typ, func(v any) (string, error) {
+		panic(errors.New(ErrParseFailure))
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		var have panicky
+		haveErr := u.Unmarshal("foo", reflect.ValueOf(&have))
+
+		var panicErr *PanicError
+		if assert.ErrorAs(t, haveErr, &panicErr) {
+			assert.Equal(t, typ, panicErr.Type)
+			assert.Equal(t, Value("foo"), panicErr.Value)
+			assert.Equal(t, "boom", panicErr.Recovered)
+		}
+	})
+	t.Run("unmarshal slice", func(t *testing.T) {
+		var have []panicky
+		var panicErr *PanicError
+		assert.ErrorAs(t, u.Unmarshal("a,b", reflect.ValueOf(&have)), &panicErr)
+	})
+	t.Run("marshal", func(t *testing.T) {
+		_, haveErr := m.Marshal(reflect.ValueOf(panicky{x: 1}))
+
+		var panicErr *PanicError
+		if assert.ErrorAs(t, haveErr, &panicErr) {
+			assert.Equal(t, typ, panicErr.Type)
+			assert.Equal(t, panicky{x: 1}, panicErr.Value)
+		}
+		assert.ErrorIs(t, haveErr, ErrParseFailure)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		var u2 Unmarshaler
+		u2.Register(typ, func(val Value, dest any) error {
+			panic("boom")
+		})
+
+		var have panicky
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = u2.Unmarshal("foo", reflect.ValueOf(&have))
+		})
+	})
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const (
+	ErrPathNotExist    errors.Msg = "path does not exist"
+	ErrPathNotFile     errors.Msg = "path is not a regular file"
+	ErrPathNotDir      errors.Msg = "path is not a directory"
+	ErrPathNotReadable errors.Msg = "path is not readable"
+	ErrPathNotWritable errors.Msg = "path is not writable"
+)
+
+var pathType = reflect.TypeOf(Path(""))
+
+// PathMode contains flags which determine how a Path is expanded and
+// validated. Flags can be combined, e.g. PathExpandHome|PathAbs|PathMustExist.
+type PathMode uint16
+
+const (
+	// PathExpandHome replaces a leading "~" with the current user's home
+	// directory.
+	PathExpandHome PathMode = 1 << iota
+	// PathExpandEnv replaces ${var} or $var using os.ExpandEnv.
+	PathExpandEnv
+	// PathAbs makes the path absolute using filepath.Abs.
+	PathAbs
+	// PathMustExist requires the path to exist.
+	PathMustExist
+	// PathMustBeFile requires the path to be an existing regular file.
+	PathMustBeFile
+	// PathMustBeDir requires the path to be an existing directory.
+	PathMustBeDir
+	// PathReadable requires the path to exist and be readable.
+	PathReadable
+	// PathWritable requires the path to exist and be writable.
+	PathWritable
+)
+
+// Path is a filesystem path. An Unmarshaler expands and validates a Path
+// according to its Options.PathMode. Paths are always cleaned using
+// filepath.Clean.
+type Path string
+
+// ParsePath expands and validates str as a Path according to mode.
+func ParsePath(str string, mode PathMode) (Path, error) {
+	return Path(str).Resolve(mode)
+}
+
+// Resolve expands and validates Path according to mode and returns the
+// resulting Path.
+func (p Path) Resolve(mode PathMode) (Path, error) {
+	str := string(p)
+	if str == "" {
+		return p, nil
+	}
+
+	if mode&PathExpandEnv != 0 {
+		str = os.ExpandEnv(str)
+	}
+	if mode&PathExpandHome != 0 && (str == "~" || strings.HasPrefix(str, "~/") ||
+		strings.HasPrefix(str, "~"+string(filepath.Separator))) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return p, errors.Wrap(err, ErrValidationFailure)
+		}
+		str = home + str[1:]
+	}
+
+	str = filepath.Clean(str)
+	if mode&PathAbs != 0 {
+		abs, err := filepath.Abs(str)
+		if err != nil {
+			return p, errors.Wrap(err, ErrValidationFailure)
+		}
+		str = abs
+	}
+
+	res := Path(str)
+	if mode&(PathMustExist|PathMustBeFile|PathMustBeDir|PathReadable|PathWritable) == 0 {
+		return res, nil
+	}
+	if err := res.validate(mode); err != nil {
+		return p, errors.Wrap(err, ErrValidationFailure)
+	}
+	return res, nil
+}
+
+func (p Path) validate(mode PathMode) error {
+	info, err := os.Stat(string(p))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.Newf("%w: `%s`", ErrPathNotExist, p)
+		}
+		return err
+	}
+
+	if mode&PathMustBeFile != 0 && !info.Mode().IsRegular() {
+		return errors.Newf("%w: `%s`", ErrPathNotFile, p)
+	}
+	if mode&PathMustBeDir != 0 && !info.IsDir() {
+		return errors.Newf("%w: `%s`", ErrPathNotDir, p)
+	}
+	if mode&PathReadable != 0 {
+		f, err := os.Open(string(p))
+		if err != nil {
+			return errors.Newf("%w: `%s`", ErrPathNotReadable, p)
+		}
+		_ = f.Close()
+	}
+	if mode&PathWritable != 0 && !writable(string(p), info) {
+		return errors.Newf("%w: `%s`", ErrPathNotWritable, p)
+	}
+	return nil
+}
+
+func writable(path string, info os.FileInfo) bool {
+	if info.IsDir() {
+		f, err := os.CreateTemp(path, ".rawconv-*")
+		if err != nil {
+			return false
+		}
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return true
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return false
+	}
+	_ = f.Close()
+	return true
+}
+
+// Abs returns an absolute representation of Path using filepath.Abs.
+func (p Path) Abs() (Path, error) {
+	abs, err := filepath.Abs(string(p))
+	return Path(abs), err
+}
+
+// String returns the Path as string.
+func (p Path) String() string { return string(p) }
+
+// MarshalText returns the Path as text.
+func (p Path) MarshalText() ([]byte, error) { return []byte(p), nil }
+
+// UnmarshalText sets the Path to the cleaned text. It does not expand or
+// validate the Path, an Unmarshaler does so according to its
+// Options.PathMode before calling UnmarshalText.
+func (p *Path) UnmarshalText(text []byte) error {
+	res, err := Path(text).Resolve(0)
+	if err != nil {
+		return err
+	}
+	*p = res
+	return nil
+}
+
+// pathValue expands and validates v according to mode when typ is a Path.
+func (mode PathMode) pathValue(v Value, typ reflect.Type) (Value, error) {
+	if v.IsEmpty() || indirectType(typ) != pathType {
+		return v, nil
+	}
+	p, err := Path(v).Resolve(mode)
+	if err != nil {
+		return "", err
+	}
+	return Value(p), nil
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPath_Resolve(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	wd, _ := os.Getwd()
+	t.Setenv("RAWCONV_TEST_DIR", "/opt/app")
+
+	tests := map[string]struct {
+		input string
+		mode  PathMode
+		want  Path
+	}{
+		"clean": {
+			input: "/var//log/../run/",
+			want:  Path(filepath.FromSlash("/var/run")),
+		},
+		"home untouched": {
+			input: "~/config",
+			want:  "~/config",
+		},
+		"home": {
+			input: "~/config",
+			mode:  PathExpandHome,
+			want:  Path(filepath.Join(home, "config")),
+		},
+		"home only": {
+			input: "~",
+			mode:  PathExpandHome,
+			want:  Path(home),
+		},
+		"env": {
+			input: "${RAWCONV_TEST_DIR}/data",
+			mode:  PathExpandEnv,
+			want:  Path(filepath.FromSlash("/opt/app/data")),
+		},
+		"abs": {
+			input: "data/file.txt",
+			mode:  PathAbs,
+			want:  Path(filepath.Join(wd, "data", "file.txt")),
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, haveErr := ParsePath(tc.input, tc.mode)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func TestPath_validate(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	assert.NoError(t, os.WriteFile(file, []byte("x"), 0o600))
+
+	tests := map[string]struct {
+		input   string
+		mode    PathMode
+		wantErr error
+	}{
+		"exists":       {input: file, mode: PathMustExist},
+		"file":         {input: file, mode: PathMustBeFile | PathReadable | PathWritable},
+		"dir":          {input: dir, mode: PathMustBeDir | PathReadable | PathWritable},
+		"not exist":    {input: filepath.Join(dir, "nope"), mode: PathMustExist, wantErr: ErrPathNotExist},
+		"dir not file": {input: dir, mode: PathMustBeFile, wantErr: ErrPathNotFile},
+		"file not dir": {input: file, mode: PathMustBeDir, wantErr: ErrPathNotDir},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, haveErr := ParsePath(tc.input, tc.mode)
+			if tc.wantErr == nil {
+				assert.NoError(t, haveErr)
+				assert.Equal(t, Path(tc.input), have)
+			} else {
+				assert.ErrorIs(t, haveErr, tc.wantErr)
+				assert.ErrorIs(t, haveErr, ErrValidationFailure)
+			}
+		})
+	}
+}
+
+func TestUnmarshaler_Path(t *testing.T) {
+	dir := t.TempDir()
+	u := Unmarshaler{Options: Options{PathMode: PathMustBeDir}}
+
+	var have Path
+	assert.NoError(t, u.Unmarshal(Value(dir+"/"), reflect.ValueOf(&have)))
+	assert.Equal(t, Path(dir), have)
+	assert.ErrorIs(t, u.Unmarshal(Value(filepath.Join(dir, "nope")), reflect.ValueOf(&have)), ErrPathNotExist)
+	assert.Equal(t, Path(dir), have)
+
+	var list []Path
+	assert.NoError(t, u.Unmarshal(Value(dir+","+dir), reflect.ValueOf(&list)))
+	assert.Equal(t, []Path{Path(dir), Path(dir)}, list)
+}
+
+func TestPath_UnmarshalText(t *testing.T) {
+	var have Path
+	assert.NoError(t, have.UnmarshalText([]byte("foo//bar/../baz/")))
+	assert.Equal(t, Path(filepath.Clean("foo/baz")), have)
+
+	text, err := have.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(have), text)
+}
+
+func TestUnmarshaler_Path_pointer(t *testing.T) {
+	dir := t.TempDir()
+	u := Unmarshaler{Options: Options{PathMode: PathMustBeDir}}
+
+	var have *Path
+	assert.NoError(t, u.Unmarshal(Value(dir+"/"), reflect.ValueOf(&have)))
+	assert.Equal(t, Path(dir), *have)
+	assert.ErrorIs(t, u.Unmarshal(Value(filepath.Join(dir, "nope")), reflect.ValueOf(&have)), ErrPathNotExist)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Port)(nil)
+	_ encoding.TextUnmarshaler = (*Port)(nil)
+	_ encoding.TextMarshaler   = (*AutoPort)(nil)
+	_ encoding.TextUnmarshaler = (*AutoPort)(nil)
+	_ encoding.TextMarshaler   = (*PortRange)(nil)
+	_ encoding.TextUnmarshaler = (*PortRange)(nil)
+)
+
+// Port is a network port between 1 and 65535, e.g. "8080".
+type Port uint16
+
+// ParsePort parses str as a Port.
+func ParsePort(str string) (Port, error) {
+	p, err := parsePort(str)
+	if err != nil {
+		return 0, err
+	}
+	if p == 0 {
+		return 0, errors.Wrap(errors.Newf("port `%s` out of range", str), ErrValidationFailure)
+	}
+	return p, nil
+}
+
+func parsePort(str string) (Port, error) {
+	x, err := strconv.ParseUint(strings.TrimSpace(str), 10, 16)
+	if err != nil {
+		return 0, errors.Wrap(err, errKind(err))
+	}
+	return Port(x), nil
+}
+
+// String returns the Port formatted as integer, or an empty string when Port is
+// the zero value.
+func (p Port) String() string {
+	if p == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(p), 10)
+}
+
+// MarshalText returns the Port formatted as text.
+func (p Port) MarshalText() ([]byte, error) { return []byte(p.String()), nil }
+
+// UnmarshalText parses text using ParsePort. An empty text results in the zero
+// value.
+func (p *Port) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*p = 0
+		return nil
+	}
+
+	x, err := ParsePort(string(text))
+	if err != nil {
+		return err
+	}
+	*p = x
+	return nil
+}
+
+// AutoPort is a Port which can also be 0, to let the system choose an
+// available port. The raw Value "auto" (in any case) results in 0, which is
+// marshaled as "0".
+type AutoPort Port
+
+// ParseAutoPort parses str as an AutoPort.
+func ParseAutoPort(str string) (AutoPort, error) {
+	if strings.EqualFold(strings.TrimSpace(str), "auto") {
+		return 0, nil
+	}
+	p, err := parsePort(str)
+	return AutoPort(p), err
+}
+
+// IsAuto indicates if the AutoPort is 0.
+func (p AutoPort) IsAuto() bool { return p == 0 }
+
+// String returns the AutoPort formatted as integer.
+func (p AutoPort) String() string { return strconv.FormatUint(uint64(p), 10) }
+
+// MarshalText returns the AutoPort formatted as text.
+func (p AutoPort) MarshalText() ([]byte, error) { return []byte(p.String()), nil }
+
+// UnmarshalText parses text using ParseAutoPort.
+func (p *AutoPort) UnmarshalText(text []byte) error {
+	x, err := ParseAutoPort(string(text))
+	if err != nil {
+		return err
+	}
+	*p = x
+	return nil
+}
+
+// PortRange is an inclusive range of Port(s). Its raw Value is either
+// "start-end", "start..end" or a single Port, e.g. "8000-8999" or "8080".
+type PortRange struct {
+	Start Port
+	End   Port
+}
+
+// ParsePortRange parses str as a PortRange. Start must not exceed End.
+func ParsePortRange(str string) (PortRange, error) {
+	var r PortRange
+	start, end, found := cutRange(str)
+
+	var err error
+	if r.Start, err = ParsePort(start); err != nil {
+		return r, err
+	}
+	if !found {
+		r.End = r.Start
+		return r, nil
+	}
+	if r.End, err = ParsePort(end); err != nil {
+		return r, err
+	}
+	if r.Start > r.End {
+		return r, errors.Wrap(errors.Newf("start exceeds end of port range `%s`", str), ErrValidationFailure)
+	}
+	return r, nil
+}
+
+// IsZero indicates if PortRange is the zero value.
+func (r PortRange) IsZero() bool { return r == PortRange{} }
+
+// Contains indicates if Port p is within the PortRange.
+func (r PortRange) Contains(p Port) bool { return p >= r.Start && p <= r.End }
+
+// Len returns the number of Port(s) within the PortRange.
+func (r PortRange) Len() int {
+	if r.Start > r.End {
+		return 0
+	}
+	return int(r.End-r.Start) + 1
+}
+
+// String returns the PortRange formatted as "start-end", or as a single Port
+// when start equals end. An empty string is returned when PortRange is the zero
+// value.
+func (r PortRange) String() string {
+	if r.IsZero() {
+		return ""
+	}
+	if r.Start == r.End {
+		return r.Start.String()
+	}
+	return r.Start.String() + "-" + r.End.String()
+}
+
+// MarshalText returns the PortRange formatted as text.
+func (r PortRange) MarshalText() ([]byte, error) { return []byte(r.String()), nil }
+
+// UnmarshalText parses text using ParsePortRange. An empty text results in the
+// zero value.
+func (r *PortRange) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*r = PortRange{}
+		return nil
+	}
+
+	x, err := ParsePortRange(string(text))
+	if err != nil {
+		return err
+	}
+	*r = x
+	return nil
+}
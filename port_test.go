@@ -0,0 +1,124 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePort(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    Port
+		wantErr error
+	}{
+		"valid":     {input: "8080", want: 8080},
+		"min":       {input: "1", want: 1},
+		"max":       {input: "65535", want: 65535},
+		"trimmed":   {input: " 443 ", want: 443},
+		"zero":      {input: "0", wantErr: ErrValidationFailure},
+		"too large": {input: "65536", wantErr: ErrValidationFailure},
+		"negative":  {input: "-1", wantErr: ErrParseFailure},
+		"invalid":   {input: "http", wantErr: ErrParseFailure},
+		"empty":     {input: "", wantErr: ErrParseFailure},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := ParsePort(tc.input)
+			assert.Equal(t, tc.want, have)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseAutoPort(t *testing.T) {
+	for input, want := range map[string]AutoPort{"0": 0, "auto": 0, "AUTO": 0, "8080": 8080} {
+		t.Run(input, func(t *testing.T) {
+			have, err := ParseAutoPort(input)
+			assert.NoError(t, err)
+			assert.Equal(t, want, have)
+			assert.Equal(t, want == 0, have.IsAuto())
+		})
+	}
+
+	_, err := ParseAutoPort("65536")
+	assert.ErrorIs(t, err, ErrValidationFailure)
+}
+
+func TestParsePortRange(t *testing.T) {
+	tests := map[string]struct {
+		input   string
+		want    PortRange
+		wantErr error
+	}{
+		"range":    {input: "8000-8999", want: PortRange{Start: 8000, End: 8999}},
+		"dots":     {input: "8000..8999", want: PortRange{Start: 8000, End: 8999}},
+		"spaces":   {input: "8000 - 8999", want: PortRange{Start: 8000, End: 8999}},
+		"single":   {input: "8080", want: PortRange{Start: 8080, End: 8080}},
+		"reversed": {input: "9000-8000", want: PortRange{Start: 9000, End: 8000}, wantErr: ErrValidationFailure},
+		"zero":     {input: "0-100", wantErr: ErrValidationFailure},
+		"too wide": {input: "1-70000", want: PortRange{Start: 1}, wantErr: ErrValidationFailure},
+		"invalid":  {input: "a-b", wantErr: ErrParseFailure},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := ParsePortRange(tc.input)
+			assert.Equal(t, tc.want, have)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPortRange(t *testing.T) {
+	r := PortRange{Start: 8000, End: 8999}
+	assert.True(t, r.Contains(8000))
+	assert.True(t, r.Contains(8999))
+	assert.False(t, r.Contains(9000))
+	assert.Equal(t, 1000, r.Len())
+	assert.Equal(t, "8000-8999", r.String())
+	assert.Equal(t, "80", PortRange{Start: 80, End: 80}.String())
+
+	var have struct {
+		Port  Port      `rawconv:"port"`
+		Auto  AutoPort  `rawconv:"auto"`
+		Range PortRange `rawconv:"range"`
+	}
+	_, err := UnmarshalStruct(Values{"port": "443", "auto": "auto", "range": "1000-2000"}, &have)
+	assert.NoError(t, err)
+	assert.Equal(t, Port(443), have.Port)
+	assert.True(t, have.Auto.IsAuto())
+	assert.Equal(t, PortRange{Start: 1000, End: 2000}, have.Range)
+
+	_, err = UnmarshalStruct(Values{"port": "0"}, &have)
+	assert.ErrorIs(t, err, ErrValidationFailure)
+}
+
+func TestPort_zero(t *testing.T) {
+	assert.Equal(t, Value(""), MustMarshal(Port(0)))
+	assert.Equal(t, Value(""), MustMarshal(PortRange{}))
+	assert.Equal(t, Value("0"), MustMarshal(AutoPort(0)))
+
+	p := Port(8080)
+	assert.NoError(t, p.UnmarshalText(nil))
+	assert.Equal(t, Port(0), p)
+	assert.NoError(t, Unmarshal(MustMarshal(Port(0)), &p, WithEmptyMode(EmptyZero)))
+	assert.Equal(t, Port(0), p)
+
+	r := PortRange{Start: 80, End: 90}
+	assert.NoError(t, r.UnmarshalText(nil))
+	assert.True(t, r.IsZero())
+	assert.NoError(t, Unmarshal(MustMarshal(PortRange{}), &r, WithEmptyMode(EmptyZero)))
+	assert.True(t, r.IsZero())
+}
@@ -0,0 +1,232 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Quantity)(nil)
+	_ encoding.TextUnmarshaler = (*Quantity)(nil)
+)
+
+// QuantityFormat is the notation of a Quantity, which is preserved when it
+// is formatted.
+type QuantityFormat uint8
+
+const (
+	// DecimalSI uses decimal SI suffixes, e.g. "500m", "1k" or "2G".
+	DecimalSI QuantityFormat = iota
+	// BinarySI uses binary suffixes, e.g. "512Ki" or "2Gi".
+	BinarySI
+	// DecimalExponent uses exponent notation, e.g. "1e3".
+	DecimalExponent
+)
+
+var (
+	decimalSuffixes = map[string]int{
+		"n": -9, "u": -6, "m": -3, "": 0,
+		"k": 3, "M": 6, "G": 9, "T": 12, "P": 15, "E": 18,
+	}
+	binarySuffixes = map[string]uint{
+		"Ki": 10, "Mi": 20, "Gi": 30, "Ti": 40, "Pi": 50, "Ei": 60,
+	}
+)
+
+// Quantity is a number in the resource notation of Kubernetes, e.g. "500m"
+// (0.5) for CPU or "2Gi" (2147483648) for memory. It supports decimal SI
+// suffixes (n, u, m, k, M, G, T, P, E), binary suffixes (Ki, Mi, Gi, Ti, Pi,
+// Ei) and exponent notation (e.g. "1e3"). Its value is stored exactly. The
+// zero value is 0.
+type Quantity struct {
+	value *big.Rat
+	// Format is the notation which is used when the Quantity is formatted.
+	Format QuantityFormat
+}
+
+// ParseQuantity parses str as a Quantity.
+func ParseQuantity(str string) (Quantity, error) {
+	num, suffix := splitQuantity(strings.TrimSpace(str))
+	if num == "" {
+		return Quantity{}, errors.Wrap(errors.Newf("invalid quantity `%s`", str), ErrParseFailure)
+	}
+
+	value, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return Quantity{}, errors.Wrap(errors.Newf("invalid quantity `%s`", str), ErrParseFailure)
+	}
+
+	q := Quantity{value: value}
+	if exp, ok := decimalSuffixes[suffix]; ok {
+		q.value.Mul(q.value, pow10(exp))
+	} else if shift, ok := binarySuffixes[suffix]; ok {
+		q.Format = BinarySI
+		q.value.Mul(q.value, new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), shift)))
+	} else if exp, err := strconv.Atoi(suffix[1:]); (suffix[0] == 'e' || suffix[0] == 'E') && err == nil {
+		if exp < -100 || exp > 100 {
+			return Quantity{}, errors.Wrap(errors.Newf("exponent out of range in quantity `%s`", str), ErrValidationFailure)
+		}
+		q.Format = DecimalExponent
+		q.value.Mul(q.value, pow10(exp))
+	} else {
+		return Quantity{}, errors.Wrap(errors.Newf("%w `%s` in quantity `%s`", ErrUnknownUnit, suffix, str), ErrParseFailure)
+	}
+	return q, nil
+}
+
+// splitQuantity splits str into its number and suffix.
+func splitQuantity(str string) (num, suffix string) {
+	i := 0
+	if i < len(str) && (str[i] == '+' || str[i] == '-') {
+		i++
+	}
+	var digits bool
+	for ; i < len(str) && str[i] >= '0' && str[i] <= '9'; i++ {
+		digits = true
+	}
+	if i < len(str) && str[i] == '.' {
+		i++
+		for ; i < len(str) && str[i] >= '0' && str[i] <= '9'; i++ {
+			digits = true
+		}
+	}
+	if !digits {
+		return "", str
+	}
+	return str[:i], str[i:]
+}
+
+func pow10(exp int) *big.Rat {
+	p := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(absInt(exp))), nil)
+	if exp < 0 {
+		return new(big.Rat).SetFrac(big.NewInt(1), p)
+	}
+	return new(big.Rat).SetInt(p)
+}
+
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Rat returns the exact value of the Quantity.
+func (q Quantity) Rat() *big.Rat {
+	if q.value == nil {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Set(q.value)
+}
+
+// Float64 returns the nearest float64 value of the Quantity.
+func (q Quantity) Float64() float64 {
+	f, _ := q.Rat().Float64()
+	return f
+}
+
+// Value returns the value of the Quantity rounded up to the nearest integer,
+// e.g. 1 for "500m". The result is clamped to the range of int64.
+func (q Quantity) Value() int64 { return q.scaled(1) }
+
+// MilliValue returns the value of the Quantity multiplied by 1000 and rounded
+// up to the nearest integer, e.g. 500 for "500m". The result is clamped to the
+// range of int64.
+func (q Quantity) MilliValue() int64 { return q.scaled(1000) }
+
+func (q Quantity) scaled(scale int64) int64 {
+	r := q.Rat()
+	r.Mul(r, new(big.Rat).SetInt64(scale))
+
+	// round up, towards positive infinity
+	n, m := new(big.Int).DivMod(r.Num(), r.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		n.Add(n, big.NewInt(1))
+	}
+	switch {
+	case n.IsInt64():
+		return n.Int64()
+	case n.Sign() < 0:
+		return math.MinInt64
+	default:
+		return math.MaxInt64
+	}
+}
+
+// Cmp compares q and o and returns -1, 0 or +1 when q is respectively less
+// than, equal to or greater than o.
+func (q Quantity) Cmp(o Quantity) int { return q.Rat().Cmp(o.Rat()) }
+
+// IsZero indicates if the value of the Quantity is 0.
+func (q Quantity) IsZero() bool { return q.value == nil || q.value.Sign() == 0 }
+
+// String returns the Quantity in canonical form, using its Format and the
+// largest suffix which results in a whole number, e.g. "1500Mi", "2Gi" or
+// "1500m". A Quantity in BinarySI format which is not a whole number is
+// formatted as DecimalSI.
+func (q Quantity) String() string {
+	r := q.Rat()
+	if q.Format == BinarySI && r.IsInt() {
+		for _, suffix := range []string{"Ei", "Pi", "Ti", "Gi", "Mi", "Ki"} {
+			d := new(big.Int).Lsh(big.NewInt(1), binarySuffixes[suffix])
+			if m := new(big.Int).Mod(r.Num(), d); m.Sign() == 0 && r.Sign() != 0 {
+				return new(big.Int).Quo(r.Num(), d).String() + suffix
+			}
+		}
+		return r.Num().String()
+	}
+
+	exp := -9
+	for e := 18; e > -9; e -= 3 {
+		if x := new(big.Rat).Quo(r, pow10(e)); x.IsInt() {
+			exp = e
+			break
+		}
+	}
+
+	x := new(big.Rat).Quo(r, pow10(exp))
+	n, m := new(big.Int).DivMod(x.Num(), x.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		// round up, only possible at the smallest suffix
+		n.Add(n, big.NewInt(1))
+	}
+	if n.Sign() == 0 {
+		return "0"
+	}
+	if q.Format == DecimalExponent {
+		if exp == 0 {
+			return n.String()
+		}
+		return n.String() + "e" + strconv.Itoa(exp)
+	}
+	for suffix, e := range decimalSuffixes {
+		if e == exp {
+			return n.String() + suffix
+		}
+	}
+	return n.String()
+}
+
+// MarshalText marshals Quantity into text.
+func (q Quantity) MarshalText() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalText unmarshals text into Quantity.
+func (q *Quantity) UnmarshalText(text []byte) error {
+	x, err := ParseQuantity(string(text))
+	if err != nil {
+		return err
+	}
+	*q = x
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		milli  int64
+		value  int64
+		format QuantityFormat
+		want   string
+	}{
+		"cpu":            {input: "500m", milli: 500, value: 1, want: "500m"},
+		"integer":        {input: "2", milli: 2000, value: 2, want: "2"},
+		"fraction":       {input: "1.5", milli: 1500, value: 2, want: "1500m"},
+		"leading dot":    {input: ".5", milli: 500, value: 1, want: "500m"},
+		"kilo":           {input: "1500k", milli: 1500000000, value: 1500000, want: "1500k"},
+		"canonical kilo": {input: "2000k", milli: 2000000000, value: 2000000, want: "2M"},
+		"gibi":           {input: "2Gi", milli: 2147483648000, value: 2147483648, format: BinarySI, want: "2Gi"},
+		"mebi":           {input: "1500Mi", milli: 1572864000000, value: 1572864000, format: BinarySI, want: "1500Mi"},
+		"canonical mebi": {input: "1024Mi", milli: 1073741824000, value: 1073741824, format: BinarySI, want: "1Gi"},
+		"half gibi":      {input: "0.5Gi", milli: 536870912000, value: 536870912, format: BinarySI, want: "512Mi"},
+		"exponent":       {input: "1e3", milli: 1000000, value: 1000, format: DecimalExponent, want: "1e3"},
+		"negative exp":   {input: "15e-1", milli: 1500, value: 2, format: DecimalExponent, want: "1500e-3"},
+		"nano":           {input: "100n", milli: 1, value: 1, want: "100n"},
+		"negative":       {input: "-500m", milli: -500, value: 0, want: "-500m"},
+		"plus":           {input: "+1k", milli: 1000000, value: 1000, want: "1k"},
+		"zero":           {input: "0", want: "0"},
+		"exa":            {input: "1E", milli: math.MaxInt64, value: 1000000000000000000, want: "1E"},
+		"large binary":   {input: "16Ei", milli: math.MaxInt64, value: math.MaxInt64, format: BinarySI, want: "16Ei"},
+		"trimmed":        {input: " 1Ki ", milli: 1024000, value: 1024, format: BinarySI, want: "1Ki"},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := ParseQuantity(tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.milli, have.MilliValue())
+			assert.Equal(t, tc.value, have.Value())
+			assert.Equal(t, tc.format, have.Format)
+			assert.Equal(t, tc.want, have.String())
+
+			again, err := ParseQuantity(have.String())
+			assert.NoError(t, err)
+			assert.Equal(t, 0, have.Cmp(again))
+		})
+	}
+
+	errs := map[string]string{
+		"empty":          "",
+		"no number":      "Gi",
+		"unknown suffix": "10X",
+		"double dot":     "1.2.3",
+		"lowercase kibi": "1ki",
+	}
+	for name, input := range errs {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseQuantity(input)
+			assert.ErrorIs(t, err, ErrParseFailure)
+		})
+	}
+}
+
+func TestQuantity(t *testing.T) {
+	var zero Quantity
+	assert.True(t, zero.IsZero())
+	assert.Equal(t, "0", zero.String())
+	assert.Equal(t, int64(0), zero.Value())
+
+	// values smaller than the smallest suffix are rounded up when formatted
+	q, err := ParseQuantity("1e-12")
+	assert.NoError(t, err)
+	assert.Equal(t, "1e-9", q.String())
+
+	q, err = ParseQuantity("250m")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, q.Float64())
+	assert.Equal(t, 1, q.Cmp(zero))
+
+	var target Quantity
+	assert.NoError(t, Unmarshal("2Gi", &target))
+	have, err := Marshal(target)
+	assert.NoError(t, err)
+	assert.Equal(t, Value("2Gi"), have)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const ErrUnquotedItem errors.Msg = "item is not quoted"
+
+// readQuoted reads the double-quoted string at the start of str. It returns
+// its unquoted value and the remainder of str.
+func readQuoted(str string) (item, rest string, err error) {
+	if !strings.HasPrefix(str, `"`) {
+		return "", str, errors.New(ErrUnquotedItem)
+	}
+
+	for i := 1; i < len(str); i++ {
+		switch str[i] {
+		case '\\':
+			i++
+		case '"':
+			item, err = strconv.Unquote(str[:i+1])
+			if err != nil {
+				return "", str, errors.Wrap(err, ErrParseFailure)
+			}
+			return item, str[i+1:], nil
+		}
+	}
+	return "", str, errors.New(ErrUnquotedItem)
+}
+
+// splitQuoted splits str into its unquoted items. Each item must be a
+// double-quoted string, which is followed by the next separator of seps or
+// the end of str. The separators are used in turn, so a map item can be
+// split using the key-value and items separators.
+func splitQuoted(str string, seps ...string) ([]string, error) {
+	var res []string
+	for i := 0; str != ""; i++ {
+		item, rest, err := readQuoted(str)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, item)
+		if rest == "" {
+			break
+		}
+
+		sep := seps[i%len(seps)]
+		if !strings.HasPrefix(rest, sep) || len(rest) == len(sep) {
+			return nil, errors.New(ErrUnquotedItem)
+		}
+		str = rest[len(sep):]
+	}
+	return res, nil
+}
+
+// formatItem formats str as an item of an array, slice or map, by quoting it
+// or escaping any separators of seps it contains.
+func (m *Marshaler) formatItem(str string, seps ...string) string {
+	if m.QuoteItems {
+		return strconv.Quote(str)
+	}
+	return escapeSeparators(str, m.escapeChar(), seps...)
+}
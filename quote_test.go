@@ -0,0 +1,74 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuoteItems(t *testing.T) {
+	tests := map[string]struct {
+		input any
+		want  Value
+	}{
+		"slice": {
+			input: []string{"a", " b,c ", `"d"`, ""},
+			want:  `"a"," b,c ","\"d\"",""`,
+		},
+		"single empty": {
+			input: []string{""},
+			want:  `""`,
+		},
+		"ints": {
+			input: []int{1, 2},
+			want:  `"1","2"`,
+		},
+		"map": {
+			input: map[string]string{"a=b": "x,y", "c": "\n"},
+			want:  `"a=b"="x,y","c"="\n"`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := NewMarshaler(WithQuoteItems()).Marshal(reflect.ValueOf(tc.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, have)
+
+			dest := reflect.New(reflect.TypeOf(tc.input))
+			assert.NoError(t, NewUnmarshaler(WithQuoteItems()).Unmarshal(have, dest))
+			assert.Equal(t, tc.input, dest.Elem().Interface())
+		})
+	}
+
+	errs := map[string]struct {
+		input   Value
+		target  any
+		wantErr error
+	}{
+		"unquoted":          {input: `a,"b"`, target: new([]string), wantErr: ErrUnquotedItem},
+		"unterminated":      {input: `"a,b`, target: new([]string), wantErr: ErrUnquotedItem},
+		"trailing":          {input: `"a",`, target: new([]string), wantErr: ErrUnquotedItem},
+		"space":             {input: `"a", "b"`, target: new([]string), wantErr: ErrUnquotedItem},
+		"invalid escape":    {input: `"\q"`, target: new([]string), wantErr: ErrParseFailure},
+		"map missing value": {input: `"a"`, target: new(map[string]string), wantErr: ErrMapInvalidFormat},
+		"map wrong sep":     {input: `"a","b"`, target: new(map[string]string), wantErr: ErrUnquotedItem},
+	}
+	for name, tc := range errs {
+		t.Run(name, func(t *testing.T) {
+			err := NewUnmarshaler(WithQuoteItems()).Unmarshal(tc.input, reflect.ValueOf(tc.target))
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+
+	t.Run("limits", func(t *testing.T) {
+		u := NewUnmarshaler(WithQuoteItems(), WithLimits(Limits{MaxItems: 2}))
+		var list []string
+		assert.NoError(t, u.Unmarshal(`"a,b,c","d"`, reflect.ValueOf(&list)))
+		assert.ErrorIs(t, u.Unmarshal(`"a","b","c"`, reflect.ValueOf(&list)), ErrTooManyItems)
+	})
+}
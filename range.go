@@ -0,0 +1,197 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*IntRange)(nil)
+	_ encoding.TextUnmarshaler = (*IntRange)(nil)
+	_ encoding.TextMarshaler   = (*FloatRange)(nil)
+	_ encoding.TextUnmarshaler = (*FloatRange)(nil)
+	_ encoding.TextMarshaler   = (*TimeRange)(nil)
+	_ encoding.TextUnmarshaler = (*TimeRange)(nil)
+)
+
+// cutRange slices str around the separator of a range, which is either ".."
+// or a "-" that does not indicate a negative number or exponent. It returns
+// str as before when there is no separator.
+func cutRange(str string) (before, after string, found bool) {
+	if before, after, found = strings.Cut(str, ".."); found {
+		return strings.TrimSpace(before), strings.TrimSpace(after), found
+	}
+	for i := 1; i < len(str); i++ {
+		if str[i] != '-' {
+			continue
+		}
+		if c := str[i-1]; (c >= '0' && c <= '9') || c == ' ' {
+			return strings.TrimSpace(str[:i]), strings.TrimSpace(str[i+1:]), true
+		}
+	}
+	return strings.TrimSpace(str), "", false
+}
+
+// IntRange is an inclusive range of integers. Its raw Value is either
+// "min-max", "min..max" or a single integer, e.g. "1-100", "-10..-5" or "8".
+type IntRange struct {
+	Min int64
+	Max int64
+}
+
+// ParseIntRange parses str as an IntRange. Min must not exceed Max.
+func ParseIntRange(str string) (IntRange, error) {
+	var r IntRange
+	min, max, found := cutRange(str)
+
+	var err error
+	if r.Min, err = intSize(Value(min), 64); err != nil {
+		return r, err
+	}
+	if !found {
+		r.Max = r.Min
+	} else if r.Max, err = intSize(Value(max), 64); err != nil {
+		return r, err
+	}
+	if r.Min > r.Max {
+		return r, errors.Wrap(errors.Newf("min exceeds max in `%s`", str), ErrValidationFailure)
+	}
+	return r, nil
+}
+
+// Contains indicates if i is within the range.
+func (r IntRange) Contains(i int64) bool { return i >= r.Min && i <= r.Max }
+
+// String returns IntRange formatted as "min-max", or as "min..max" when
+// either bound is negative.
+func (r IntRange) String() string {
+	sep := "-"
+	if r.Min < 0 || r.Max < 0 {
+		sep = ".."
+	}
+	return strconv.FormatInt(r.Min, 10) + sep + strconv.FormatInt(r.Max, 10)
+}
+
+// MarshalText returns the result of String.
+func (r IntRange) MarshalText() ([]byte, error) { return []byte(r.String()), nil }
+
+// UnmarshalText parses text using ParseIntRange.
+func (r *IntRange) UnmarshalText(text []byte) error {
+	x, err := ParseIntRange(string(text))
+	if err != nil {
+		return err
+	}
+	*r = x
+	return nil
+}
+
+// FloatRange is an inclusive range of floats. Its raw Value is either
+// "min..max", "min-max" or a single float, e.g. "0.5..0.9".
+type FloatRange struct {
+	Min float64
+	Max float64
+}
+
+// ParseFloatRange parses str as a FloatRange. Min must not exceed Max, and
+// neither may be NaN.
+func ParseFloatRange(str string) (FloatRange, error) {
+	var r FloatRange
+	min, max, found := cutRange(str)
+
+	var err error
+	if r.Min, err = floatSize(Value(min), 64); err != nil {
+		return r, err
+	}
+	if !found {
+		r.Max = r.Min
+	} else if r.Max, err = floatSize(Value(max), 64); err != nil {
+		return r, err
+	}
+	if math.IsNaN(r.Min) || math.IsNaN(r.Max) {
+		return r, errors.Wrap(errors.New(ErrNaNNotAllowed), ErrValidationFailure)
+	}
+	if r.Min > r.Max {
+		return r, errors.Wrap(errors.Newf("min exceeds max in `%s`", str), ErrValidationFailure)
+	}
+	return r, nil
+}
+
+// Contains indicates if f is within the range.
+func (r FloatRange) Contains(f float64) bool { return f >= r.Min && f <= r.Max }
+
+// String returns FloatRange formatted as "min..max".
+func (r FloatRange) String() string {
+	return strconv.FormatFloat(r.Min, 'g', -1, 64) + ".." + strconv.FormatFloat(r.Max, 'g', -1, 64)
+}
+
+// MarshalText returns the result of String.
+func (r FloatRange) MarshalText() ([]byte, error) { return []byte(r.String()), nil }
+
+// UnmarshalText parses text using ParseFloatRange.
+func (r *FloatRange) UnmarshalText(text []byte) error {
+	x, err := ParseFloatRange(string(text))
+	if err != nil {
+		return err
+	}
+	*r = x
+	return nil
+}
+
+// TimeRange is a range of time within a day, from Start up to End. Its raw
+// Value has the format "HH:MM-HH:MM", e.g. "09:00-17:00". A TimeRange of which
+// End is before Start spans midnight, e.g. "22:00-06:00".
+type TimeRange struct {
+	Start TimeOfDay
+	End   TimeOfDay
+}
+
+// ParseTimeRange parses str as a TimeRange.
+func ParseTimeRange(str string) (TimeRange, error) {
+	var r TimeRange
+	start, end, found := cutRange(str)
+	if !found {
+		return r, errors.Wrap(errors.Newf("missing end of time range `%s`", str), ErrParseFailure)
+	}
+	if err := r.Start.UnmarshalText([]byte(start)); err != nil {
+		return r, err
+	}
+	if err := r.End.UnmarshalText([]byte(end)); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+// Contains indicates if tod is within the range, including Start and
+// excluding End.
+func (r TimeRange) Contains(tod TimeOfDay) bool {
+	start, end, d := r.Start.Duration(), r.End.Duration(), tod.Duration()
+	if start <= end {
+		return d >= start && d < end
+	}
+	// range spans midnight
+	return d >= start || d < end
+}
+
+// String returns TimeRange formatted as "HH:MM-HH:MM".
+func (r TimeRange) String() string { return r.Start.String() + "-" + r.End.String() }
+
+// MarshalText returns the result of String.
+func (r TimeRange) MarshalText() ([]byte, error) { return []byte(r.String()), nil }
+
+// UnmarshalText parses text using ParseTimeRange.
+func (r *TimeRange) UnmarshalText(text []byte) error {
+	x, err := ParseTimeRange(string(text))
+	if err != nil {
+		return err
+	}
+	*r = x
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIntRange(t *testing.T) {
+	tests := map[string]struct {
+		want    IntRange
+		wantStr string
+	}{
+		"1-100":     {IntRange{1, 100}, "1-100"},
+		"1 - 100":   {IntRange{1, 100}, "1-100"},
+		"1..100":    {IntRange{1, 100}, "1-100"},
+		"-10--5":    {IntRange{-10, -5}, "-10..-5"},
+		"-10..5":    {IntRange{-10, 5}, "-10..5"},
+		"8":         {IntRange{8, 8}, "8-8"},
+		"0x10-0xf0": {IntRange{16, 240}, "16-240"},
+	}
+	for input, tc := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseIntRange(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, tc.want, have)
+			assert.Equal(t, tc.wantStr, have.String())
+
+			var dest IntRange
+			assert.NoError(t, Unmarshal(MustMarshal(have), &dest))
+			assert.Equal(t, have, dest)
+		})
+	}
+
+	errs := map[string]error{
+		"":      ErrParseFailure,
+		"a-b":   ErrParseFailure,
+		"1-":    ErrParseFailure,
+		"100-1": ErrValidationFailure,
+	}
+	for input, wantErr := range errs {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseIntRange(input)
+			assert.ErrorIs(t, haveErr, wantErr)
+		})
+	}
+
+	assert.True(t, IntRange{1, 10}.Contains(10))
+	assert.False(t, IntRange{1, 10}.Contains(11))
+}
+
+func TestParseFloatRange(t *testing.T) {
+	tests := map[string]FloatRange{
+		"0.5..0.9":  {0.5, 0.9},
+		"1e-5-2":    {1e-5, 2},
+		"-1.5..1.5": {-1.5, 1.5},
+		"0.25":      {0.25, 0.25},
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseFloatRange(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, want, have)
+
+			var dest FloatRange
+			assert.NoError(t, Unmarshal(MustMarshal(have), &dest))
+			assert.Equal(t, have, dest)
+		})
+	}
+
+	_, haveErr := ParseFloatRange("0.9..0.5")
+	assert.ErrorIs(t, haveErr, ErrValidationFailure)
+	_, haveErr = ParseFloatRange("NaN..1")
+	assert.ErrorIs(t, haveErr, ErrNaNNotAllowed)
+
+	assert.True(t, FloatRange{0.5, 0.9}.Contains(0.5))
+	assert.False(t, FloatRange{0.5, 0.9}.Contains(0.91))
+}
+
+func TestParseTimeRange(t *testing.T) {
+	have, haveErr := ParseTimeRange("09:00-17:30")
+	assert.NoError(t, haveErr)
+	assert.Equal(t, TimeRange{TimeOfDay{Hour: 9}, TimeOfDay{Hour: 17, Minute: 30}}, have)
+	assert.Equal(t, "09:00-17:30", have.String())
+	assert.True(t, have.Contains(TimeOfDay{Hour: 9}))
+	assert.True(t, have.Contains(TimeOfDay{Hour: 12}))
+	assert.False(t, have.Contains(TimeOfDay{Hour: 17, Minute: 30}))
+
+	night, haveErr := ParseTimeRange("22:00..06:00")
+	assert.NoError(t, haveErr)
+	assert.True(t, night.Contains(TimeOfDay{Hour: 23}))
+	assert.True(t, night.Contains(TimeOfDay{Hour: 1}))
+	assert.False(t, night.Contains(TimeOfDay{Hour: 12}))
+
+	var dest TimeRange
+	assert.NoError(t, Unmarshal(MustMarshal(have), &dest))
+	assert.Equal(t, have, dest)
+
+	_, haveErr = ParseTimeRange("09:00")
+	assert.ErrorIs(t, haveErr, ErrParseFailure)
+	_, haveErr = ParseTimeRange("09:00-25:00")
+	assert.ErrorIs(t, haveErr, ErrParseFailure)
+}
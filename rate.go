@@ -0,0 +1,120 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Rate)(nil)
+	_ encoding.TextUnmarshaler = (*Rate)(nil)
+)
+
+// Rate is a number of events per time.Duration, e.g. for rate limiting. Its
+// raw Value has the format "events/duration", where duration is either a unit
+// like "s", "m" or "h", or a time.Duration, e.g. "100/s", "5000/m" or "10/5s".
+type Rate struct {
+	Events float64
+	Per    time.Duration
+}
+
+// ParseRate parses str as a Rate. Events must be finite and not negative, and
+// the duration must be greater than zero.
+func ParseRate(str string) (Rate, error) {
+	events, per, ok := strings.Cut(str, "/")
+	if !ok {
+		return Rate{}, errors.Wrap(errors.Newf("missing `/` in rate `%s`", str), ErrParseFailure)
+	}
+
+	var r Rate
+	var err error
+	if r.Events, err = floatSize(Value(strings.TrimSpace(events)), 64); err != nil {
+		return r, err
+	}
+	if per = strings.TrimSpace(per); per != "" && (per[0] < '0' || per[0] > '9') {
+		per = "1" + per
+	}
+	if r.Per, err = Value(per).Duration(); err != nil {
+		return r, err
+	}
+
+	if r.Events < 0 || math.IsNaN(r.Events) || math.IsInf(r.Events, 0) {
+		return r, errors.Wrap(errors.Newf("invalid number of events in rate `%s`", str), ErrValidationFailure)
+	}
+	if r.Per <= 0 {
+		return r, errors.Wrap(errors.Newf("invalid duration in rate `%s`", str), ErrValidationFailure)
+	}
+	return r, nil
+}
+
+// PerSecond returns the number of events per second. The result is compatible
+// with rate.Limit of package golang.org/x/time/rate.
+func (r Rate) PerSecond() float64 {
+	if r.Per <= 0 {
+		return 0
+	}
+	return r.Events / r.Per.Seconds()
+}
+
+// Interval returns the time.Duration between two events, or 0 when there are
+// no events.
+func (r Rate) Interval() time.Duration {
+	if r.Events <= 0 {
+		return 0
+	}
+	return time.Duration(float64(r.Per) / r.Events)
+}
+
+// IsZero indicates if Rate is the zero value.
+func (r Rate) IsZero() bool { return r == Rate{} }
+
+// String returns Rate formatted as "events/duration", e.g. "100/s", or an
+// empty string when Rate is the zero value.
+func (r Rate) String() string {
+	if r.IsZero() {
+		return ""
+	}
+
+	var per string
+	switch r.Per {
+	case time.Millisecond:
+		per = "ms"
+	case time.Second:
+		per = "s"
+	case time.Minute:
+		per = "m"
+	case time.Hour:
+		per = "h"
+	default:
+		per = r.Per.String()
+	}
+	return strconv.FormatFloat(r.Events, 'g', -1, 64) + "/" + per
+}
+
+// MarshalText returns the result of String.
+func (r Rate) MarshalText() ([]byte, error) { return []byte(r.String()), nil }
+
+// UnmarshalText parses text using ParseRate. An empty text results in the
+// zero value.
+func (r *Rate) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*r = Rate{}
+		return nil
+	}
+
+	x, err := ParseRate(string(text))
+	if err != nil {
+		return err
+	}
+	*r = x
+	return nil
+}
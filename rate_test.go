@@ -0,0 +1,74 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := map[string]struct {
+		want      Rate
+		wantStr   string
+		perSecond float64
+	}{
+		"100/s":   {Rate{100, time.Second}, "100/s", 100},
+		"5000/m":  {Rate{5000, time.Minute}, "5000/m", 5000.0 / 60},
+		"10/5s":   {Rate{10, 5 * time.Second}, "10/5s", 2},
+		"1 / h":   {Rate{1, time.Hour}, "1/h", 1.0 / 3600},
+		"0.5/ms":  {Rate{0.5, time.Millisecond}, "0.5/ms", 500},
+		"3/1m30s": {Rate{3, 90 * time.Second}, "3/1m30s", 3.0 / 90},
+		"0/s":     {Rate{0, time.Second}, "0/s", 0},
+	}
+	for input, tc := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseRate(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, tc.want, have)
+			assert.Equal(t, tc.wantStr, have.String())
+			assert.InDelta(t, tc.perSecond, have.PerSecond(), 1e-9)
+
+			var dest Rate
+			assert.NoError(t, Unmarshal(MustMarshal(have), &dest))
+			assert.Equal(t, have, dest)
+		})
+	}
+
+	errs := map[string]error{
+		"":      ErrParseFailure,
+		"100":   ErrParseFailure,
+		"x/s":   ErrParseFailure,
+		"100/x": ErrParseFailure,
+		"100/":  ErrParseFailure,
+		"-1/s":  ErrValidationFailure,
+		"1/0s":  ErrValidationFailure,
+		"Inf/s": ErrValidationFailure,
+	}
+	for input, wantErr := range errs {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseRate(input)
+			assert.ErrorIs(t, haveErr, wantErr)
+		})
+	}
+}
+
+func TestRate_zero(t *testing.T) {
+	assert.Equal(t, Value(""), MustMarshal(Rate{}))
+
+	r := Rate{100, time.Second}
+	assert.NoError(t, r.UnmarshalText(nil))
+	assert.True(t, r.IsZero())
+	assert.NoError(t, Unmarshal(MustMarshal(Rate{}), &r, WithEmptyMode(EmptyZero)))
+	assert.True(t, r.IsZero())
+}
+
+func TestRate_Interval(t *testing.T) {
+	assert.Equal(t, 10*time.Millisecond, Rate{100, time.Second}.Interval())
+	assert.Equal(t, 500*time.Millisecond, Rate{10, 5 * time.Second}.Interval())
+	assert.Equal(t, time.Duration(0), Rate{0, time.Second}.Interval())
+}
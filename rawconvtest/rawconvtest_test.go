@@ -0,0 +1,119 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconvtest
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockT struct {
+	errs []string
+}
+
+func (m *mockT) Helper() {}
+
+func (m *mockT) Errorf(format string, args ...any) {
+	m.errs = append(m.errs, fmt.Sprintf(format, args...))
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := map[string]func(t TestingT) bool{
+		"string":     func(t TestingT) bool { return RoundTrip(t, "some value") },
+		"bool":       func(t TestingT) bool { return RoundTrip(t, true) },
+		"int":        func(t TestingT) bool { return RoundTrip(t, -10) },
+		"uint8":      func(t TestingT) bool { return RoundTrip(t, uint8(255)) },
+		"float64":    func(t TestingT) bool { return RoundTrip(t, 3.14) },
+		"float32":    func(t TestingT) bool { return RoundTrip(t, float32(0.1)) },
+		"complex128": func(t TestingT) bool { return RoundTrip(t, 1+2i) },
+		"rune":       func(t TestingT) bool { return RoundTrip(t, 'a') },
+		"duration":   func(t TestingT) bool { return RoundTrip(t, time.Second*90) },
+		"time": func(t TestingT) bool {
+			return RoundTrip(t, time.Date(1997, 8, 29, 13, 37, 0, 0, time.FixedZone("", 3600)))
+		},
+		"url": func(t TestingT) bool {
+			return RoundTrip(t, url.URL{Scheme: "http", Host: "localhost", Path: "/"})
+		},
+		"pointer": func(t TestingT) bool { return RoundTrip(t, ptr(10)) },
+		"slice":   func(t TestingT) bool { return RoundTrip(t, []int{1, 2, 3}) },
+		"array":   func(t TestingT) bool { return RoundTrip(t, [2]string{"a", "b"}) },
+		"map":     func(t TestingT) bool { return RoundTrip(t, map[string]int{"a": 1, "b": 2}) },
+	}
+	for name, fn := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, fn(t))
+		})
+	}
+
+	t.Run("mismatch", func(t *testing.T) {
+		var m mockT
+		assert.False(t, RoundTrip(&m, []string{"a,b"}))
+		assert.Len(t, m.errs, 1)
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		var m mockT
+		assert.False(t, RoundTrip(&m, make(chan int)))
+		assert.Len(t, m.errs, 1)
+	})
+}
+
+func TestRoundTripBuiltins(t *testing.T) {
+	assert.True(t, RoundTripBuiltins(t))
+}
+
+func TestEqual(t *testing.T) {
+	nan := math.NaN()
+	assert.True(t, Equal(nan, nan))
+	assert.True(t, Equal(complex(nan, 1), complex(nan, 1)))
+	assert.True(t, Equal([]float64{nan}, []float64{nan}))
+	assert.True(t, Equal(map[string]float32{"a": float32(nan)}, map[string]float32{"a": float32(nan)}))
+	assert.True(t, Equal(ptr(nan), ptr(nan)))
+	assert.False(t, Equal(nan, 1.0))
+	assert.False(t, Equal([]float64{nan}, []float64(nil)))
+	assert.False(t, Equal([]float64{}, []float64(nil)))
+	assert.False(t, Equal(map[string]int{"a": 1}, map[string]int{"b": 1}))
+	assert.True(t, Equal(struct{ A int }{1}, struct{ A int }{1}))
+
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, Equal(t1, t1.In(time.FixedZone("", 3600))))
+}
+
+func TestSeeds(t *testing.T) {
+	assert.Nil(t, Seeds(reflect.TypeOf(struct{}{})))
+	assert.Contains(t, Seeds(reflect.TypeOf((**int)(nil))), "0x1F")
+	assert.Contains(t, Seeds(reflect.TypeOf([]bool{})), "true,false,1,0,T,F,TRUE,False,yes")
+	assert.Contains(t, Seeds(reflect.TypeOf(map[string]int{})), "some value=0")
+
+	for _, typ := range []reflect.Type{
+		reflect.TypeOf(""),
+		reflect.TypeOf(int64(0)),
+		reflect.TypeOf(uint(0)),
+		reflect.TypeOf(float32(0)),
+		reflect.TypeOf(complex64(0)),
+		reflect.TypeOf(time.Time{}),
+		reflect.TypeOf(url.URL{}),
+	} {
+		t.Run(typ.String(), func(t *testing.T) {
+			assert.NotEmpty(t, Seeds(typ))
+		})
+	}
+}
+
+func FuzzRoundTrip_int(f *testing.F)        { FuzzRoundTrip[int](f) }
+func FuzzRoundTrip_uint16(f *testing.F)     { FuzzRoundTrip[uint16](f) }
+func FuzzRoundTrip_float32(f *testing.F)    { FuzzRoundTrip[float32](f) }
+func FuzzRoundTrip_float64(f *testing.F)    { FuzzRoundTrip[float64](f) }
+func FuzzRoundTrip_complex128(f *testing.F) { FuzzRoundTrip[complex128](f) }
+func FuzzRoundTrip_duration(f *testing.F)   { FuzzRoundTrip[time.Duration](f) }
+func FuzzRoundTrip_time(f *testing.F)       { FuzzRoundTrip[time.Time](f) }
+func FuzzRoundTrip_slice(f *testing.F)      { FuzzRoundTrip[[]int](f) }
+
+func ptr[T any](v T) *T { return &v }
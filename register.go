@@ -6,6 +6,7 @@ package rawconv
 
 import (
 	"encoding"
+	"image/color"
 	"net/url"
 	"reflect"
 	"time"
@@ -23,6 +24,21 @@ func RegisterMarshalFunc(typ reflect.Type, fn MarshalFunc) {
 	marshaler.Register(typ, fn)
 }
 
+// Scope takes a snapshot of all globally registered MarshalFunc and
+// UnmarshalFunc funcs. The returned func restores this snapshot, removing or
+// reverting any funcs registered in the meantime, without changing the frozen
+// state. It is intended for tests and plugins which temporarily need to
+// register or override funcs:
+//
+//	defer rawconv.Scope()()
+func Scope() (restore func()) {
+	m, u := marshaler.Scope(), unmarshaler.Scope()
+	return func() {
+		m()
+		u()
+	}
+}
+
 func init() {
 	// interfaces
 	textMarshaler := reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
@@ -38,6 +54,18 @@ func init() {
 	RegisterUnmarshalFunc(timeDuration, unmarshalDuration)
 	RegisterMarshalFunc(timeDuration, marshalDuration)
 
+	timeWeekday := reflect.TypeOf(time.Sunday)
+	RegisterUnmarshalFunc(timeWeekday, unmarshalWeekday)
+	RegisterMarshalFunc(timeWeekday, marshalWeekday)
+
+	timeMonth := reflect.TypeOf(time.January)
+	RegisterUnmarshalFunc(timeMonth, unmarshalMonth)
+	RegisterMarshalFunc(timeMonth, marshalMonth)
+
+	colorRGBA := reflect.TypeOf(color.RGBA{})
+	RegisterUnmarshalFunc(colorRGBA, unmarshalRGBA)
+	RegisterMarshalFunc(colorRGBA, marshalRGBA)
+
 	urlUrl := reflect.TypeOf(url.URL{})
 	RegisterUnmarshalFunc(urlUrl, unmarshalUrl)
 	RegisterMarshalFunc(urlUrl, marshalUrl)
@@ -53,15 +81,48 @@ func marshalText(v any) (string, error) {
 }
 
 type register[T interface{ MarshalFunc | UnmarshalFunc }] struct {
-	types map[reflect.Kind]map[reflect.Type]int
-	funcs []T
+	types  map[reflect.Kind]map[reflect.Type]int
+	funcs  []T
+	frozen bool
+}
+
+// clone returns a deep copy of the register.
+func (r *register[T]) clone() register[T] {
+	res := register[T]{frozen: r.frozen}
+	if r.types != nil {
+		res.types = make(map[reflect.Kind]map[reflect.Type]int, len(r.types))
+		for k, types := range r.types {
+			res.types[k] = make(map[reflect.Type]int, len(types))
+			for typ, i := range types {
+				res.types[k][typ] = i
+			}
+		}
+	}
+	if r.funcs != nil {
+		res.funcs = make([]T, len(r.funcs))
+		copy(res.funcs, r.funcs)
+	}
+	return res
+}
+
+// restore replaces the registered funcs with those of snapshot, while keeping
+// the current frozen state.
+func (r *register[T]) restore(snapshot register[T]) {
+	r.types, r.funcs = snapshot.types, snapshot.funcs
 }
 
 func (r *register[T]) initialized() bool { return r.types != nil && r.funcs != nil }
 
-const panicUnsupportedKind = "rawconv: unsupported kind"
+const (
+	panicUnsupportedKind = "rawconv: unsupported kind"
+	panicFrozen          = "rawconv: register is frozen"
+)
 
 func (r *register[T]) add(typ reflect.Type, fn T) {
+	if r.frozen {
+		panic(panicFrozen)
+	}
+
 	k := typ.Kind()
 	if k == reflect.Invalid ||
 		k == reflect.Uintptr ||
@@ -91,26 +152,32 @@ func (r *register[T]) add(typ reflect.Type, fn T) {
 	r.funcs = append(r.funcs, fn)
 }
 
-func (r *register[T]) find(typ reflect.Type) T {
-	// check if the exact type is registered
+// lookupType returns the func registered for exactly typ, or for the type it
+// points to, and the type it is registered with.
+func (r *register[T]) lookupType(typ reflect.Type) (T, reflect.Type) {
 	if fn := r.getFromType(typ); fn != nil {
-		return fn
+		return fn, typ
+	}
+	if typ.Kind() == reflect.Ptr {
+		return r.lookupType(typ.Elem())
 	}
+	return nil, nil
+}
 
+// lookupImpl returns the func registered for an interface which is
+// implemented by typ, or a pointer to typ, and the interface it is registered
+// with.
+func (r *register[T]) lookupImpl(typ reflect.Type) (T, reflect.Type) {
 	if typ.Kind() != reflect.Ptr {
 		// check if the type is registered as a pointer
 		return r.getFromImpl(reflect.New(typ).Type())
 	}
 
 	// check if the elem type which is pointed to is registered
-	if fn := r.find(typ.Elem()); fn != nil {
-		return fn
+	if fn, x := r.lookupImpl(typ.Elem()); fn != nil {
+		return fn, x
 	}
-	if fn := r.getFromImpl(typ); fn != nil {
-		return fn
-	}
-
-	return nil
+	return r.getFromImpl(typ)
 }
 
 func (r *register[T]) getFromType(typ reflect.Type) T {
@@ -122,13 +189,13 @@ func (r *register[T]) getFromType(typ reflect.Type) T {
 	return nil
 }
 
-func (r *register[T]) getFromImpl(typ reflect.Type) T {
+func (r *register[T]) getFromImpl(typ reflect.Type) (T, reflect.Type) {
 	for x, i := range r.types[reflect.Interface] {
 		if typ.Implements(x) {
-			return r.getFromIndex(i)
+			return r.getFromIndex(i), x
 		}
 	}
-	return nil
+	return nil, nil
 }
 
 const panicInvalidFuncIndex = "rawconv: invalid index, func must exist!"
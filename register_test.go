@@ -71,3 +71,84 @@ func testRegisterFind(t *testing.T, i int, getFn func(reflect.Type) any) {
 		}
 	}
 }
+
+func TestFreeze(t *testing.T) {
+	typ := reflect.TypeOf(stageText(""))
+
+	t.Run("marshaler", func(t *testing.T) {
+		var m Marshaler
+		m.Register(typ, func(any) (string, error) { return "foo", nil })
+		assert.False(t, m.Frozen())
+		assert.True(t, m.Freeze().Frozen())
+
+		assert.PanicsWithValue(t, panicFrozen, func() {
+			m.Register(typ, func(any) (string, error) { return "bar", nil })
+		})
+
+		have, err := m.Marshal(reflect.ValueOf(stageText("")))
+		assert.NoError(t, err)
+		assert.Equal(t, Value("foo"), have)
+	})
+	t.Run("unmarshaler", func(t *testing.T) {
+		var u Unmarshaler
+		u.Freeze()
+		assert.True(t, u.Frozen())
+		assert.PanicsWithValue(t, panicFrozen, func() {
+			u.Register(typ, func(Value, any) error { return nil })
+		})
+	})
+}
+
+func TestScope(t *testing.T) {
+	typ := reflect.TypeOf(stageText(""))
+	val := reflect.ValueOf(stageText("foo"))
+
+	t.Run("marshaler", func(t *testing.T) {
+		var m Marshaler
+		m.Register(typ, func(any) (string, error) { return "foo", nil })
+
+		restore := m.Scope()
+		m.Register(typ, func(any) (string, error) { return "bar", nil })
+		have, _ := m.Marshal(val)
+		assert.Equal(t, Value("bar"), have)
+
+		restore()
+		have, _ = m.Marshal(val)
+		assert.Equal(t, Value("foo"), have)
+	})
+	t.Run("frozen", func(t *testing.T) {
+		var m Marshaler
+		m.Register(typ, func(any) (string, error) { return "foo", nil })
+
+		restore := m.Scope()
+		m.Freeze()
+		restore()
+		assert.True(t, m.Frozen())
+		assert.PanicsWithValue(t, panicFrozen, func() {
+			m.Register(typ, func(any) (string, error) { return "bar", nil })
+		})
+
+		var u Unmarshaler
+		restore = u.Scope()
+		u.Freeze()
+		restore()
+		assert.True(t, u.Frozen())
+	})
+	t.Run("global", func(t *testing.T) {
+		restore := Scope()
+		RegisterMarshalFunc(typ, func(any) (string, error) { return "bar", nil })
+		RegisterUnmarshalFunc(typ, func(Value, any) error { return nil })
+		have, err := Marshal(stageText("foo"))
+		assert.NoError(t, err)
+		assert.Equal(t, Value("bar"), have)
+
+		restore()
+		have, err = Marshal(stageText("foo"))
+		assert.NoError(t, err)
+		assert.Equal(t, Value("text foo"), have)
+
+		var dest stageText
+		assert.NoError(t, Unmarshal("foo", &dest))
+		assert.Equal(t, stageText("foo"), dest)
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// Change describes a struct field of which the value is changed by Reload.
+type Change struct {
+	// Field is the path of the field within the struct, e.g. "Db.Host".
+	Field string
+	// Key is the key of the Value within the Source.
+	Key string
+	// Old is the marshaled value of the field before the reload.
+	Old Value
+	// New is the marshaled value of the field after the reload.
+	New Value
+}
+
+// Reload re-applies the Value(s) from Source src onto the struct pointed to by
+// v, using the global Unmarshaler. See Unmarshaler.Reload for additional
+// details.
+func Reload(src Source, v any) ([]Change, error) {
+	return unmarshaler.Reload(src, v)
+}
+
+// Reload re-applies the Value(s) from Source src onto the existing struct
+// pointed to by v, like UnmarshalStruct does. It returns the fields of which
+// the value has changed. The struct is only modified when all Value(s) are
+// unmarshaled successfully, so it is never left partially reloaded. Old and
+// new values are compared by marshaling them with Options.Marshaler, or a
+// Marshaler that uses the same Options as Unmarshaler when it is not set.
+func (u *Unmarshaler) Reload(src Source, v any) ([]Change, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, errors.New(ErrStructExpected)
+	}
+
+	rv = rv.Elem()
+	m := u.marshaler()
+	fields := structFields(rv.Type(), func(typ reflect.Type) bool {
+		return u.Func(typ) != nil
+	})
+
+	lookup := u.lookupFunc(src)
+
+	// marshal the current values of all fields which are going to be set
+	changes := make([]Change, 0, len(fields))
+	indexes := make([][]int, 0, len(fields))
+	marshalers := make([]*Marshaler, 0, len(fields))
+	for _, field := range fields {
+		key, _, _, ok := field.lookup(lookup)
+		if !ok {
+			continue
+		}
+
+		fm, err := m.forField(field.tag)
+		var old Value
+		if err == nil {
+			old, err = fm.Marshal(rv.FieldByIndex(field.index))
+		}
+		if err != nil {
+			return nil, errors.WithStack(&FieldError{
+				Field: field.path,
+				Key:   key,
+				Err:   err,
+			})
+		}
+
+		changes = append(changes, Change{
+			Field: field.path,
+			Key:   key,
+			Old:   old,
+		})
+		indexes = append(indexes, field.index)
+		marshalers = append(marshalers, fm)
+	}
+
+	// unmarshal into a copy, so v is left untouched when an error occurs
+	res := reflect.New(rv.Type())
+	res.Elem().Set(rv)
+	for _, index := range indexes {
+		detach(res.Elem().FieldByIndex(index))
+	}
+	if _, err := u.UnmarshalStruct(src, res.Interface()); err != nil {
+		return nil, err
+	}
+
+	n := 0
+	for i, change := range changes {
+		var err error
+		fv := res.Elem().FieldByIndex(indexes[i])
+		if change.New, err = marshalers[i].Marshal(fv); err != nil {
+			return nil, errors.WithStack(&FieldError{
+				Field: change.Field,
+				Key:   change.Key,
+				Err:   err,
+			})
+		}
+		if change.New != change.Old {
+			changes[n] = change
+			n++
+		}
+	}
+
+	rv.Set(res.Elem())
+	return changes[:n], nil
+}
+
+// detach replaces the map or pointer of val with a copy, so unmarshaling into
+// val does not modify the original struct it is copied from.
+func detach(val reflect.Value) {
+	switch val.Kind() {
+	case reflect.Map:
+		if val.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(val.Type(), val.Len())
+		for iter := val.MapRange(); iter.Next(); {
+			m.SetMapIndex(iter.Key(), iter.Value())
+		}
+		val.Set(m)
+
+	case reflect.Ptr:
+		if val.IsNil() {
+			return
+		}
+		p := reflect.New(val.Type().Elem())
+		p.Elem().Set(val.Elem())
+		detach(p.Elem())
+		val.Set(p)
+	}
+}
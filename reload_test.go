@@ -0,0 +1,135 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReload(t *testing.T) {
+	t.Run("not a struct pointer", func(t *testing.T) {
+		_, err := Reload(Values{}, testConfig{})
+		assert.ErrorIs(t, err, ErrStructExpected)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		have := testConfig{Name: "foo", Timeout: time.Second}
+		have.Db.Port = 5432
+
+		haveChanges, haveErr := Reload(Values{
+			"Name":    "foo",
+			"timeout": "1m",
+			"db.port": "5433",
+		}, &have)
+
+		assert.NoError(t, haveErr)
+		assert.Equal(t, time.Minute, have.Timeout)
+		assert.Equal(t, 5433, have.Db.Port)
+		assert.Equal(t, []Change{
+			{Field: "Timeout", Key: "timeout", Old: "1s", New: "1m0s"},
+			{Field: "Db.Port", Key: "db.port", Old: "5432", New: "5433"},
+		}, haveChanges)
+	})
+
+	t.Run("alias", func(t *testing.T) {
+		var have struct {
+			Timeout time.Duration `rawconv:"timeout,alias=TIMEOUT_SECS"`
+		}
+
+		haveChanges, haveErr := Reload(Values{"TIMEOUT_SECS": "5s"}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, 5*time.Second, have.Timeout)
+		assert.Equal(t, []Change{
+			{Field: "Timeout", Key: "TIMEOUT_SECS", Old: "0s", New: "5s"},
+		}, haveChanges)
+	})
+
+	t.Run("fold keys", func(t *testing.T) {
+		var have struct{ Port int }
+
+		haveChanges, haveErr := NewUnmarshaler(WithFoldKeys()).Reload(Values{"port": "81"}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, 81, have.Port)
+		assert.Equal(t, []Change{
+			{Field: "Port", Key: "Port", Old: "0", New: "81"},
+		}, haveChanges)
+	})
+
+	t.Run("tag options", func(t *testing.T) {
+		have := struct {
+			Mask  int      `rawconv:"mask,base=16"`
+			Hosts []string `rawconv:"hosts,sep=;"`
+		}{Mask: 0xff, Hosts: []string{"a", "b"}}
+
+		haveChanges, haveErr := Reload(Values{"mask": "1f", "hosts": "a;c"}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, []Change{
+			{Field: "Mask", Key: "mask", Old: "ff", New: "1f"},
+			{Field: "Hosts", Key: "hosts", Old: "a;b", New: "a;c"},
+		}, haveChanges)
+	})
+
+	t.Run("local funcs", func(t *testing.T) {
+		type version struct{ major, minor int }
+		typ := reflect.TypeOf(version{})
+
+		var m Marshaler
+		m.Register(typ, func(v any) (string, error) {
+			x := v.(version)
+			return fmt.Sprintf("%d.%d", x.major, x.minor), nil
+		})
+		u := NewUnmarshaler(WithMarshaler(&m))
+		u.Register(typ, func(val Value, dest any) error {
+			x := dest.(*version)
+			_, err := fmt.Sscanf(val.String(), "%d.%d", &x.major, &x.minor)
+			return err
+		})
+
+		have := struct {
+			Version version `rawconv:"version"`
+		}{Version: version{1, 2}}
+
+		haveChanges, haveErr := u.Reload(Values{"version": "1.3"}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, []Change{
+			{Field: "Version", Key: "version", Old: "1.2", New: "1.3"},
+		}, haveChanges)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var have testConfig
+		haveChanges, haveErr := Reload(Values{"db.port": "not a number"}, &have)
+		assert.ErrorIs(t, haveErr, ErrParseFailure)
+		assert.Nil(t, haveChanges)
+	})
+
+	t.Run("error leaves struct untouched", func(t *testing.T) {
+		type config struct {
+			A string
+			C map[string]int
+			D *int
+			B int
+		}
+
+		d := 1
+		have := config{A: "old", B: 1, C: map[string]int{"x": 1}, D: &d}
+		haveChanges, haveErr := Reload(Values{
+			"A": "new",
+			"B": "notint",
+			"C": "y=2",
+			"D": "2",
+		}, &have)
+
+		assert.ErrorIs(t, haveErr, ErrParseFailure)
+		assert.Nil(t, haveChanges)
+		assert.Equal(t, config{A: "old", B: 1, C: map[string]int{"x": 1}, D: &d}, have)
+		assert.Equal(t, 1, d)
+	})
+}
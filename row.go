@@ -0,0 +1,102 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// RowBinder binds records, such as those read by encoding/csv, to the fields
+// of a struct. A field is bound to a column by its index, using the "col" tag
+// option, e.g. `rawconv:",col=0"`, or by matching its key with the name of a
+// column within the header. Fields without a matching column are left
+// untouched.
+type RowBinder struct {
+	u       *Unmarshaler
+	columns map[string]int
+}
+
+// NewRowBinder returns a RowBinder which uses the global Unmarshaler. Header
+// contains the names of the columns and may be nil when all fields are bound
+// by index.
+func NewRowBinder(header []string) *RowBinder {
+	return unmarshaler.RowBinder(header)
+}
+
+// RowBinder returns a RowBinder which uses the Unmarshaler. See NewRowBinder
+// for additional details.
+func (u *Unmarshaler) RowBinder(header []string) *RowBinder {
+	b := RowBinder{
+		u:       u,
+		columns: make(map[string]int, len(header)),
+	}
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if u.FoldKeys {
+			name = foldKey(name)
+		}
+		if _, ok := b.columns[name]; !ok {
+			b.columns[name] = i
+		}
+	}
+	return &b
+}
+
+// Bind unmarshals the columns of record into the fields of the struct pointed
+// to by v. Fields are handled as with UnmarshalStruct, including the
+// "required" tag option.
+func (b *RowBinder) Bind(record []string, v any) error {
+	typ := reflect.TypeOf(v)
+	if typ == nil || typ.Kind() != reflect.Ptr || typ.Elem().Kind() != reflect.Struct {
+		return errors.New(ErrStructExpected)
+	}
+
+	fields := structFields(typ.Elem(), func(typ reflect.Type) bool {
+		return b.u.Func(typ) != nil
+	})
+
+	vals := make(Values, len(fields))
+	for _, field := range fields {
+		col, err := b.column(field)
+		if err != nil {
+			return errors.WithStack(&FieldError{
+				Field: field.path,
+				Key:   field.key,
+				Err:   err,
+			})
+		}
+		if col >= 0 && col < len(record) {
+			vals[field.key] = Value(record[col])
+		}
+	}
+
+	_, err := b.u.UnmarshalStruct(vals, v)
+	return err
+}
+
+// column returns the index of the column of field, or -1 when there is none.
+func (b *RowBinder) column(field structField) (int, error) {
+	if str, ok := field.tag.lookup("col"); ok {
+		col, err := strconv.Atoi(str)
+		if err != nil || col < 0 {
+			return -1, errors.Newf("%w `col=%s`", ErrInvalidTagOption, str)
+		}
+		return col, nil
+	}
+
+	for _, key := range append([]string{field.key}, field.aliases...) {
+		if b.u.FoldKeys {
+			key = foldKey(key)
+		}
+		if col, ok := b.columns[key]; ok {
+			return col, nil
+		}
+	}
+	return -1, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type rowRecord struct {
+	ID      int           `rawconv:"id,required"`
+	Name    string        `rawconv:"name"`
+	Timeout time.Duration `rawconv:"timeout,alias=ttl"`
+	Tags    []string      `rawconv:"tags,sep=;"`
+}
+
+func TestRowBinder(t *testing.T) {
+	t.Run("header", func(t *testing.T) {
+		r := csv.NewReader(strings.NewReader("name,id,ttl,tags,other\nfoo,1,5s,a;b,x\nbar,2,1m,,y\n"))
+		header, err := r.Read()
+		assert.NoError(t, err)
+
+		b := NewRowBinder(header)
+
+		var have []rowRecord
+		for {
+			record, err := r.Read()
+			if err == io.EOF {
+				break
+			}
+			assert.NoError(t, err)
+
+			var rec rowRecord
+			assert.NoError(t, b.Bind(record, &rec))
+			have = append(have, rec)
+		}
+		assert.Equal(t, []rowRecord{
+			{ID: 1, Name: "foo", Timeout: 5 * time.Second, Tags: []string{"a", "b"}},
+			{ID: 2, Name: "bar", Timeout: time.Minute},
+		}, have)
+	})
+	t.Run("index", func(t *testing.T) {
+		var have struct {
+			Name  string `rawconv:",col=1"`
+			Count uint   `rawconv:",col=0"`
+			Extra string `rawconv:",col=5"`
+		}
+		assert.NoError(t, NewRowBinder(nil).Bind([]string{"3", "foo"}, &have))
+		assert.Equal(t, "foo", have.Name)
+		assert.Equal(t, uint(3), have.Count)
+		assert.Equal(t, "", have.Extra)
+	})
+	t.Run("fold keys", func(t *testing.T) {
+		var have rowRecord
+		b := NewUnmarshaler(WithFoldKeys()).RowBinder([]string{"ID", "Name"})
+		assert.NoError(t, b.Bind([]string{"7", "baz"}, &have))
+		assert.Equal(t, rowRecord{ID: 7, Name: "baz"}, have)
+	})
+	t.Run("errors", func(t *testing.T) {
+		b := NewRowBinder([]string{"id", "name"})
+
+		var rec rowRecord
+		assert.ErrorIs(t, b.Bind([]string{"x", "foo"}, &rec), ErrParseFailure)
+		assert.ErrorIs(t, b.Bind([]string{}, &rec), ErrMissingValue)
+		assert.ErrorIs(t, b.Bind([]string{"1"}, rec), ErrStructExpected)
+
+		var invalid struct {
+			Name string `rawconv:",col=x"`
+		}
+		assert.ErrorIs(t, b.Bind([]string{"1"}, &invalid), ErrInvalidTagOption)
+	})
+}
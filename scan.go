@@ -0,0 +1,62 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const ErrScanCount errors.Msg = "number of values and targets differ"
+
+// ScanError is returned by Scan when the Value at Index cannot be unmarshaled
+// into its target.
+type ScanError struct {
+	Index int
+	Err   error
+}
+
+func (e *ScanError) Unwrap() error { return e.Err }
+
+func (e *ScanError) Error() string {
+	return "value #" + strconv.Itoa(e.Index) + ": " + e.Err.Error()
+}
+
+// Scan unmarshals values into targets using the global Unmarshaler. See
+// Unmarshaler.Scan for additional details.
+func Scan(values []Value, targets ...any) error {
+	return unmarshaler.Scan(values, targets...)
+}
+
+// Scan unmarshals each Value of values into the target at the same position,
+// like sql.Rows.Scan. This is useful for parsing delimited records or
+// positional command line arguments. Each target must be a non-nil pointer. A
+// nil target skips the Value at its position. The number of values and
+// targets must be equal, otherwise an error wrapping ErrScanCount is
+// returned. An error for a specific Value is returned as *ScanError.
+func (u *Unmarshaler) Scan(values []Value, targets ...any) error {
+	if len(values) != len(targets) {
+		return errors.Newf("%w (%d values, %d targets)", ErrScanCount, len(values), len(targets))
+	}
+
+	for i, target := range targets {
+		if target == nil {
+			continue
+		}
+
+		var err error
+		if rv := reflect.ValueOf(target); rv.Kind() != reflect.Ptr || rv.IsNil() {
+			err = errors.New(ErrPointerExpected)
+		} else {
+			err = u.unmarshal(values[i], rv, false)
+		}
+		if err != nil {
+			return errors.WithStack(&ScanError{Index: i, Err: err})
+		}
+	}
+	return nil
+}
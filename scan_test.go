@@ -0,0 +1,51 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	var (
+		name    string
+		count   int
+		timeout time.Duration
+		tags    []string
+	)
+
+	t.Run("success", func(t *testing.T) {
+		err := Scan([]Value{"foo", "42", "5s", "a,b", "skipped"}, &name, &count, &timeout, &tags, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, "foo", name)
+		assert.Equal(t, 42, count)
+		assert.Equal(t, 5*time.Second, timeout)
+		assert.Equal(t, []string{"a", "b"}, tags)
+	})
+	t.Run("count mismatch", func(t *testing.T) {
+		assert.ErrorIs(t, Scan([]Value{"foo"}, &name, &count), ErrScanCount)
+	})
+	t.Run("invalid value", func(t *testing.T) {
+		err := Scan([]Value{"foo", "bar"}, &name, &count)
+		assert.ErrorIs(t, err, ErrParseFailure)
+
+		var scanErr *ScanError
+		assert.ErrorAs(t, err, &scanErr)
+		assert.Equal(t, 1, scanErr.Index)
+		assert.Contains(t, scanErr.Error(), "value #1: ")
+	})
+	t.Run("non-pointer target", func(t *testing.T) {
+		assert.ErrorIs(t, Scan([]Value{"foo"}, name), ErrPointerExpected)
+	})
+	t.Run("options", func(t *testing.T) {
+		var list []int
+		u := NewUnmarshaler(WithSeparators(";", ""))
+		assert.NoError(t, u.Scan([]Value{"1;2"}, &list))
+		assert.Equal(t, []int{1, 2}, list)
+	})
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Schedule)(nil)
+	_ encoding.TextUnmarshaler = (*Schedule)(nil)
+)
+
+// Schedule is a cron expression, e.g. "0 */2 * * MON-FRI". It contains either
+// five fields (minute, hour, day of month, month, day of week) or six fields,
+// where the first field contains the seconds. Descriptors like "@daily" and
+// "@every 1h30m" are also supported.
+// Unmarshaling a Schedule only validates the syntax of the expression, the raw
+// string is stored as is.
+type Schedule string
+
+// String returns Schedule as a raw string.
+func (s Schedule) String() string { return string(s) }
+
+// Fields returns the fields of the cron expression. It returns nil when
+// Schedule is a descriptor.
+func (s Schedule) Fields() []string {
+	if strings.HasPrefix(string(s), "@") {
+		return nil
+	}
+	return strings.Fields(string(s))
+}
+
+// HasSeconds indicates if Schedule's expression contains a seconds field.
+func (s Schedule) HasSeconds() bool { return len(s.Fields()) == 6 }
+
+// MarshalText returns Schedule as text.
+func (s Schedule) MarshalText() ([]byte, error) { return []byte(s), nil }
+
+// UnmarshalText validates text as a cron expression and sets it as Schedule.
+// An empty text results in the zero value.
+func (s *Schedule) UnmarshalText(text []byte) error {
+	str := strings.TrimSpace(string(text))
+	if str == "" {
+		*s = ""
+		return nil
+	}
+	if err := validateSchedule(str); err != nil {
+		return errors.Wrap(err, ErrParseFailure)
+	}
+
+	*s = Schedule(str)
+	return nil
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var (
+	cronSeconds = cronField{name: "seconds", max: 59}
+	cronFields  = [5]cronField{
+		{name: "minutes", max: 59},
+		{name: "hours", max: 23},
+		{name: "day of month", min: 1, max: 31},
+		{name: "month", min: 1, max: 12, names: []string{
+			"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN",
+			"JUL", "AUG", "SEP", "OCT", "NOV", "DEC",
+		}},
+		{name: "day of week", max: 7, names: []string{
+			"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT",
+		}},
+	}
+)
+
+var cronDescriptors = map[string]struct{}{
+	"@yearly":   {},
+	"@annually": {},
+	"@monthly":  {},
+	"@weekly":   {},
+	"@daily":    {},
+	"@midnight": {},
+	"@hourly":   {},
+}
+
+func validateSchedule(str string) error {
+	if strings.HasPrefix(str, "@") {
+		if d, ok := strings.CutPrefix(str, "@every "); ok {
+			if _, err := time.ParseDuration(strings.TrimSpace(d)); err != nil {
+				return errors.Newf("invalid @every duration `%s`", d)
+			}
+			return nil
+		}
+		if _, ok := cronDescriptors[str]; !ok {
+			return errors.Newf("unknown descriptor `%s`", str)
+		}
+		return nil
+	}
+
+	fields := strings.Fields(str)
+	switch len(fields) {
+	case 5:
+	case 6:
+		if err := cronSeconds.validate(fields[0]); err != nil {
+			return err
+		}
+		fields = fields[1:]
+	default:
+		return errors.Newf("expected 5 or 6 fields, got %d", len(fields))
+	}
+
+	for i, field := range fields {
+		if err := cronFields[i].validate(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cf cronField) validate(str string) error {
+	for _, part := range strings.Split(str, ",") {
+		if err := cf.validatePart(part); err != nil {
+			return errors.Newf("invalid %s field `%s`: %s", cf.name, str, err.Error())
+		}
+	}
+	return nil
+}
+
+func (cf cronField) validatePart(part string) error {
+	if rng, step, ok := strings.Cut(part, "/"); ok {
+		if i, err := strconv.Atoi(step); err != nil || i < 1 {
+			return errors.Newf("invalid step `%s`", step)
+		}
+		part = rng
+	}
+	if part == "*" || part == "?" {
+		return nil
+	}
+
+	from, to, isRange := strings.Cut(part, "-")
+	lo, err := cf.parseValue(from)
+	if err != nil {
+		return err
+	}
+	if !isRange {
+		return nil
+	}
+
+	hi, err := cf.parseValue(to)
+	if err != nil {
+		return err
+	}
+	if lo > hi {
+		return errors.Newf("invalid range `%s`", part)
+	}
+	return nil
+}
+
+func (cf cronField) parseValue(str string) (int, error) {
+	for i, name := range cf.names {
+		if name != "" && strings.EqualFold(str, name) {
+			return i, nil
+		}
+	}
+
+	i, err := strconv.Atoi(str)
+	if err != nil {
+		return 0, errors.Newf("invalid value `%s`", str)
+	}
+	if i < cf.min || i > cf.max {
+		return 0, errors.Newf("value `%s` out of range [%d-%d]", str, cf.min, cf.max)
+	}
+	return i, nil
+}
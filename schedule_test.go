@@ -0,0 +1,76 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedule(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		tests := []Value{
+			"* * * * *",
+			"0 */2 * * MON-FRI",
+			"30 9-17/2 1,15 * ?",
+			"0 0 1 jan,jul *",
+			"0 0 * * 0-7",
+			"*/10 * * * * *",
+			"@daily",
+			"@every 1h30m",
+		}
+		for _, input := range tests {
+			t.Run(input.String(), func(t *testing.T) {
+				var have Schedule
+				assert.NoError(t, Unmarshal(input, &have))
+				assert.Equal(t, Schedule(input), have)
+				assert.Equal(t, input, MustMarshal(have))
+			})
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []Value{
+			"* * * *",
+			"* * * * * * *",
+			"60 * * * *",
+			"* 24 * * *",
+			"* * 0 * *",
+			"* * * 13 *",
+			"* * * * 8",
+			"* * * * FOO",
+			"*/0 * * * *",
+			"5-1 * * * *",
+			"1,,2 * * * *",
+			"@sometimes",
+			"@every forever",
+		}
+		for _, input := range tests {
+			t.Run(input.String(), func(t *testing.T) {
+				var have Schedule
+				assert.ErrorIs(t, Unmarshal(input, &have), ErrParseFailure)
+				assert.Equal(t, Schedule(""), have)
+			})
+		}
+	})
+
+	t.Run("zero", func(t *testing.T) {
+		assert.Equal(t, Value(""), MustMarshal(Schedule("")))
+
+		have := Schedule("@daily")
+		assert.NoError(t, have.UnmarshalText(nil))
+		assert.Equal(t, Schedule(""), have)
+		assert.NoError(t, Unmarshal(MustMarshal(Schedule("")), &have, WithEmptyMode(EmptyZero)))
+		assert.Equal(t, Schedule(""), have)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		assert.Equal(t, []string{"0", "12", "*", "*", "*"}, Schedule("0 12 * * *").Fields())
+		assert.False(t, Schedule("0 12 * * *").HasSeconds())
+		assert.True(t, Schedule("0 0 12 * * *").HasSeconds())
+		assert.Nil(t, Schedule("@hourly").Fields())
+	})
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import "strings"
+
+// isShellSafe indicates if r can be used unquoted in a POSIX shell word.
+func isShellSafe(r rune) bool {
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return true
+	}
+	return strings.ContainsRune("@%+=:,./-_", r)
+}
+
+// shellQuote returns str quoted using single quotes, so it can be safely used
+// as a single word in a POSIX shell. A single quote within str is written as
+// '\''. Strings which consist solely of safe characters are returned as is. An
+// empty string results in ''.
+func shellQuote(str string) string {
+	if str == "" {
+		return "''"
+	}
+	if strings.IndexFunc(str, func(r rune) bool { return !isShellSafe(r) }) < 0 {
+		return str
+	}
+	return "'" + strings.ReplaceAll(str, "'", `'\''`) + "'"
+}
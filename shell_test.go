@@ -0,0 +1,44 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"":                    "''",
+		"simple":              "simple",
+		"/usr/local/bin:/bin": "/usr/local/bin:/bin",
+		"user@host":           "user@host",
+		"hello world":         "'hello world'",
+		"it's":                `'it'\''s'`,
+		"$HOME":               "'$HOME'",
+		"a;rm -rf /":          "'a;rm -rf /'",
+		"line\nbreak":         "'line\nbreak'",
+		"`cmd`":               "'`cmd`'",
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			assert.Equal(t, want, shellQuote(input))
+		})
+	}
+}
+
+func TestMarshaler_ShellQuote(t *testing.T) {
+	m := Marshaler{Options: Options{ShellQuote: true}}
+
+	have, err := m.Marshal(reflect.ValueOf([]string{"foo bar", "baz"}))
+	assert.NoError(t, err)
+	assert.Equal(t, Value("'foo bar,baz'"), have)
+
+	have, err = m.Marshal(reflect.ValueOf(8080))
+	assert.NoError(t, err)
+	assert.Equal(t, Value("8080"), have)
+}
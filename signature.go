@@ -0,0 +1,80 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const (
+	ErrMissingSignature errors.Msg = "missing signature"
+	ErrInvalidSignature errors.Msg = "invalid signature"
+	ErrMissingVerifier  errors.Msg = "missing verifier for signed field"
+)
+
+// DefaultSignatureSuffix is the suffix which is added to the key of a field
+// with the "signed" tag option, to look up its signature.
+const DefaultSignatureSuffix = "_SIG"
+
+// Verifier verifies the signature of a Value with key.
+type Verifier interface {
+	Verify(key string, v Value, signature Value) error
+}
+
+var _ Verifier = (HMACVerifier)(nil)
+
+// HMACVerifier is a Verifier which verifies HMAC-SHA256 signatures, which are
+// created with Sign, using itself as secret.
+type HMACVerifier []byte
+
+// Sign returns the hex encoded HMAC-SHA256 signature of key and Value v, using
+// the secret of HMACVerifier. The key is included so a Value and its
+// signature cannot be moved to another key.
+func (h HMACVerifier) Sign(key string, v Value) Value {
+	return Value(hex.EncodeToString(h.sum(key, v)))
+}
+
+// Verify returns an ErrInvalidSignature error when signature is not the
+// signature of key and Value v.
+func (h HMACVerifier) Verify(key string, v Value, signature Value) error {
+	sig, err := hex.DecodeString(signature.String())
+	if err != nil || !hmac.Equal(sig, h.sum(key, v)) {
+		return errors.New(ErrInvalidSignature)
+	}
+	return nil
+}
+
+func (h HMACVerifier) sum(key string, v Value) []byte {
+	mac := hmac.New(sha256.New, h)
+	mac.Write([]byte(key))
+	mac.Write([]byte{0})
+	mac.Write([]byte(v))
+	return mac.Sum(nil)
+}
+
+func (o Options) signatureSuffix() string {
+	if o.SignatureSuffix == "" {
+		return DefaultSignatureSuffix
+	}
+	return o.SignatureSuffix
+}
+
+// verify looks up the signature of Value v with key and verifies it using
+// Options.Verifier.
+func (u *Unmarshaler) verify(key string, v Value, lookup func(key string) (Value, Origin, bool)) error {
+	if u.Verifier == nil {
+		return errors.New(ErrMissingVerifier)
+	}
+
+	sig, _, ok := lookup(key + u.signatureSuffix())
+	if !ok {
+		return errors.New(ErrMissingSignature)
+	}
+	return u.Verifier.Verify(key, v, sig)
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMACVerifier(t *testing.T) {
+	h := HMACVerifier("secret")
+	sig := h.Sign("KEY", "value")
+
+	assert.Len(t, sig, 64)
+	assert.NoError(t, h.Verify("KEY", "value", sig))
+	assert.ErrorIs(t, h.Verify("KEY", "other", sig), ErrInvalidSignature)
+	assert.ErrorIs(t, h.Verify("OTHER", "value", sig), ErrInvalidSignature)
+	assert.ErrorIs(t, h.Verify("KEY", "value", "nothex"), ErrInvalidSignature)
+	assert.ErrorIs(t, HMACVerifier("other").Verify("KEY", "value", sig), ErrInvalidSignature)
+}
+
+func TestUnmarshaler_UnmarshalStruct_signed(t *testing.T) {
+	type config struct {
+		DSN  string `rawconv:"DSN,signed"`
+		Name string
+	}
+
+	h := HMACVerifier("secret")
+	dsn := Value("postgres://localhost/app")
+
+	t.Run("valid", func(t *testing.T) {
+		u := NewUnmarshaler(WithVerifier(h), WithUnknownKeys(RejectUnknownKeys))
+
+		var have config
+		_, err := u.UnmarshalStruct(Values{
+			"DSN":     dsn,
+			"DSN_SIG": h.Sign("DSN", dsn),
+			"Name":    "app",
+		}, &have)
+		assert.NoError(t, err)
+		assert.Equal(t, config{DSN: dsn.String(), Name: "app"}, have)
+	})
+	t.Run("suffix", func(t *testing.T) {
+		u := NewUnmarshaler(WithVerifier(h))
+		u.SignatureSuffix = ".sig"
+
+		var have config
+		_, err := u.UnmarshalStruct(Values{"DSN": dsn, "DSN.sig": h.Sign("DSN", dsn)}, &have)
+		assert.NoError(t, err)
+		assert.Equal(t, dsn.String(), have.DSN)
+	})
+	t.Run("unset", func(t *testing.T) {
+		var have config
+		_, err := NewUnmarshaler(WithVerifier(h)).UnmarshalStruct(Values{"Name": "app"}, &have)
+		assert.NoError(t, err)
+	})
+
+	tests := map[string]struct {
+		verifier Verifier
+		src      Values
+		wantErr  error
+	}{
+		"tampered": {
+			verifier: h,
+			src:      Values{"DSN": "postgres://evil/app", "DSN_SIG": h.Sign("DSN", dsn)},
+			wantErr:  ErrInvalidSignature,
+		},
+		"missing signature": {
+			verifier: h,
+			src:      Values{"DSN": dsn},
+			wantErr:  ErrMissingSignature,
+		},
+		"missing verifier": {
+			src:     Values{"DSN": dsn, "DSN_SIG": h.Sign("DSN", dsn)},
+			wantErr: ErrMissingVerifier,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var have config
+			_, err := NewUnmarshaler(WithVerifier(tc.verifier)).UnmarshalStruct(tc.src, &have)
+			assert.ErrorIs(t, err, tc.wantErr)
+
+			var fieldErr *FieldError
+			assert.ErrorAs(t, err, &fieldErr)
+			assert.Equal(t, "DSN", fieldErr.Key)
+			assert.Equal(t, config{}, have)
+		})
+	}
+}
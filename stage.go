@@ -0,0 +1,98 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import "reflect"
+
+// Stage is a step in the process of resolving how a type is handled by a
+// Marshaler or Unmarshaler. The stages are tried in order, the first stage
+// which is able to handle a type is used.
+type Stage uint8
+
+const (
+	// StageLocalFunc uses a func registered for the exact type, or the type
+	// it points to, with the Marshaler or Unmarshaler itself.
+	StageLocalFunc Stage = iota
+	// StageLocalInterface uses a func registered for an interface the type
+	// implements, with the Marshaler or Unmarshaler itself.
+	StageLocalInterface
+	// StageGlobalFunc uses a func registered for the exact type, or the type
+	// it points to, with RegisterMarshalFunc or RegisterUnmarshalFunc.
+	StageGlobalFunc
+	// StageGlobalInterface uses a func registered for an interface the type
+	// implements, with RegisterMarshalFunc or RegisterUnmarshalFunc. This
+	// includes the encoding.TextMarshaler and encoding.TextUnmarshaler
+	// fallback.
+	StageGlobalInterface
+	// StageKind handles the type based on its kind, e.g. a string, int or
+	// slice.
+	StageKind
+)
+
+// DefaultStages returns the stages, in order, which are used when
+// Options.Stages is nil.
+func DefaultStages() []Stage {
+	return []Stage{
+		StageLocalFunc,
+		StageLocalInterface,
+		StageGlobalFunc,
+		StageGlobalInterface,
+		StageKind,
+	}
+}
+
+func (o Options) stages() []Stage {
+	if o.Stages == nil {
+		return DefaultStages()
+	}
+	return o.Stages
+}
+
+// funcMatch describes how a type is resolved by resolve.
+type funcMatch struct {
+	// registered is the type the func is registered with.
+	registered reflect.Type
+	// global indicates the func is found in the global register.
+	global bool
+	// kind indicates the type is handled by its kind.
+	kind bool
+}
+
+// resolve returns the func for typ from local or global, according to stages.
+// When no func is returned, funcMatch.kind indicates the type should be handled
+// by its kind. Otherwise, the type is not supported.
+func resolve[T interface{ MarshalFunc | UnmarshalFunc }](
+	stages []Stage,
+	local, global *register[T],
+	typ reflect.Type,
+) (T, funcMatch) {
+	for _, stage := range stages {
+		var fn T
+		var x reflect.Type
+		switch stage {
+		case StageLocalFunc:
+			if local.initialized() {
+				fn, x = local.lookupType(typ)
+			}
+		case StageLocalInterface:
+			if local.initialized() {
+				fn, x = local.lookupImpl(typ)
+			}
+		case StageGlobalFunc:
+			fn, x = global.lookupType(typ)
+		case StageGlobalInterface:
+			fn, x = global.lookupImpl(typ)
+		case StageKind:
+			return nil, funcMatch{kind: true}
+		}
+		if fn != nil {
+			return fn, funcMatch{
+				registered: x,
+				global:     stage == StageGlobalFunc || stage == StageGlobalInterface,
+			}
+		}
+	}
+	return nil, funcMatch{}
+}
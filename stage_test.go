@@ -0,0 +1,94 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"reflect"
+	"testing"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type stageText string
+
+func (s stageText) MarshalText() ([]byte, error) { return []byte("text " + s), nil }
+
+func (s *stageText) UnmarshalText(b []byte) error {
+	*s = stageText(b)
+	return nil
+}
+
+func TestStages(t *testing.T) {
+	val := stageText("foo")
+	valType := reflect.TypeOf(val)
+	textMarshaler := reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	newMarshaler := func(stages ...Stage) *Marshaler {
+		var m Marshaler
+		m.Stages = stages
+		m.Register(valType, func(any) (string, error) { return "local", nil })
+		m.Register(textMarshaler, func(any) (string, error) { return "local interface", nil })
+		return &m
+	}
+
+	tests := map[string]struct {
+		stages  []Stage
+		want    Value
+		wantErr bool
+	}{
+		"default": {
+			stages: nil,
+			want:   "local",
+		},
+		"local interface first": {
+			stages: []Stage{StageLocalInterface, StageLocalFunc},
+			want:   "local interface",
+		},
+		"global only": {
+			stages: []Stage{StageGlobalFunc, StageGlobalInterface},
+			want:   "text foo",
+		},
+		"kind first": {
+			stages: []Stage{StageKind, StageLocalFunc},
+			want:   "foo",
+		},
+		"disabled": {
+			stages:  []Stage{StageGlobalFunc},
+			wantErr: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, haveErr := newMarshaler(tc.stages...).Marshal(reflect.ValueOf(val))
+			if tc.wantErr {
+				var wantErr *UnsupportedTypeError
+				assert.True(t, errors.As(haveErr, &wantErr))
+				return
+			}
+			assert.NoError(t, haveErr)
+			assert.Equal(t, tc.want, have)
+		})
+	}
+
+	t.Run("unmarshal without kind", func(t *testing.T) {
+		var u Unmarshaler
+		u.Stages = []Stage{StageGlobalFunc, StageGlobalInterface}
+
+		var have stageText
+		assert.NoError(t, u.Unmarshal("foo", reflect.ValueOf(&have)))
+		assert.Equal(t, val, have)
+
+		var i int
+		haveErr := u.Unmarshal("1", reflect.ValueOf(&i))
+		assert.ErrorIs(t, haveErr, &UnsupportedTypeError{Type: reflect.TypeOf(&i)})
+	})
+
+	t.Run("explain", func(t *testing.T) {
+		m := newMarshaler(StageGlobalFunc)
+		assert.Equal(t, Explanation{Type: valType}, m.Explain(valType))
+	})
+}
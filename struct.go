@@ -0,0 +1,404 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const (
+	ErrStructExpected errors.Msg = "expected a non-nil pointer to a struct"
+	ErrMissingValue   errors.Msg = "missing value for required field"
+	ErrUnknownKey     errors.Msg = "unknown key"
+)
+
+// UnknownKeyPolicy determines how UnmarshalStruct handles keys of a Source
+// which have no matching struct field.
+type UnknownKeyPolicy uint8
+
+const (
+	// IgnoreUnknownKeys ignores unknown keys.
+	IgnoreUnknownKeys UnknownKeyPolicy = iota
+	// WarnUnknownKeys reports each unknown key as a Warning with kind
+	// WarnUnknownKey to Options.Warner.
+	WarnUnknownKeys
+	// RejectUnknownKeys returns an *UnknownKeyError when there are unknown
+	// keys, before any field is set.
+	RejectUnknownKeys
+)
+
+// UnknownKeyError is returned by UnmarshalStruct when Options.UnknownKeys is
+// set to RejectUnknownKeys and the Source contains keys without a matching
+// struct field.
+type UnknownKeyError struct {
+	// Keys are the unknown keys, in sorted order.
+	Keys []string
+}
+
+func (e *UnknownKeyError) Unwrap() error { return ErrUnknownKey }
+
+func (e *UnknownKeyError) Error() string {
+	return ErrUnknownKey.String() + "(s) `" + strings.Join(e.Keys, "`, `") + "`"
+}
+
+// FieldError is returned when the Value of a struct field cannot be
+// unmarshaled.
+type FieldError struct {
+	// Field is the path of the field within the struct, e.g. "Db.Host".
+	Field string
+	// Key is the key of the Value within the Source.
+	Key string
+	// Origin is the Origin of the Value, when the Source is an OriginSource.
+	Origin Origin
+	Err    error
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+func (e *FieldError) Error() string {
+	str := "field `" + e.Field + "` (key `" + e.Key + "`"
+	if origin := e.Origin.String(); origin != "" {
+		str += " from `" + origin + "`"
+	}
+	return str + "): " + e.Err.Error()
+}
+
+// UnmarshalStruct unmarshals the Value(s) from Source src into the fields of
+// the struct pointed to by v, using the global Unmarshaler. See
+// Unmarshaler.UnmarshalStruct for additional details.
+func UnmarshalStruct(src Source, v any) ([]string, error) {
+	return unmarshaler.UnmarshalStruct(src, v)
+}
+
+// UnmarshalStruct unmarshals the Value(s) from Source src into the fields of
+// the struct pointed to by v. It returns the paths of the fields which are set
+// from src, fields without a matching key are left untouched. An
+// ErrMissingValue error is returned for fields with the "required" tag option,
+// e.g. `rawconv:"key,required"`, which have no matching key in src.
+// Tag options, such as `rawconv:"mask,base=16"` or `rawconv:"list,sep=;"`,
+// override the Options of the Unmarshaler for a single field. See
+// ErrInvalidTagOption for unsupported option values.
+//
+// A *FieldError is returned when a Value cannot be unmarshaled. When src is an
+// OriginSource, such as Layered, the error includes the Origin of the Value.
+//
+// Alternative keys of a field are set with the "alias" tag option, e.g.
+// `rawconv:"timeout,alias=TIMEOUT_SECS"`. The key itself takes precedence
+// over its aliases. A Value which is set using an alias, or using the key of a
+// field with the "deprecated" tag option, results in a Warning of kind
+// WarnDeprecatedKey.
+//
+// The Value of a field with the "signed" tag option, e.g.
+// `rawconv:"dsn,signed"`, is verified using Options.Verifier before it is
+// unmarshaled. Its signature is looked up with the same key followed by
+// Options.SignatureSuffix, e.g. "dsn_SIG". An ErrMissingSignature or
+// ErrInvalidSignature error is returned when the signature is missing or
+// invalid.
+//
+// When Options.FoldKeys is enabled, keys are matched case-insensitively and
+// without differences between '-' and '_', e.g. "db-host" matches "DB_HOST".
+//
+// Keys of src without a matching field are handled according to
+// Options.UnknownKeys. A top level field with the "rest" tag option, e.g.
+// `rawconv:",rest"`, of type Values collects these keys and their Value(s)
+// instead.
+//
+// The key of a field defaults to its name and can be changed with the
+// `rawconv:"key"` struct tag. Fields with tag `rawconv:"-"` and unexported
+// fields are ignored. Fields of embedded structs are handled as if they are
+// fields of the outer struct. Nested struct fields, of types which are not
+// supported by an UnmarshalFunc, are traversed and their keys are prefixed
+// with the key of the parent field, e.g. "Db.Host".
+func (u *Unmarshaler) UnmarshalStruct(src Source, v any) ([]string, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, errors.New(ErrStructExpected)
+	}
+
+	rv = rv.Elem()
+	fields := structFields(rv.Type(), func(typ reflect.Type) bool {
+		return u.Func(typ) != nil
+	})
+
+	lookup := u.lookupFunc(src)
+	if err := u.unknownKeys(src, rv, fields, lookup); err != nil {
+		return nil, err
+	}
+
+	set := make([]string, 0, len(fields))
+	for _, field := range fields {
+		key, val, origin, ok := field.lookup(lookup)
+		if ok && u.Warner != nil && (key != field.key || field.tag.has("deprecated")) {
+			u.Warner.Warn(Warning{
+				Kind:   WarnDeprecatedKey,
+				Field:  field.path,
+				Key:    key,
+				Origin: origin,
+			})
+		}
+		if !ok {
+			if field.tag.has("required") {
+				return set, errors.WithStack(&FieldError{
+					Field: field.path,
+					Key:   field.key,
+					Err:   errors.New(ErrMissingValue),
+				})
+			}
+			continue
+		}
+		fu, err := u.forField(field.tag)
+		if err == nil && field.tag.has("signed") {
+			err = u.verify(key, val, lookup)
+		}
+		if err == nil && fu.Warner != nil {
+			fu = &Unmarshaler{Options: fu.Options, register: fu.register}
+			fu.Warner = fieldWarner{
+				Warner: fu.Warner,
+				field:  field.path,
+				key:    key,
+				origin: origin,
+			}
+		}
+		if err == nil {
+			err = fu.unmarshal(val, rv.FieldByIndex(field.index), false)
+		}
+		if err != nil {
+			return set, errors.WithStack(&FieldError{
+				Field:  field.path,
+				Key:    key,
+				Origin: origin,
+				Err:    err,
+			})
+		}
+		set = append(set, field.path)
+	}
+	return set, nil
+}
+
+// lookupFunc returns a func which looks up the Value and Origin of a key
+// within src. It uses the Origin of an OriginSource and honours
+// Options.FoldKeys.
+func (u *Unmarshaler) lookupFunc(src Source) func(key string) (Value, Origin, bool) {
+	lookup := func(key string) (Value, Origin, bool) {
+		val, ok := src.Lookup(key)
+		return val, Origin{}, ok
+	}
+	if os, ok := src.(OriginSource); ok {
+		lookup = os.LookupOrigin
+	}
+	if u.FoldKeys {
+		lookup = foldLookup(src, lookup)
+	}
+	return lookup
+}
+
+// lookup looks up the Value of the field using lookup, first by its key and
+// then by its aliases. It returns the key which is matched.
+func (f structField) lookup(lookup func(key string) (Value, Origin, bool)) (string, Value, Origin, bool) {
+	if val, origin, ok := lookup(f.key); ok {
+		return f.key, val, origin, true
+	}
+	for _, alias := range f.aliases {
+		if val, origin, ok := lookup(alias); ok {
+			return alias, val, origin, true
+		}
+	}
+	return "", "", Origin{}, false
+}
+
+// unknownKeys handles the keys of src which do not match any of fields,
+// according to the rest field of rv or Options.UnknownKeys.
+func (u *Unmarshaler) unknownKeys(
+	src Source,
+	rv reflect.Value,
+	fields []structField,
+	lookup func(key string) (Value, Origin, bool),
+) error {
+	rest, err := restField(rv)
+	if err != nil {
+		return err
+	}
+	if !rest.IsValid() && u.UnknownKeys == IgnoreUnknownKeys {
+		return nil
+	}
+
+	norm := func(key string) string { return key }
+	if u.FoldKeys {
+		norm = foldKey
+	}
+
+	known := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		known[norm(field.key)] = struct{}{}
+		for _, alias := range field.aliases {
+			known[norm(alias)] = struct{}{}
+		}
+		if field.tag.has("signed") {
+			// signatures of signed fields are not unknown
+			suffix := u.signatureSuffix()
+			known[norm(field.key+suffix)] = struct{}{}
+			for _, alias := range field.aliases {
+				known[norm(alias+suffix)] = struct{}{}
+			}
+		}
+	}
+
+	var unknown []string
+	for _, key := range src.Keys() {
+		if _, ok := known[norm(key)]; !ok {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	switch {
+	case rest.IsValid():
+		vs := make(Values, len(unknown))
+		for _, key := range unknown {
+			vs[key], _, _ = lookup(key)
+		}
+		rest.Set(reflect.ValueOf(vs).Convert(rest.Type()))
+
+	case u.UnknownKeys == RejectUnknownKeys:
+		return errors.WithStack(&UnknownKeyError{Keys: unknown})
+
+	case u.UnknownKeys == WarnUnknownKeys && u.Warner != nil:
+		for _, key := range unknown {
+			_, origin, _ := lookup(key)
+			u.Warner.Warn(Warning{Kind: WarnUnknownKey, Key: key, Origin: origin})
+		}
+	}
+	return nil
+}
+
+// foldKey returns key in lower case, with all '-' replaced by '_'.
+func foldKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "-", "_")
+}
+
+// foldLookup returns a lookup func which first looks up the exact key, and
+// otherwise the first key of src, in sorted order, which matches key when both
+// are folded using foldKey.
+func foldLookup(src Source, lookup func(key string) (Value, Origin, bool)) func(key string) (Value, Origin, bool) {
+	keys := src.Keys()
+	index := make(map[string]string, len(keys))
+	for _, key := range keys {
+		folded := foldKey(key)
+		if _, ok := index[folded]; !ok {
+			index[folded] = key
+		}
+	}
+
+	return func(key string) (Value, Origin, bool) {
+		if val, origin, ok := lookup(key); ok {
+			return val, origin, ok
+		}
+		if k, ok := index[foldKey(key)]; ok {
+			return lookup(k)
+		}
+		return "", Origin{}, false
+	}
+}
+
+var valuesType = reflect.TypeOf(Values{})
+
+// restField returns the top level field of struct rv which has the "rest" tag
+// option, if any.
+func restField(rv reflect.Value) (reflect.Value, error) {
+	typ := rv.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !parseTag(sf.Tag).has("rest") {
+			continue
+		}
+		if !sf.IsExported() || !valuesType.ConvertibleTo(sf.Type) {
+			return reflect.Value{}, errors.WithStack(&FieldError{
+				Field: sf.Name,
+				Err:   errors.Newf("%w `rest`, field must be of type Values", ErrInvalidTagOption),
+			})
+		}
+		return rv.Field(i), nil
+	}
+	return reflect.Value{}, nil
+}
+
+// StructKeySeparator separates the keys of nested struct fields.
+const StructKeySeparator = "."
+
+type structField struct {
+	sf      reflect.StructField
+	path    string
+	key     string
+	aliases []string
+	tag     tag
+	index   []int
+	typ     reflect.Type
+}
+
+// structFields returns all fields of struct type typ which can be handled as a
+// single Value. Nested structs for which isValue returns false are traversed.
+func structFields(typ reflect.Type, isValue func(reflect.Type) bool) []structField {
+	return appendStructFields(nil, typ, isValue, "", "", nil)
+}
+
+func appendStructFields(
+	res []structField,
+	typ reflect.Type,
+	isValue func(reflect.Type) bool,
+	pathPrefix, keyPrefix string,
+	index []int,
+) []structField {
+	for i := 0; i < typ.NumField(); i++ {
+		sf := typ.Field(i)
+		if !sf.IsExported() && !(sf.Anonymous && sf.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		t := parseTag(sf.Tag)
+		if t.ignore || t.has("rest") {
+			continue
+		}
+
+		field := structField{
+			sf:    sf,
+			path:  pathPrefix + sf.Name,
+			key:   t.key,
+			tag:   t,
+			index: append(append(make([]int, 0, len(index)+1), index...), i),
+			typ:   sf.Type,
+		}
+		if field.key == "" {
+			field.key = sf.Name
+		}
+
+		if sf.Type.Kind() == reflect.Struct && !isValue(sf.Type) {
+			if sf.Anonymous && t.key == "" {
+				res = appendStructFields(res, sf.Type, isValue, pathPrefix, keyPrefix, field.index)
+			} else {
+				res = appendStructFields(res, sf.Type, isValue,
+					field.path+StructKeySeparator,
+					keyPrefix+field.key+StructKeySeparator,
+					field.index,
+				)
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		field.key = keyPrefix + field.key
+		for _, alias := range t.values("alias") {
+			field.aliases = append(field.aliases, keyPrefix+alias)
+		}
+		res = append(res, field)
+	}
+	return res
+}
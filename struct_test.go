@@ -0,0 +1,268 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type embeddedConfig struct {
+	Debug bool
+}
+
+type testConfig struct {
+	embeddedConfig
+	Name    string
+	Timeout time.Duration `rawconv:"timeout"`
+	Url     url.URL
+	Ignored string `rawconv:"-"`
+	Db      struct {
+		Host string `rawconv:"host"`
+		Port int    `rawconv:"port"`
+	} `rawconv:"db"`
+
+	unexported string
+}
+
+func TestUnmarshalStruct(t *testing.T) {
+	t.Run("not a struct pointer", func(t *testing.T) {
+		tests := map[string]any{
+			"nil":         nil,
+			"struct":      testConfig{},
+			"nil pointer": (*testConfig)(nil),
+			"int pointer": ptr(1),
+		}
+		for name, val := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := UnmarshalStruct(Values{}, val)
+				assert.ErrorIs(t, err, ErrStructExpected)
+			})
+		}
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		have := testConfig{Name: "default"}
+		haveSet, haveErr := UnmarshalStruct(Values{
+			"Debug":      "true",
+			"timeout":    "10s",
+			"Url":        "http://localhost/",
+			"Ignored":    "some value",
+			"db.host":    "localhost",
+			"db.port":    "5432",
+			"unexported": "some value",
+		}, &have)
+
+		want := testConfig{Name: "default", Timeout: time.Second * 10}
+		want.Debug = true
+		want.Url = url.URL{Scheme: "http", Host: "localhost", Path: "/"}
+		want.Db.Host = "localhost"
+		want.Db.Port = 5432
+
+		assert.NoError(t, haveErr)
+		assert.Equal(t, want, have)
+		assert.Equal(t, []string{"Debug", "Timeout", "Url", "Db.Host", "Db.Port"}, haveSet)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		var have testConfig
+		haveSet, haveErr := UnmarshalStruct(Values{
+			"timeout": "10s",
+			"db.port": "not a number",
+		}, &have)
+
+		var fieldErr *FieldError
+		assert.ErrorAs(t, haveErr, &fieldErr)
+		assert.ErrorIs(t, haveErr, ErrParseFailure)
+		assert.Equal(t, "Db.Port", fieldErr.Field)
+		assert.Equal(t, "db.port", fieldErr.Key)
+		assert.Equal(t, []string{"Timeout"}, haveSet)
+	})
+	t.Run("error origin", func(t *testing.T) {
+		var l Layered
+		l.Add("defaults", Values{"db.port": "5432"})
+		l.Add("file", AnnotatedValues{
+			"db.port": {Value: "not a number", Origin: Origin{Source: "config.env", Line: 3}},
+		})
+
+		var have testConfig
+		_, haveErr := UnmarshalStruct(&l, &have)
+
+		var fieldErr *FieldError
+		assert.ErrorAs(t, haveErr, &fieldErr)
+		assert.Equal(t, Origin{Source: "config.env", Line: 3}, fieldErr.Origin)
+		assert.Contains(t, haveErr.Error(), "field `Db.Port` (key `db.port` from `config.env:3`)")
+	})
+	t.Run("required", func(t *testing.T) {
+		var have struct {
+			Name string `rawconv:"name,required"`
+		}
+		_, haveErr := UnmarshalStruct(Values{}, &have)
+		assert.ErrorIs(t, haveErr, ErrMissingValue)
+
+		_, haveErr = UnmarshalStruct(Values{"name": "foo"}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, "foo", have.Name)
+	})
+	t.Run("tag options", func(t *testing.T) {
+		var have struct {
+			Mask  uint16   `rawconv:"mask,base=16"`
+			List  []string `rawconv:"list,sep=;"`
+			Empty int      `rawconv:"empty,empty=error"`
+			JSON  []int    `rawconv:"json,json"`
+		}
+		_, haveErr := UnmarshalStruct(Values{"mask": "ff", "list": "a,b;c", "json": "[1,2]"}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, uint16(0xff), have.Mask)
+		assert.Equal(t, []string{"a,b", "c"}, have.List)
+		assert.Equal(t, []int{1, 2}, have.JSON)
+
+		_, haveErr = UnmarshalStruct(Values{"empty": ""}, &have)
+		assert.ErrorIs(t, haveErr, ErrEmptyValue)
+
+		var invalid struct {
+			Mask int `rawconv:"mask,base=x"`
+		}
+		_, haveErr = UnmarshalStruct(Values{"mask": "1"}, &invalid)
+		assert.ErrorIs(t, haveErr, ErrInvalidTagOption)
+
+		var unknown struct {
+			Mask int `rawconv:"mask,bsae=16"`
+		}
+		_, haveErr = UnmarshalStruct(Values{"mask": "1"}, &unknown)
+		assert.ErrorIs(t, haveErr, ErrInvalidTagOption)
+
+		var invalidFormat struct {
+			Timeout time.Duration `rawconv:"timeout,format=hours"`
+		}
+		_, haveErr = UnmarshalStruct(Values{"timeout": "1"}, &invalidFormat)
+		assert.ErrorIs(t, haveErr, ErrInvalidTagOption)
+	})
+	t.Run("quoted tag option", func(t *testing.T) {
+		var have struct {
+			List  []string       `rawconv:"list,sep=','"`
+			Pairs map[string]int `rawconv:"pairs, sep = ';' ,kvsep=','"`
+		}
+		_, haveErr := UnmarshalStruct(Values{"list": "a,b", "pairs": "a,1;b,2"}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, []string{"a", "b"}, have.List)
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, have.Pairs)
+	})
+	t.Run("format tag option", func(t *testing.T) {
+		var have struct {
+			Timeout time.Duration `rawconv:"timeout,format=seconds"`
+			Delay   time.Duration `rawconv:"delay,format=milliseconds"`
+		}
+		_, haveErr := UnmarshalStruct(Values{"timeout": "90", "delay": "1.5"}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, 90*time.Second, have.Timeout)
+		assert.Equal(t, 1500*time.Microsecond, have.Delay)
+	})
+	t.Run("unknown keys", func(t *testing.T) {
+		src := AnnotatedValues{
+			"Name":    {Value: "foo"},
+			"nmae":    {Value: "typo", Origin: Origin{Source: "env"}},
+			"db.hots": {Value: "localhost"},
+		}
+
+		var have testConfig
+		_, haveErr := UnmarshalStruct(src, &have)
+		assert.NoError(t, haveErr)
+
+		_, haveErr = NewUnmarshaler(WithUnknownKeys(RejectUnknownKeys)).UnmarshalStruct(src, &have)
+		assert.ErrorIs(t, haveErr, ErrUnknownKey)
+		var keyErr *UnknownKeyError
+		assert.ErrorAs(t, haveErr, &keyErr)
+		assert.Equal(t, []string{"db.hots", "nmae"}, keyErr.Keys)
+		assert.Equal(t, "unknown key(s) `db.hots`, `nmae`", keyErr.Error())
+
+		var ws Warnings
+		_, haveErr = NewUnmarshaler(WithUnknownKeys(WarnUnknownKeys), WithWarner(&ws)).UnmarshalStruct(src, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, Warnings{
+			{Kind: WarnUnknownKey, Key: "db.hots"},
+			{Kind: WarnUnknownKey, Key: "nmae", Origin: Origin{Source: "env"}},
+		}, ws)
+	})
+	t.Run("rest", func(t *testing.T) {
+		var have struct {
+			Name string `rawconv:"name"`
+			Rest Values `rawconv:",rest"`
+		}
+		_, haveErr := NewUnmarshaler(WithUnknownKeys(RejectUnknownKeys)).UnmarshalStruct(Values{
+			"name":  "foo",
+			"other": "bar",
+		}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, "foo", have.Name)
+		assert.Equal(t, Values{"other": "bar"}, have.Rest)
+
+		var invalid struct {
+			Rest map[string]int `rawconv:",rest"`
+		}
+		_, haveErr = UnmarshalStruct(Values{}, &invalid)
+		assert.ErrorIs(t, haveErr, ErrInvalidTagOption)
+	})
+	t.Run("alias", func(t *testing.T) {
+		type config struct {
+			Timeout time.Duration `rawconv:"timeout,alias=TIMEOUT,alias=timeout_secs"`
+			Port    int           `rawconv:"port,deprecated"`
+		}
+
+		var have config
+		var ws Warnings
+		u := NewUnmarshaler(WithUnknownKeys(RejectUnknownKeys), WithWarner(&ws))
+		haveSet, haveErr := u.UnmarshalStruct(Values{"timeout_secs": "5s", "port": "80"}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, []string{"Timeout", "Port"}, haveSet)
+		assert.Equal(t, config{Timeout: 5 * time.Second, Port: 80}, have)
+		assert.Equal(t, Warnings{
+			{Kind: WarnDeprecatedKey, Field: "Timeout", Key: "timeout_secs"},
+			{Kind: WarnDeprecatedKey, Field: "Port", Key: "port"},
+		}, ws)
+
+		ws = ws[:0]
+		_, haveErr = u.UnmarshalStruct(Values{"timeout": "1s", "TIMEOUT": "2s"}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, time.Second, have.Timeout)
+		assert.Empty(t, ws)
+	})
+	t.Run("fold keys", func(t *testing.T) {
+		src := Values{
+			"DB-HOST": "localhost",
+			"db_port": "5432",
+			"timeout": "1s",
+			"TIMEOUT": "2s",
+		}
+
+		var have testConfig
+		haveSet, haveErr := NewUnmarshaler(
+			WithFoldKeys(),
+			WithUnknownKeys(RejectUnknownKeys),
+		).UnmarshalStruct(Values{
+			"db.HOST": "localhost",
+			"DB.Port": "5432",
+			"timeout": "1s",
+			"TIMEOUT": "2s",
+		}, &have)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, []string{"Timeout", "Db.Host", "Db.Port"}, haveSet)
+		assert.Equal(t, time.Second, have.Timeout)
+		assert.Equal(t, "localhost", have.Db.Host)
+		assert.Equal(t, 5432, have.Db.Port)
+
+		var flat struct {
+			DbHost string `rawconv:"db_host"`
+			DbPort int    `rawconv:"db-port"`
+		}
+		_, haveErr = NewUnmarshaler(WithFoldKeys()).UnmarshalStruct(src, &flat)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, "localhost", flat.DbHost)
+		assert.Equal(t, 5432, flat.DbPort)
+	})
+}
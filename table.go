@@ -0,0 +1,131 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"bytes"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// secretKeyParts are the (lowercase) parts of a key which indicate its Value
+// is a secret.
+var secretKeyParts = []string{"password", "passwd", "secret", "token", "apikey", "api_key", "private"}
+
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, part := range secretKeyParts {
+		if strings.Contains(key, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalIndentedTable renders v as a table using the global Marshaler. See
+// Marshaler.MarshalIndentedTable for additional details.
+func MarshalIndentedTable(v any) ([]byte, error) {
+	return marshaler.MarshalIndentedTable(v)
+}
+
+// MarshalIndentedTable renders v as an aligned, human-readable table with the
+// columns KEY, VALUE, SOURCE and DEFAULT, e.g. for `--print-config` output.
+// Argument v may be a Source or a struct (or pointer to a struct) as accepted
+// by DescribeStruct. The SOURCE and DEFAULT columns are filled when v is an
+// OriginSource, such as Layered or AnnotatedValues. For a struct, the VALUE
+// column contains the marshaled value of each field, and the DEFAULT column
+// marks fields whose marshaled value equals that of their zero value.
+//
+// Values of struct fields with the "secret" tag option and of keys containing
+// e.g. "password", "secret" or "token" are replaced with Redacted. Struct
+// fields of types which implement Redacter, such as DSN, are displayed in their
+// redacted form. Values containing control characters are quoted.
+func (m *Marshaler) MarshalIndentedTable(v any) ([]byte, error) {
+	type row struct {
+		key, value string
+		origin     Origin
+	}
+
+	var rows []row
+	if src, ok := v.(Source); ok {
+		osrc, _ := src.(OriginSource)
+		for _, key := range src.Keys() {
+			r := row{key: key}
+			var val Value
+			if osrc != nil {
+				val, r.origin, _ = osrc.LookupOrigin(key)
+			} else {
+				val, _ = src.Lookup(key)
+			}
+
+			r.value = tableValue(val, isSecretKey(key))
+			rows = append(rows, r)
+		}
+	} else {
+		fields, desc, err := m.describeStruct(v)
+		if err != nil {
+			return nil, err
+		}
+
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		for i, d := range desc {
+			r := row{key: d.Key, origin: Origin{Default: true}}
+			if rv.IsValid() {
+				fv := rv.FieldByIndex(fields[i].index)
+				val, err := m.describeValue(fields[i], fv)
+				if err != nil {
+					return nil, err
+				}
+
+				zero, err := m.describeValue(fields[i], reflect.Zero(fv.Type()))
+				r.value = tableValue(val, false)
+				r.origin.Default = err == nil && val == zero
+			}
+			rows = append(rows, r)
+		}
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	_, _ = tw.Write([]byte("KEY\tVALUE\tSOURCE\tDEFAULT\n"))
+	for _, r := range rows {
+		def := ""
+		if r.origin.Default {
+			def = "yes"
+		}
+
+		src := Origin{Source: r.origin.Source, Line: r.origin.Line}.String()
+		_, _ = tw.Write([]byte(r.key + "\t" + r.value + "\t" + src + "\t" + def + "\n"))
+	}
+	if err := tw.Flush(); err != nil {
+		return nil, err
+	}
+
+	// remove padding of empty trailing columns
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	res := make([]byte, 0, buf.Len())
+	for _, line := range lines {
+		res = append(res, bytes.TrimRight(line, " ")...)
+		res = append(res, '\n')
+	}
+	return res, nil
+}
+
+func tableValue(val Value, secret bool) string {
+	if secret && !val.IsEmpty() {
+		return Redacted
+	}
+
+	str := val.String()
+	if strings.IndexFunc(str, func(r rune) bool { return r < ' ' || r == 0x7f }) >= 0 {
+		return strconv.Quote(str)
+	}
+	return str
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalIndentedTable(t *testing.T) {
+	t.Run("layered", func(t *testing.T) {
+		src := NewLayered(
+			Layer{Name: "defaults", Source: Values{"host": "localhost", "port": "8080"}, Default: true},
+			Layer{Name: "env", Source: Values{
+				"port":        "9090",
+				"db_password": "hunter2",
+				"banner":      "hello\nworld",
+			}},
+		)
+
+		have, haveErr := MarshalIndentedTable(src)
+		assert.NoError(t, haveErr)
+		assert.Equal(t, ""+
+			"KEY          VALUE           SOURCE    DEFAULT\n"+
+			"banner       \"hello\\nworld\"  env\n"+
+			"db_password  xxxxx           env\n"+
+			"host         localhost       defaults  yes\n"+
+			"port         9090            env\n",
+			string(have),
+		)
+	})
+	t.Run("values", func(t *testing.T) {
+		have, haveErr := MarshalIndentedTable(Values{"token": "", "name": "app"})
+		assert.NoError(t, haveErr)
+		assert.Equal(t, ""+
+			"KEY    VALUE  SOURCE  DEFAULT\n"+
+			"name   app\n"+
+			"token\n",
+			string(have),
+		)
+	})
+	t.Run("struct", func(t *testing.T) {
+		type config struct {
+			Timeout time.Duration `rawconv:"timeout"`
+			DSN     string        `rawconv:"dsn,secret"`
+			Name    string        `rawconv:"name"`
+			Retries int           `rawconv:"retries"`
+		}
+
+		have, haveErr := MarshalIndentedTable(config{Timeout: time.Second, DSN: "postgres://"})
+		assert.NoError(t, haveErr)
+		assert.Equal(t, ""+
+			"KEY      VALUE  SOURCE  DEFAULT\n"+
+			"timeout  1s\n"+
+			"dsn      xxxxx\n"+
+			"name                    yes\n"+
+			"retries  0              yes\n",
+			string(have),
+		)
+	})
+	t.Run("nil struct", func(t *testing.T) {
+		type config struct {
+			Name string `rawconv:"name"`
+		}
+
+		have, haveErr := MarshalIndentedTable((*config)(nil))
+		assert.NoError(t, haveErr)
+		assert.Equal(t, ""+
+			"KEY   VALUE  SOURCE  DEFAULT\n"+
+			"name                 yes\n",
+			string(have),
+		)
+	})
+	t.Run("redacter", func(t *testing.T) {
+		dsn, err := ParseDSN("postgres://app:hunter2@db:5432/app")
+		assert.NoError(t, err)
+
+		have, haveErr := MarshalIndentedTable(struct {
+			DatabaseURL DSN `rawconv:"DATABASE_URL"`
+		}{DatabaseURL: dsn})
+		assert.NoError(t, haveErr)
+		assert.NotContains(t, string(have), "hunter2")
+		assert.Contains(t, string(have), "postgres://app:xxxxx@db:5432/app")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		_, haveErr := MarshalIndentedTable(42)
+		assert.ErrorIs(t, haveErr, ErrStructExpected)
+	})
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const ErrInvalidTagOption errors.Msg = "invalid tag option"
+
+// TagName is the name of the struct tag which is used to configure how
+// struct fields are handled, e.g. `rawconv:"key,option"`. Use "-" as key to
+// ignore a field.
+const TagName = "rawconv"
+
+type tag struct {
+	key     string
+	ignore  bool
+	options []string
+}
+
+func parseTag(st reflect.StructTag) tag {
+	str, ok := st.Lookup(TagName)
+	if !ok {
+		return tag{}
+	}
+	if str == "-" {
+		return tag{ignore: true}
+	}
+
+	parts := splitTag(str)
+	t := tag{key: strings.TrimSpace(parts[0])}
+	for _, opt := range parts[1:] {
+		if opt = strings.TrimSpace(opt); opt != "" {
+			t.options = append(t.options, opt)
+		}
+	}
+	return t
+}
+
+// splitTag splits str on each comma, except for commas within single quotes,
+// e.g. "list,sep=','" results in "list" and "sep=','".
+func splitTag(str string) []string {
+	var parts []string
+	var quoted bool
+	var start int
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case '\'':
+			quoted = !quoted
+		case ',':
+			if !quoted {
+				parts = append(parts, str[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, str[start:])
+}
+
+// tagValue returns the trimmed value v of an option, without its surrounding
+// single quotes.
+func tagValue(v string) string {
+	v = strings.TrimSpace(v)
+	if len(v) >= 2 && v[0] == '\'' && v[len(v)-1] == '\'' {
+		return v[1 : len(v)-1]
+	}
+	return v
+}
+
+// has indicates if option name is present, either as flag or with a value.
+func (t tag) has(name string) bool {
+	_, ok := t.lookup(name)
+	return ok
+}
+
+// lookup returns the value of option name, e.g. "base" for "base=16".
+func (t tag) lookup(name string) (string, bool) {
+	for _, opt := range t.options {
+		k, v, _ := strings.Cut(opt, "=")
+		if strings.TrimSpace(k) == name {
+			return tagValue(v), true
+		}
+	}
+	return "", false
+}
+
+// values returns the values of all occurrences of option name, e.g. "A" and
+// "B" for "alias=A,alias=B".
+func (t tag) values(name string) []string {
+	var res []string
+	for _, opt := range t.options {
+		k, v, _ := strings.Cut(opt, "=")
+		if strings.TrimSpace(k) == name {
+			if v = tagValue(v); v != "" {
+				res = append(res, v)
+			}
+		}
+	}
+	return res
+}
+
+// fieldTagOptions are the supported tag options which do not override any
+// Options, they are handled by UnmarshalStruct, Describe and the binders.
+var fieldTagOptions = map[string]struct{}{
+	"alias":      {},
+	"col":        {},
+	"deprecated": {},
+	"len":        {},
+	"pos":        {},
+	"required":   {},
+	"rest":       {},
+	"secret":     {},
+	"signed":     {},
+}
+
+// applyOptions returns a copy of opts, modified by the tag options which
+// override them. It returns false when the tag does not contain any of these
+// options. The following options are supported:
+//   - sep=;          Options.ItemsSeparator
+//   - kvsep=:        Options.KeyValueSeparator
+//   - base=16        Options.IntBase
+//   - empty=zero     Options.EmptyMode (default, skip, zero or error)
+//   - format=seconds Options.DurationFormat (default, seconds or milliseconds)
+//   - noexp          Options.NoExponent
+//   - nonan          Options.RejectNaN
+//   - noinf          Options.RejectInf
+//   - escape         Options.EscapeNewlines
+//   - quote          Options.QuoteItems
+//   - json           Options.Tokenizer (JSONTokenizer)
+//
+// Values which contain a comma must be surrounded by single quotes, e.g.
+// "sep=','". An ErrInvalidTagOption error is returned for an invalid value, or
+// for an option which is neither listed above nor in fieldTagOptions.
+func (t tag) applyOptions(opts Options) (Options, bool, error) {
+	var changed bool
+	for _, opt := range t.options {
+		name, val, _ := strings.Cut(opt, "=")
+		name, val = strings.TrimSpace(name), tagValue(val)
+
+		switch name {
+		case "sep":
+			opts.ItemsSeparator = val
+		case "kvsep":
+			opts.KeyValueSeparator = val
+		case "base":
+			base, err := strconv.Atoi(val)
+			if err != nil || base < 2 || base > 36 {
+				return opts, false, errors.Newf("%w `%s`", ErrInvalidTagOption, opt)
+			}
+			opts.IntBase = base
+		case "empty":
+			switch val {
+			case "default":
+				opts.EmptyMode = EmptyDefault
+			case "skip":
+				opts.EmptyMode = EmptySkip
+			case "zero":
+				opts.EmptyMode = EmptyZero
+			case "error":
+				opts.EmptyMode = EmptyError
+			default:
+				return opts, false, errors.Newf("%w `%s`", ErrInvalidTagOption, opt)
+			}
+		case "format":
+			switch val {
+			case "default":
+				opts.DurationFormat = DurationDefault
+			case "seconds":
+				opts.DurationFormat = DurationSeconds
+			case "milliseconds":
+				opts.DurationFormat = DurationMilliseconds
+			default:
+				return opts, false, errors.Newf("%w `%s`", ErrInvalidTagOption, opt)
+			}
+		case "noexp":
+			opts.NoExponent = true
+		case "nonan":
+			opts.RejectNaN = true
+		case "noinf":
+			opts.RejectInf = true
+		case "escape":
+			opts.EscapeNewlines = true
+		case "quote":
+			opts.QuoteItems = true
+		case "json":
+			opts.Tokenizer = JSONTokenizer{}
+		default:
+			if _, ok := fieldTagOptions[name]; !ok {
+				return opts, false, errors.Newf("%w `%s`", ErrInvalidTagOption, opt)
+			}
+			continue
+		}
+		changed = true
+	}
+	return opts, changed, nil
+}
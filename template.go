@@ -0,0 +1,62 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*TemplateString)(nil)
+	_ encoding.TextUnmarshaler = (*TemplateString)(nil)
+)
+
+// TemplateString is a text/template, e.g. "{{.Level}}: {{.Message}}". Its
+// syntax is checked when unmarshaling, without executing it, so an invalid
+// template results in an error at startup instead of when it is first used.
+// Functions are not checked, as they are only known when the template is
+// created using Template.
+type TemplateString string
+
+// ParseTemplateString checks the syntax of str and returns it as a
+// TemplateString.
+func ParseTemplateString(str string) (TemplateString, error) {
+	tree := parse.New("")
+	tree.Mode = parse.SkipFuncCheck | parse.ParseComments
+	if _, err := tree.Parse(str, "", "", make(map[string]*parse.Tree)); err != nil {
+		return "", errors.Wrap(err, ErrParseFailure)
+	}
+	return TemplateString(str), nil
+}
+
+// Template parses the TemplateString as a template.Template with the
+// provided name and funcs, which may be nil.
+func (ts TemplateString) Template(name string, funcs template.FuncMap) (*template.Template, error) {
+	tmpl, err := template.New(name).Funcs(funcs).Parse(string(ts))
+	if err != nil {
+		return nil, errors.Wrap(err, ErrParseFailure)
+	}
+	return tmpl, nil
+}
+
+// String returns the TemplateString as string.
+func (ts TemplateString) String() string { return string(ts) }
+
+// MarshalText returns the TemplateString as text.
+func (ts TemplateString) MarshalText() ([]byte, error) { return []byte(ts), nil }
+
+// UnmarshalText checks the syntax of text and sets it as TemplateString.
+func (ts *TemplateString) UnmarshalText(text []byte) error {
+	x, err := ParseTemplateString(string(text))
+	if err != nil {
+		return err
+	}
+	*ts = x
+	return nil
+}
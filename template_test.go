@@ -0,0 +1,63 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTemplateString(t *testing.T) {
+	valid := map[string]string{
+		"empty":       "",
+		"text":        "just text",
+		"field":       "{{.Level}}: {{.Message}}",
+		"control":     "{{if .Error}}error: {{.Error}}{{else}}ok{{end}}",
+		"builtin":     `{{printf "%05d" .ID}}`,
+		"custom func": "{{upper .Name}}",
+		"comment":     "{{/* comment */}}x",
+	}
+	for name, input := range valid {
+		t.Run(name, func(t *testing.T) {
+			have, err := ParseTemplateString(input)
+			assert.NoError(t, err)
+			assert.Equal(t, TemplateString(input), have)
+		})
+	}
+
+	invalid := map[string]string{
+		"unclosed action": "{{.Level",
+		"unclosed if":     "{{if .Error}}error",
+		"unexpected end":  "{{end}}",
+		"bad field":       "{{.}}{{..Foo}}",
+	}
+	for name, input := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseTemplateString(input)
+			assert.ErrorIs(t, err, ErrParseFailure)
+		})
+	}
+}
+
+func TestTemplateString_Template(t *testing.T) {
+	var ts TemplateString
+	assert.NoError(t, Unmarshal("{{upper .Name}}!", &ts))
+
+	_, err := ts.Template("test", nil)
+	assert.ErrorIs(t, err, ErrParseFailure)
+
+	tmpl, err := ts.Template("test", template.FuncMap{"upper": strings.ToUpper})
+	assert.NoError(t, err)
+
+	var buf strings.Builder
+	assert.NoError(t, tmpl.Execute(&buf, map[string]string{"Name": "foo"}))
+	assert.Equal(t, "FOO!", buf.String())
+
+	assert.ErrorIs(t, Unmarshal("{{.Name", &ts), ErrParseFailure)
+	assert.Equal(t, TemplateString("{{upper .Name}}!"), ts)
+}
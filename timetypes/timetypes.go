@@ -0,0 +1,47 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package timetypes registers conversions for types of package time, which
+// are not supported by package rawconv out of the box. Import it for its side
+// effects only:
+//
+//	import _ "github.com/go-pogo/rawconv/timetypes"
+//
+// The following types are registered:
+//   - *time.Location, e.g. "Europe/Amsterdam", "UTC" or "Local"
+package timetypes
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/go-pogo/rawconv"
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+func init() {
+	// register the pointer type, so the *time.Location returned by
+	// time.LoadLocation is used instead of a copy of it, which would not
+	// work for the lazily initialized time.Local
+	location := reflect.TypeOf((*time.Location)(nil))
+	rawconv.RegisterUnmarshalFunc(location, unmarshalLocation)
+	rawconv.RegisterMarshalFunc(location, marshalLocation)
+}
+
+func unmarshalLocation(val rawconv.Value, dest any) error {
+	if val.IsEmpty() {
+		return nil
+	}
+
+	x, err := time.LoadLocation(val.String())
+	if err != nil {
+		return errors.Wrap(err, rawconv.ErrParseFailure)
+	}
+	*dest.(**time.Location) = x
+	return nil
+}
+
+func marshalLocation(v any) (string, error) {
+	return v.(*time.Location).String(), nil
+}
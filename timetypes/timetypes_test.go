@@ -0,0 +1,42 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package timetypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-pogo/rawconv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocation(t *testing.T) {
+	var have *time.Location
+	assert.NoError(t, rawconv.Unmarshal("UTC", &have))
+	assert.Equal(t, "UTC", have.String())
+	assert.Equal(t, rawconv.Value("UTC"), rawconv.MustMarshal(have))
+
+	assert.ErrorIs(t, rawconv.Unmarshal("Nowhere/Special", &have), rawconv.ErrParseFailure)
+}
+
+func TestLocation_local(t *testing.T) {
+	t.Setenv("TZ", "Europe/Amsterdam")
+
+	type config struct {
+		Location *time.Location `rawconv:"location"`
+	}
+
+	var have config
+	_, err := rawconv.UnmarshalStruct(rawconv.Values{"location": "Local"}, &have)
+	assert.NoError(t, err)
+	assert.Same(t, time.Local, have.Location)
+	assert.Equal(t, rawconv.Value(time.Local.String()), rawconv.MustMarshal(have.Location))
+
+	ts := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, ts.In(time.Local).Format(time.RFC3339), ts.In(have.Location).Format(time.RFC3339))
+
+	var nilLoc *time.Location
+	assert.Equal(t, rawconv.Value(""), rawconv.MustMarshal(nilLoc))
+}
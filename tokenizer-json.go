@@ -0,0 +1,163 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var _ Tokenizer = (*JSONTokenizer)(nil)
+
+// JSONTokenizer is a Tokenizer which uses JSON arrays for arrays and slices,
+// e.g. `["a","b"]`, and JSON objects for maps, e.g. `{"a":"1"}`. Items are
+// always joined as JSON strings. When splitting, numbers, booleans and null
+// are accepted as well, where null results in an empty item. Nested arrays and
+// objects are not supported. An empty string results in no items.
+type JSONTokenizer struct{}
+
+// Split splits the JSON array str into its items.
+func (JSONTokenizer) Split(str string) ([]string, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	dec := newJSONDecoder(str)
+	if err := expectDelim(dec, '['); err != nil {
+		return nil, err
+	}
+
+	var res []string
+	for dec.More() {
+		item, err := readJSONItem(dec)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, item)
+	}
+	if err := expectDelim(dec, ']'); err != nil {
+		return nil, err
+	}
+	return res, expectEOF(dec)
+}
+
+// Join joins items as a JSON array of strings.
+func (JSONTokenizer) Join(items []string) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeJSONString(&buf, item)
+	}
+	buf.WriteByte(']')
+	return buf.String(), nil
+}
+
+// SplitPairs splits the JSON object str into its key-value pairs, in the
+// order they occur.
+func (JSONTokenizer) SplitPairs(str string) ([][2]string, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	dec := newJSONDecoder(str)
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, err
+	}
+
+	var res [][2]string
+	for dec.More() {
+		// object keys are always strings
+		key, err := readJSONItem(dec)
+		if err != nil {
+			return nil, err
+		}
+		val, err := readJSONItem(dec)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, [2]string{key, val})
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return nil, err
+	}
+	return res, expectEOF(dec)
+}
+
+// JoinPairs joins pairs as a JSON object with string values.
+func (JSONTokenizer) JoinPairs(pairs [][2]string) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, pair := range pairs {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeJSONString(&buf, pair[0])
+		buf.WriteByte(':')
+		writeJSONString(&buf, pair[1])
+	}
+	buf.WriteByte('}')
+	return buf.String(), nil
+}
+
+func newJSONDecoder(str string) *json.Decoder {
+	dec := json.NewDecoder(strings.NewReader(str))
+	dec.UseNumber()
+	return dec
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return errors.Wrap(err, ErrParseFailure)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != delim {
+		return errors.Wrap(errors.Newf("expected `%s`", delim), ErrParseFailure)
+	}
+	return nil
+}
+
+func expectEOF(dec *json.Decoder) error {
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.Wrap(errors.New("unexpected data after JSON value"), ErrParseFailure)
+	}
+	return nil
+}
+
+func readJSONItem(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", errors.Wrap(err, ErrParseFailure)
+	}
+
+	switch v := tok.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case nil:
+		return "", nil
+	default:
+		return "", errors.New(ErrUnmarshalNested)
+	}
+}
+
+func writeJSONString(buf *bytes.Buffer, str string) {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	// encoding a string never fails
+	_ = enc.Encode(str)
+	// remove the newline which is added by Encode
+	buf.Truncate(buf.Len() - 1)
+}
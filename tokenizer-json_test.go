@@ -0,0 +1,72 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONTokenizer(t *testing.T) {
+	tests := map[string]struct {
+		input any
+		want  Value
+	}{
+		"strings": {
+			input: []string{"a", "b,c", `"<q>"`},
+			want:  `["a","b,c","\"<q>\""]`,
+		},
+		"ints": {
+			input: []int{1, 2},
+			want:  `["1","2"]`,
+		},
+		"map": {
+			input: map[string]float64{"a": 1.5, "b": 2},
+			want:  `{"a":"1.5","b":"2"}`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := NewMarshaler(WithTokenizer(JSONTokenizer{})).Marshal(reflect.ValueOf(tc.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, have)
+
+			dest := reflect.New(reflect.TypeOf(tc.input))
+			assert.NoError(t, NewUnmarshaler(WithTokenizer(JSONTokenizer{})).Unmarshal(have, dest))
+			assert.Equal(t, tc.input, dest.Elem().Interface())
+		})
+	}
+
+	u := NewUnmarshaler(WithTokenizer(JSONTokenizer{}))
+	t.Run("non-string values", func(t *testing.T) {
+		var list []string
+		assert.NoError(t, u.Unmarshal(`[1, 2.5, true, null, "x"]`, reflect.ValueOf(&list)))
+		assert.Equal(t, []string{"1", "2.5", "true", "", "x"}, list)
+
+		var m map[string]int
+		assert.NoError(t, u.Unmarshal(`{"a": 1, "b": 2}`, reflect.ValueOf(&m)))
+		assert.Equal(t, map[string]int{"a": 1, "b": 2}, m)
+	})
+
+	errs := map[string]struct {
+		input   Value
+		target  any
+		wantErr error
+	}{
+		"not an array":  {input: `"a"`, target: new([]string), wantErr: ErrParseFailure},
+		"object":        {input: `{"a":"b"}`, target: new([]string), wantErr: ErrParseFailure},
+		"unterminated":  {input: `["a"`, target: new([]string), wantErr: ErrParseFailure},
+		"trailing data": {input: `["a"] x`, target: new([]string), wantErr: ErrParseFailure},
+		"nested":        {input: `[["a"]]`, target: new([]string), wantErr: ErrUnmarshalNested},
+		"map array":     {input: `["a"]`, target: new(map[string]string), wantErr: ErrParseFailure},
+	}
+	for name, tc := range errs {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, u.Unmarshal(tc.input, reflect.ValueOf(tc.target)), tc.wantErr)
+		})
+	}
+}
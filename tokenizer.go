@@ -0,0 +1,86 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// Tokenizer splits the raw string of an array, slice or map into its raw
+// items, and joins them back together. The items themselves are converted by
+// the Marshaler or Unmarshaler, which allows plugging in a custom collection
+// syntax while reusing the conversions of the item types. Set
+// Options.Tokenizer to use a Tokenizer instead of the separators.
+type Tokenizer interface {
+	// Split splits str into the raw items of an array or slice.
+	Split(str string) ([]string, error)
+	// Join joins the raw items of an array or slice.
+	Join(items []string) (string, error)
+	// SplitPairs splits str into the raw key-value pairs of a map.
+	SplitPairs(str string) ([][2]string, error)
+	// JoinPairs joins the raw key-value pairs of a map, which are sorted by
+	// key.
+	JoinPairs(pairs [][2]string) (string, error)
+}
+
+var _ Tokenizer = (*FieldsTokenizer)(nil)
+
+// FieldsTokenizer is a Tokenizer which splits items around whitespace, e.g.
+// "a b  c" results in [a b c]. Key-value pairs are split by
+// KeyValueSeparator, which defaults to DefaultKeyValueSeparator.
+type FieldsTokenizer struct {
+	KeyValueSeparator string
+}
+
+func (t FieldsTokenizer) keyValueSeparator() string {
+	if t.KeyValueSeparator == "" {
+		return DefaultKeyValueSeparator
+	}
+	return t.KeyValueSeparator
+}
+
+// Split splits str around each instance of one or more consecutive
+// whitespace characters.
+func (FieldsTokenizer) Split(str string) ([]string, error) {
+	return strings.Fields(str), nil
+}
+
+// Join joins items using a single space.
+func (FieldsTokenizer) Join(items []string) (string, error) {
+	return strings.Join(items, " "), nil
+}
+
+// SplitPairs splits str around whitespace and each resulting field into a
+// key-value pair.
+func (t FieldsTokenizer) SplitPairs(str string) ([][2]string, error) {
+	fields := strings.Fields(str)
+	pairs := make([][2]string, 0, len(fields))
+	for _, field := range fields {
+		k, v, ok := strings.Cut(field, t.keyValueSeparator())
+		if !ok {
+			return nil, errors.New(ErrMapInvalidFormat)
+		}
+		pairs = append(pairs, [2]string{k, v})
+	}
+	return pairs, nil
+}
+
+// JoinPairs joins pairs using a single space.
+func (t FieldsTokenizer) JoinPairs(pairs [][2]string) (string, error) {
+	sep := t.keyValueSeparator()
+
+	var buf strings.Builder
+	for i, pair := range pairs {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(pair[0])
+		buf.WriteString(sep)
+		buf.WriteString(pair[1])
+	}
+	return buf.String(), nil
+}
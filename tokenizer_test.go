@@ -0,0 +1,121 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// bracketTokenizer is a Tokenizer for a "[a|b]" and "[k:v|k2:v2]" syntax.
+type bracketTokenizer struct{}
+
+const errNoBrackets errors.Msg = "missing brackets"
+
+func (bracketTokenizer) trim(str string) (string, error) {
+	if !strings.HasPrefix(str, "[") || !strings.HasSuffix(str, "]") {
+		return "", errors.New(errNoBrackets)
+	}
+	return str[1 : len(str)-1], nil
+}
+
+func (t bracketTokenizer) Split(str string) ([]string, error) {
+	str, err := t.trim(str)
+	if err != nil || str == "" {
+		return nil, err
+	}
+	return strings.Split(str, "|"), nil
+}
+
+func (bracketTokenizer) Join(items []string) (string, error) {
+	return "[" + strings.Join(items, "|") + "]", nil
+}
+
+func (t bracketTokenizer) SplitPairs(str string) ([][2]string, error) {
+	items, err := t.Split(str)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([][2]string, 0, len(items))
+	for _, item := range items {
+		k, v, _ := strings.Cut(item, ":")
+		pairs = append(pairs, [2]string{k, v})
+	}
+	return pairs, nil
+}
+
+func (t bracketTokenizer) JoinPairs(pairs [][2]string) (string, error) {
+	items := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		items = append(items, pair[0]+":"+pair[1])
+	}
+	return t.Join(items)
+}
+
+func TestTokenizer(t *testing.T) {
+	tests := map[string]struct {
+		tokenizer Tokenizer
+		input     any
+		want      Value
+	}{
+		"fields slice": {
+			tokenizer: FieldsTokenizer{},
+			input:     []int{1, 2, 3},
+			want:      "1 2 3",
+		},
+		"fields map": {
+			tokenizer: FieldsTokenizer{KeyValueSeparator: ":"},
+			input:     map[string]bool{"a": true, "b": false},
+			want:      "a:true b:false",
+		},
+		"custom slice": {
+			tokenizer: bracketTokenizer{},
+			input:     []string{"a,b", " c "},
+			want:      "[a,b| c ]",
+		},
+		"custom array": {
+			tokenizer: bracketTokenizer{},
+			input:     [2]float64{1.5, 2},
+			want:      "[1.5|2]",
+		},
+		"custom map": {
+			tokenizer: bracketTokenizer{},
+			input:     map[string]int{"x": 1, "y": 2},
+			want:      "[x:1|y:2]",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := NewMarshaler(WithTokenizer(tc.tokenizer)).Marshal(reflect.ValueOf(tc.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, have)
+
+			dest := reflect.New(reflect.TypeOf(tc.input))
+			assert.NoError(t, NewUnmarshaler(WithTokenizer(tc.tokenizer)).Unmarshal(have, dest))
+			assert.Equal(t, tc.input, dest.Elem().Interface())
+		})
+	}
+
+	t.Run("fields whitespace", func(t *testing.T) {
+		var list []string
+		u := NewUnmarshaler(WithTokenizer(FieldsTokenizer{}))
+		assert.NoError(t, u.Unmarshal(" a \t b\nc ", reflect.ValueOf(&list)))
+		assert.Equal(t, []string{"a", "b", "c"}, list)
+	})
+	t.Run("error", func(t *testing.T) {
+		var list []string
+		u := NewUnmarshaler(WithTokenizer(bracketTokenizer{}))
+		assert.ErrorIs(t, u.Unmarshal("a|b", reflect.ValueOf(&list)), errNoBrackets)
+	})
+	t.Run("limits", func(t *testing.T) {
+		var list []string
+		u := NewUnmarshaler(WithTokenizer(FieldsTokenizer{}), WithLimits(Limits{MaxItems: 2}))
+		assert.ErrorIs(t, u.Unmarshal("a b c", reflect.ValueOf(&list)), ErrTooManyItems)
+	})
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const ErrUnsupportedTOMLValue errors.Msg = "unsupported toml value"
+
+// MarshalTOML returns Value as a TOML basic string. It is used by TOML
+// encoders which support a MarshalTOML() ([]byte, error) method, such as
+// github.com/BurntSushi/toml.
+func (v Value) MarshalTOML() ([]byte, error) {
+	return []byte(tomlQuote(v.String())), nil
+}
+
+// UnmarshalTOML sets the decoded TOML value data as Value. It is used by TOML
+// decoders which support an UnmarshalTOML(any) error method, such as
+// github.com/BurntSushi/toml, and allows capturing scalar values as raw Value
+// for later typed decoding. Strings, integers, floats, booleans and datetimes
+// are formatted as text. The items of an array of scalars are joined using
+// DefaultItemsSeparator and the pairs of a table of scalars are joined using
+// DefaultKeyValueSeparator, so they can be unmarshaled to a slice or map.
+// Separators within the items are escaped with DefaultEscapeChar, unmarshal
+// such a Value with Options.EscapeSeparators enabled to restore them.
+//
+// As TOML decoders interpret values before passing them, the original text
+// of numbers is lost, e.g. 0x0A results in "10".
+func (v *Value) UnmarshalTOML(data any) error {
+	str, err := tomlValue(data, true)
+	if err != nil {
+		return err
+	}
+	*v = Value(str)
+	return nil
+}
+
+func tomlValue(data any, nested bool) (string, error) {
+	switch x := data.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return x, nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	case int64:
+		return strconv.FormatInt(x, 10), nil
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64), nil
+	case time.Time:
+		return x.Format(time.RFC3339Nano), nil
+	case encoding.TextMarshaler:
+		b, err := x.MarshalText()
+		return string(b), err
+	case fmt.Stringer:
+		return x.String(), nil
+
+	case []any:
+		if !nested {
+			break
+		}
+		items := make([]string, 0, len(x))
+		for _, item := range x {
+			str, err := tomlValue(item, false)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, escapeSeparators(str, DefaultEscapeChar, DefaultItemsSeparator))
+		}
+		return strings.Join(items, DefaultItemsSeparator), nil
+
+	case map[string]any:
+		if !nested {
+			break
+		}
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		items := make([]string, 0, len(x))
+		for _, k := range keys {
+			str, err := tomlValue(x[k], false)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, escapeSeparators(k, DefaultEscapeChar, DefaultKeyValueSeparator, DefaultItemsSeparator)+
+				DefaultKeyValueSeparator+
+				escapeSeparators(str, DefaultEscapeChar, DefaultKeyValueSeparator, DefaultItemsSeparator))
+		}
+		return strings.Join(items, DefaultItemsSeparator), nil
+	}
+	return "", errors.Newf("%w of type %T", ErrUnsupportedTOMLValue, data)
+}
+
+// UnmarshalTOML sets the entries of a decoded TOML table as Values. Nested
+// tables are flattened by joining their keys with StructKeySeparator, e.g.
+// "db.host", which matches the keys of nested struct fields used by
+// UnmarshalStruct. Other values are unmarshaled like Value.UnmarshalTOML does.
+func (vs *Values) UnmarshalTOML(data any) error {
+	table, ok := data.(map[string]any)
+	if !ok {
+		return errors.Newf("%w of type %T", ErrUnsupportedTOMLValue, data)
+	}
+
+	res := make(Values, len(table))
+	if err := res.appendTOML("", table); err != nil {
+		return err
+	}
+	*vs = res
+	return nil
+}
+
+func (vs Values) appendTOML(prefix string, table map[string]any) error {
+	for k, v := range table {
+		if t, ok := v.(map[string]any); ok {
+			if err := vs.appendTOML(prefix+k+StructKeySeparator, t); err != nil {
+				return err
+			}
+			continue
+		}
+
+		str, err := tomlValue(v, true)
+		if err != nil {
+			return err
+		}
+		vs[prefix+k] = Value(str)
+	}
+	return nil
+}
+
+// tomlQuote returns str as a TOML basic string.
+func tomlQuote(str string) string {
+	var sb strings.Builder
+	sb.Grow(len(str) + 2)
+	sb.WriteByte('"')
+	for _, r := range str {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\f':
+			sb.WriteString(`\f`)
+		case '\r':
+			sb.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
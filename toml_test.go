@@ -0,0 +1,95 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValue_UnmarshalTOML(t *testing.T) {
+	tests := map[string]struct {
+		data any
+		want Value
+	}{
+		"string": {data: "1h30m", want: "1h30m"},
+		"int":    {data: int64(10), want: "10"},
+		"float":  {data: 1.5, want: "1.5"},
+		"bool":   {data: true, want: "true"},
+		"time": {
+			data: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+			want: "2024-01-02T15:04:05Z",
+		},
+		"array":         {data: []any{"a", int64(2), true}, want: "a,2,true"},
+		"table":         {data: map[string]any{"b": int64(2), "a": int64(1)}, want: "a=1,b=2"},
+		"escaped array": {data: []any{"x,y", "z"}, want: `x\,y,z`},
+		"escaped table": {data: map[string]any{"a=b": "x,y"}, want: `a\=b=x\,y`},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var have Value
+			assert.NoError(t, have.UnmarshalTOML(tc.data))
+			assert.Equal(t, tc.want, have)
+		})
+	}
+
+	t.Run("escaped items", func(t *testing.T) {
+		var have Value
+		assert.NoError(t, have.UnmarshalTOML([]any{"x,y", "z"}))
+
+		var list []string
+		assert.NoError(t, Unmarshal(have, &list, WithEscapeSeparators(DefaultEscapeChar)))
+		assert.Equal(t, []string{"x,y", "z"}, list)
+
+		assert.NoError(t, have.UnmarshalTOML(map[string]any{"a=b": "x,y"}))
+
+		var m map[string]string
+		assert.NoError(t, Unmarshal(have, &m, WithEscapeSeparators(DefaultEscapeChar)))
+		assert.Equal(t, map[string]string{"a=b": "x,y"}, m)
+	})
+	t.Run("nested array", func(t *testing.T) {
+		var have Value
+		assert.ErrorIs(t, have.UnmarshalTOML([]any{[]any{"a"}}), ErrUnsupportedTOMLValue)
+	})
+}
+
+func TestValue_MarshalTOML(t *testing.T) {
+	tests := map[Value]string{
+		"foo":           `"foo"`,
+		`say "hi"`:      `"say \"hi\""`,
+		"a\\b":          `"a\\b"`,
+		"line\nbreak\t": `"line\nbreak\t"`,
+		"\x01":          `"\u0001"`,
+	}
+	for input, want := range tests {
+		t.Run(input.String(), func(t *testing.T) {
+			have, err := input.MarshalTOML()
+			assert.NoError(t, err)
+			assert.Equal(t, want, string(have))
+		})
+	}
+}
+
+func TestValues_UnmarshalTOML(t *testing.T) {
+	var have Values
+	assert.NoError(t, have.UnmarshalTOML(map[string]any{
+		"name": "app",
+		"db": map[string]any{
+			"host": "localhost",
+			"port": int64(5432),
+		},
+		"hosts": []any{"a", "b"},
+	}))
+	assert.Equal(t, Values{
+		"name":    "app",
+		"db.host": "localhost",
+		"db.port": "5432",
+		"hosts":   "a,b",
+	}, have)
+
+	assert.ErrorIs(t, have.UnmarshalTOML("nope"), ErrUnsupportedTOMLValue)
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"strings"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*TriBool)(nil)
+	_ encoding.TextUnmarshaler = (*TriBool)(nil)
+)
+
+// TriBool is a bool which can also be unset, so an overridable boolean can
+// distinguish "not specified" from false without using a *bool. The raw
+// Values "" and "default" (in any case) result in TriUnset, all other Values
+// are parsed with Value.Bool.
+type TriBool uint8
+
+const (
+	TriUnset TriBool = iota
+	TriTrue
+	TriFalse
+)
+
+// TriBoolFromBool returns TriTrue or TriFalse, depending on b.
+func TriBoolFromBool(b bool) TriBool {
+	if b {
+		return TriTrue
+	}
+	return TriFalse
+}
+
+// ParseTriBool parses str as a TriBool.
+func ParseTriBool(str string) (TriBool, error) {
+	if str = strings.TrimSpace(str); str == "" || strings.EqualFold(str, "default") {
+		return TriUnset, nil
+	}
+
+	b, err := Value(str).Bool()
+	if err != nil {
+		return TriUnset, err
+	}
+	return TriBoolFromBool(b), nil
+}
+
+// IsSet indicates if the TriBool is either TriTrue or TriFalse.
+func (tb TriBool) IsSet() bool { return tb == TriTrue || tb == TriFalse }
+
+// Bool returns true when the TriBool is TriTrue. It returns false otherwise.
+func (tb TriBool) Bool() bool { return tb == TriTrue }
+
+// Or returns the TriBool as bool when it is set, or def otherwise.
+func (tb TriBool) Or(def bool) bool {
+	if tb.IsSet() {
+		return tb.Bool()
+	}
+	return def
+}
+
+// String returns "true", "false" or an empty string when the TriBool is
+// TriUnset.
+func (tb TriBool) String() string {
+	switch tb {
+	case TriTrue:
+		return "true"
+	case TriFalse:
+		return "false"
+	default:
+		return ""
+	}
+}
+
+// MarshalText marshals TriBool into text.
+func (tb TriBool) MarshalText() ([]byte, error) {
+	return []byte(tb.String()), nil
+}
+
+// UnmarshalText unmarshals text into TriBool.
+func (tb *TriBool) UnmarshalText(text []byte) error {
+	x, err := ParseTriBool(string(text))
+	if err != nil {
+		return err
+	}
+	*tb = x
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTriBool(t *testing.T) {
+	tests := map[string]TriBool{
+		"":        TriUnset,
+		"default": TriUnset,
+		"DEFAULT": TriUnset,
+		"true":    TriTrue,
+		"1":       TriTrue,
+		"T":       TriTrue,
+		"false":   TriFalse,
+		"0":       TriFalse,
+		" FALSE ": TriFalse,
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, err := ParseTriBool(input)
+			assert.NoError(t, err)
+			assert.Equal(t, want, have)
+		})
+	}
+
+	_, err := ParseTriBool("maybe")
+	assert.ErrorIs(t, err, ErrParseFailure)
+}
+
+func TestTriBool(t *testing.T) {
+	assert.False(t, TriUnset.IsSet())
+	assert.True(t, TriTrue.IsSet())
+	assert.True(t, TriFalse.IsSet())
+
+	assert.True(t, TriUnset.Or(true))
+	assert.False(t, TriFalse.Or(true))
+	assert.True(t, TriTrue.Or(false))
+
+	assert.Equal(t, TriTrue, TriBoolFromBool(true))
+	assert.Equal(t, TriFalse, TriBoolFromBool(false))
+
+	for _, tb := range []TriBool{TriUnset, TriTrue, TriFalse} {
+		val, err := Marshal(tb)
+		assert.NoError(t, err)
+
+		var have TriBool
+		assert.NoError(t, Unmarshal(val, &have))
+		assert.Equal(t, tb, have)
+	}
+
+	var have struct {
+		Debug   TriBool `rawconv:"debug"`
+		Verbose TriBool `rawconv:"verbose"`
+	}
+	_, err := UnmarshalStruct(Values{"debug": "false"}, &have)
+	assert.NoError(t, err)
+	assert.Equal(t, TriFalse, have.Debug)
+	assert.Equal(t, TriUnset, have.Verbose)
+}
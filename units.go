@@ -0,0 +1,48 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import "time"
+
+// Seconds is an integer amount of seconds. Its raw Value is an integer without
+// unit, e.g. "30".
+type Seconds int64
+
+// Duration returns Seconds as a time.Duration.
+func (s Seconds) Duration() time.Duration { return time.Duration(s) * time.Second }
+
+// Milliseconds is an integer amount of milliseconds. Its raw Value is an
+// integer without unit, e.g. "1500".
+type Milliseconds int64
+
+// Duration returns Milliseconds as a time.Duration.
+func (ms Milliseconds) Duration() time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
+
+// Bytes is an integer amount of bytes. Its raw Value is an integer without
+// unit, e.g. "4096".
+type Bytes int64
+
+// KiB is an integer amount of kibibytes (1024 bytes). Its raw Value is an
+// integer without unit, e.g. "4".
+type KiB int64
+
+// Bytes returns KiB as an amount of Bytes.
+func (k KiB) Bytes() Bytes { return Bytes(k) << 10 }
+
+// MiB is an integer amount of mebibytes (1024 KiB). Its raw Value is an
+// integer without unit, e.g. "512".
+type MiB int64
+
+// Bytes returns MiB as an amount of Bytes.
+func (m MiB) Bytes() Bytes { return Bytes(m) << 20 }
+
+// GiB is an integer amount of gibibytes (1024 MiB). Its raw Value is an
+// integer without unit, e.g. "2".
+type GiB int64
+
+// Bytes returns GiB as an amount of Bytes.
+func (g GiB) Bytes() Bytes { return Bytes(g) << 30 }
@@ -0,0 +1,44 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnits(t *testing.T) {
+	var have struct {
+		Timeout Seconds      `rawconv:"timeout"`
+		Delay   Milliseconds `rawconv:"delay"`
+		Buffer  KiB          `rawconv:"buffer"`
+		Memory  MiB          `rawconv:"memory"`
+		Disk    GiB          `rawconv:"disk"`
+		Chunk   Bytes        `rawconv:"chunk"`
+	}
+
+	_, err := UnmarshalStruct(Values{
+		"timeout": "30",
+		"delay":   "1500",
+		"buffer":  "4",
+		"memory":  "512",
+		"disk":    "2",
+		"chunk":   "100",
+	}, &have)
+	assert.NoError(t, err)
+
+	assert.Equal(t, time.Second*30, have.Timeout.Duration())
+	assert.Equal(t, time.Millisecond*1500, have.Delay.Duration())
+	assert.Equal(t, Bytes(4096), have.Buffer.Bytes())
+	assert.Equal(t, Bytes(512*1024*1024), have.Memory.Bytes())
+	assert.Equal(t, Bytes(2*1024*1024*1024), have.Disk.Bytes())
+	assert.Equal(t, Bytes(100), have.Chunk)
+
+	val, err := Marshal(have.Timeout)
+	assert.NoError(t, err)
+	assert.Equal(t, Value("30"), val)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+const ErrUnknownUnit errors.Msg = "unknown unit"
+
+// Unit is a suffix of a raw Value and its multiplier relative to the base
+// unit of a UnitTable.
+type Unit struct {
+	Suffix     string
+	Multiplier float64
+}
+
+// UnitTable is a set of Unit(s) which is used to parse and format numbers with
+// a unit suffix, e.g. "1.5KiB" or "20°C". A number without suffix is in the
+// base unit, which has a multiplier of 1. Use its MarshalFunc and
+// UnmarshalFunc to register a unit system for an integer or float type:
+//
+//	type Size int64
+//	units := rawconv.NewUnitTable(rawconv.ByteUnits...)
+//	rawconv.RegisterMarshalFunc(reflect.TypeOf(Size(0)), units.MarshalFunc())
+//	rawconv.RegisterUnmarshalFunc(reflect.TypeOf(Size(0)), units.UnmarshalFunc())
+type UnitTable struct {
+	// units sorted by suffix length, the longest first
+	units []Unit
+	// format units sorted by multiplier, the largest first
+	format []Unit
+}
+
+// ByteUnits are the Unit(s) of byte sizes, both decimal (kB, MB, GB, TB) and
+// binary (KiB, MiB, GiB, TiB).
+var ByteUnits = []Unit{
+	{"B", 1},
+	{"kB", 1e3}, {"MB", 1e6}, {"GB", 1e9}, {"TB", 1e12},
+	{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30}, {"TiB", 1 << 40},
+}
+
+// NewUnitTable returns a UnitTable with the provided Unit(s). It panics when a
+// Unit has an empty suffix or a multiplier which is not greater than zero.
+func NewUnitTable(units ...Unit) *UnitTable {
+	ut := UnitTable{
+		units:  make([]Unit, len(units)),
+		format: make([]Unit, len(units)),
+	}
+	for i, u := range units {
+		if u.Suffix == "" || !(u.Multiplier > 0) || math.IsInf(u.Multiplier, 0) {
+			panic("rawconv: invalid unit " + strconv.Quote(u.Suffix))
+		}
+		ut.units[i], ut.format[i] = u, u
+	}
+
+	sort.SliceStable(ut.units, func(i, j int) bool {
+		return len(ut.units[i].Suffix) > len(ut.units[j].Suffix)
+	})
+	sort.SliceStable(ut.format, func(i, j int) bool {
+		return ut.format[i].Multiplier > ut.format[j].Multiplier
+	})
+	return &ut
+}
+
+// Parse parses str as a number with an optional unit suffix, and returns it
+// in the base unit.
+func (ut *UnitTable) Parse(str string) (float64, error) {
+	str = strings.TrimSpace(str)
+	num, mul := str, 1.0
+	for _, u := range ut.units {
+		if strings.HasSuffix(str, u.Suffix) {
+			num, mul = strings.TrimSpace(str[:len(str)-len(u.Suffix)]), u.Multiplier
+			break
+		}
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		kind := errKind(err)
+		if kind == ErrParseFailure && hasNumberPrefix(str) {
+			return 0, errors.Wrap(errors.Newf("%w in `%s`", ErrUnknownUnit, str), ErrParseFailure)
+		}
+		return 0, errors.Wrap(err, kind)
+	}
+	return f * mul, nil
+}
+
+// hasNumberPrefix indicates if str starts with a valid number, which is
+// followed by other characters.
+func hasNumberPrefix(str string) bool {
+	for i := len(str) - 1; i > 0; i-- {
+		if _, err := strconv.ParseFloat(strings.TrimSpace(str[:i]), 64); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Format formats f, in the base unit, using the Unit with the largest
+// multiplier which results in a whole number, e.g. "2KiB" or "1500B". When
+// there is no such Unit, the Unit with the largest multiplier which is not
+// greater than the absolute value of f is used, e.g. "2.5B".
+func (ut *UnitTable) Format(f float64) string {
+	abs := math.Abs(f)
+	for _, u := range ut.format {
+		if x := f / u.Multiplier; abs >= u.Multiplier && x == math.Trunc(x) {
+			return strconv.FormatFloat(x, 'g', -1, 64) + u.Suffix
+		}
+	}
+	for _, u := range ut.format {
+		if abs >= u.Multiplier {
+			return strconv.FormatFloat(f/u.Multiplier, 'g', -1, 64) + u.Suffix
+		}
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// MarshalFunc returns a MarshalFunc which formats integer and float types
+// using Format.
+func (ut *UnitTable) MarshalFunc() MarshalFunc {
+	return func(v any) (string, error) {
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return ut.Format(float64(rv.Int())), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return ut.Format(float64(rv.Uint())), nil
+		case reflect.Float32, reflect.Float64:
+			return ut.Format(rv.Float()), nil
+		default:
+			return "", errors.WithStack(&UnsupportedTypeError{Type: rv.Type()})
+		}
+	}
+}
+
+// UnmarshalFunc returns an UnmarshalFunc which parses a Value using Parse, and
+// sets the result to integer and float types. The result must be a whole
+// number which fits an integer type, otherwise an ErrValidationFailure is
+// returned.
+func (ut *UnitTable) UnmarshalFunc() UnmarshalFunc {
+	return func(val Value, dest any) error {
+		if val.IsEmpty() {
+			return nil
+		}
+
+		f, err := ut.Parse(val.String())
+		if err != nil {
+			return err
+		}
+
+		rv := reflect.ValueOf(dest).Elem()
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 || rv.OverflowInt(int64(f)) {
+				return errors.Wrap(errors.Newf("invalid integer `%s`", val), ErrValidationFailure)
+			}
+			rv.SetInt(int64(f))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if f != math.Trunc(f) || f < 0 || f >= math.MaxUint64 || rv.OverflowUint(uint64(f)) {
+				return errors.Wrap(errors.Newf("invalid unsigned integer `%s`", val), ErrValidationFailure)
+			}
+			rv.SetUint(uint64(f))
+		case reflect.Float32, reflect.Float64:
+			if rv.OverflowFloat(f) {
+				return errors.Wrap(errors.Newf("float `%s` out of range", val), ErrValidationFailure)
+			}
+			rv.SetFloat(f)
+		default:
+			return errors.WithStack(&UnsupportedTypeError{Type: rv.Type()})
+		}
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitTable(t *testing.T) {
+	units := NewUnitTable(ByteUnits...)
+
+	t.Run("parse", func(t *testing.T) {
+		tests := map[string]float64{
+			"4096":    4096,
+			"10B":     10,
+			"1.5KiB":  1536,
+			"2 MiB":   2 << 20,
+			"1kB":     1000,
+			"0.5GB":   5e8,
+			"-1TiB":   -(1 << 40),
+			" 3 GiB ": 3 << 30,
+		}
+		for input, want := range tests {
+			t.Run(input, func(t *testing.T) {
+				have, haveErr := units.Parse(input)
+				assert.NoError(t, haveErr)
+				assert.Equal(t, want, have)
+			})
+		}
+
+		_, haveErr := units.Parse("10XB")
+		assert.ErrorIs(t, haveErr, ErrUnknownUnit)
+		assert.ErrorIs(t, haveErr, ErrParseFailure)
+		_, haveErr = units.Parse("KiB")
+		assert.ErrorIs(t, haveErr, ErrParseFailure)
+	})
+	t.Run("format", func(t *testing.T) {
+		tests := map[float64]string{
+			0:          "0",
+			0.5:        "0.5",
+			1500:       "1500B",
+			2048:       "2KiB",
+			2.5:        "2.5B",
+			3e9:        "3GB",
+			1 << 40:    "1TiB",
+			-(2 << 20): "-2MiB",
+		}
+		for input, want := range tests {
+			have := units.Format(input)
+			assert.Equal(t, want, have)
+
+			back, err := units.Parse(have)
+			assert.NoError(t, err)
+			assert.Equal(t, input, back)
+		}
+	})
+	t.Run("funcs", func(t *testing.T) {
+		type size int32
+
+		var m Marshaler
+		m.Register(reflect.TypeOf(size(0)), units.MarshalFunc())
+		var u Unmarshaler
+		u.Register(reflect.TypeOf(size(0)), units.UnmarshalFunc())
+
+		var have size
+		assert.NoError(t, u.Unmarshal("1.5KiB", reflect.ValueOf(&have)))
+		assert.Equal(t, size(1536), have)
+
+		val, err := m.Marshal(reflect.ValueOf(size(4096)))
+		assert.NoError(t, err)
+		assert.Equal(t, Value("4KiB"), val)
+
+		assert.ErrorIs(t, u.Unmarshal("0.5B", reflect.ValueOf(&have)), ErrValidationFailure)
+		assert.ErrorIs(t, u.Unmarshal("4GiB", reflect.ValueOf(&have)), ErrValidationFailure)
+
+		var f float64
+		uf := Unmarshaler{}
+		uf.Register(reflect.TypeOf(f), units.UnmarshalFunc())
+		assert.NoError(t, uf.Unmarshal("0.5kB", reflect.ValueOf(&f)))
+		assert.Equal(t, 500.0, f)
+	})
+	t.Run("invalid unit", func(t *testing.T) {
+		assert.Panics(t, func() { NewUnitTable(Unit{"", 1}) })
+		assert.Panics(t, func() { NewUnitTable(Unit{"x", 0}) })
+	})
+}
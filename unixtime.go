@@ -0,0 +1,91 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"strconv"
+	"time"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*UnixTime)(nil)
+	_ encoding.TextUnmarshaler = (*UnixTime)(nil)
+	_ encoding.TextMarshaler   = (*UnixMilliTime)(nil)
+	_ encoding.TextUnmarshaler = (*UnixMilliTime)(nil)
+)
+
+// UnixTime is a time.Time which is represented by its raw Value as the number
+// of seconds elapsed since January 1, 1970 UTC, e.g. "872861820".
+type UnixTime time.Time
+
+// Time returns UnixTime as time.Time in UTC.
+func (t UnixTime) Time() time.Time { return time.Time(t).UTC() }
+
+// IsZero indicates if UnixTime is the zero time.Time.
+func (t UnixTime) IsZero() bool { return time.Time(t).IsZero() }
+
+// MarshalText returns the number of seconds since epoch as text, or an empty
+// text when UnixTime is the zero time.Time.
+func (t UnixTime) MarshalText() ([]byte, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return strconv.AppendInt(nil, time.Time(t).Unix(), 10), nil
+}
+
+// UnmarshalText parses text as an integer number of seconds since epoch. An
+// empty text results in the zero time.Time.
+func (t *UnixTime) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = UnixTime{}
+		return nil
+	}
+
+	x, err := Value(text).Int64()
+	if err != nil {
+		return err
+	}
+
+	*t = UnixTime(time.Unix(x, 0).UTC())
+	return nil
+}
+
+// UnixMilliTime is a time.Time which is represented by its raw Value as the
+// number of milliseconds elapsed since January 1, 1970 UTC, e.g.
+// "872861820000".
+type UnixMilliTime time.Time
+
+// Time returns UnixMilliTime as time.Time in UTC.
+func (t UnixMilliTime) Time() time.Time { return time.Time(t).UTC() }
+
+// IsZero indicates if UnixMilliTime is the zero time.Time.
+func (t UnixMilliTime) IsZero() bool { return time.Time(t).IsZero() }
+
+// MarshalText returns the number of milliseconds since epoch as text, or an
+// empty text when UnixMilliTime is the zero time.Time.
+func (t UnixMilliTime) MarshalText() ([]byte, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return strconv.AppendInt(nil, time.Time(t).UnixMilli(), 10), nil
+}
+
+// UnmarshalText parses text as an integer number of milliseconds since epoch.
+// An empty text results in the zero time.Time.
+func (t *UnixMilliTime) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = UnixMilliTime{}
+		return nil
+	}
+
+	x, err := Value(text).Int64()
+	if err != nil {
+		return err
+	}
+
+	*t = UnixMilliTime(time.UnixMilli(x).UTC())
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnixTime(t *testing.T) {
+	want := time.Date(1997, 8, 29, 13, 37, 0, 0, time.UTC)
+
+	var have UnixTime
+	assert.NoError(t, Unmarshal("872861820", &have))
+	assert.Equal(t, want, have.Time())
+
+	val, err := Marshal(have)
+	assert.NoError(t, err)
+	assert.Equal(t, Value("872861820"), val)
+
+	assert.ErrorIs(t, Unmarshal("1997-08-29", &have), ErrParseFailure)
+}
+
+func TestUnixMilliTime(t *testing.T) {
+	want := time.Date(1997, 8, 29, 13, 37, 0, int(time.Millisecond*123), time.UTC)
+
+	var have UnixMilliTime
+	assert.NoError(t, Unmarshal("872861820123", &have))
+	assert.Equal(t, want, have.Time())
+
+	val, err := Marshal(&have)
+	assert.NoError(t, err)
+	assert.Equal(t, Value("872861820123"), val)
+
+	assert.ErrorIs(t, Unmarshal("foo", &have), ErrParseFailure)
+}
+
+func TestUnixTime_zero(t *testing.T) {
+	assert.Equal(t, Value(""), MustMarshal(UnixTime{}))
+	assert.Equal(t, Value(""), MustMarshal(UnixMilliTime{}))
+	assert.Equal(t, Value("0"), MustMarshal(UnixTime(time.Unix(0, 0))))
+
+	ut := UnixTime(time.Now())
+	assert.NoError(t, ut.UnmarshalText(nil))
+	assert.True(t, ut.IsZero())
+
+	umt := UnixMilliTime(time.Now())
+	assert.NoError(t, Unmarshal(MustMarshal(UnixMilliTime{}), &umt, WithEmptyMode(EmptyZero)))
+	assert.True(t, umt.IsZero())
+}
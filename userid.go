@@ -0,0 +1,172 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"os/user"
+	"strconv"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*UID)(nil)
+	_ encoding.TextUnmarshaler = (*UID)(nil)
+	_ encoding.TextMarshaler   = (*GID)(nil)
+	_ encoding.TextUnmarshaler = (*GID)(nil)
+	_ encoding.TextMarshaler   = (*NamedUID)(nil)
+	_ encoding.TextUnmarshaler = (*NamedUID)(nil)
+	_ encoding.TextMarshaler   = (*NamedGID)(nil)
+	_ encoding.TextUnmarshaler = (*NamedGID)(nil)
+)
+
+// UID is a numeric user id. Use NamedUID to also accept user names.
+type UID uint32
+
+// GID is a numeric group id. Use NamedGID to also accept group names.
+type GID uint32
+
+func parseID(str string) (uint32, error) {
+	id, err := strconv.ParseUint(str, 10, 32)
+	if kind := errKind(err); kind != nil {
+		return 0, errors.Wrap(err, kind)
+	}
+	return uint32(id), nil
+}
+
+// ParseUID parses str as a numeric UID.
+func ParseUID(str string) (UID, error) {
+	id, err := parseID(str)
+	return UID(id), err
+}
+
+// ParseGID parses str as a numeric GID.
+func ParseGID(str string) (GID, error) {
+	id, err := parseID(str)
+	return GID(id), err
+}
+
+// MarshalText returns the UID as text.
+func (id UID) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(id), 10)), nil
+}
+
+// UnmarshalText parses text as a numeric UID.
+func (id *UID) UnmarshalText(text []byte) error {
+	x, err := ParseUID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = x
+	return nil
+}
+
+// MarshalText returns the GID as text.
+func (id GID) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(id), 10)), nil
+}
+
+// UnmarshalText parses text as a numeric GID.
+func (id *GID) UnmarshalText(text []byte) error {
+	x, err := ParseGID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = x
+	return nil
+}
+
+// NamedUID is a UID which accepts either a numeric user id or a user name.
+// User names are resolved to their id using os/user when unmarshaling.
+type NamedUID struct {
+	ID UID
+	// Name is the user name the ID was resolved from, it is empty when a
+	// numeric id was unmarshaled.
+	Name string
+}
+
+// LookupUID parses str as a numeric UID or resolves it as a user name using
+// user.Lookup.
+func LookupUID(str string) (NamedUID, error) {
+	if id, err := ParseUID(str); err == nil {
+		return NamedUID{ID: id}, nil
+	}
+
+	u, err := user.Lookup(str)
+	if err != nil {
+		return NamedUID{}, errors.Wrap(err, ErrValidationFailure)
+	}
+	id, err := ParseUID(u.Uid)
+	if err != nil {
+		return NamedUID{}, err
+	}
+	return NamedUID{ID: id, Name: u.Username}, nil
+}
+
+// MarshalText returns the name of NamedUID or its numeric id when the name is
+// empty.
+func (id NamedUID) MarshalText() ([]byte, error) {
+	if id.Name != "" {
+		return []byte(id.Name), nil
+	}
+	return id.ID.MarshalText()
+}
+
+// UnmarshalText parses text using LookupUID.
+func (id *NamedUID) UnmarshalText(text []byte) error {
+	x, err := LookupUID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = x
+	return nil
+}
+
+// NamedGID is a GID which accepts either a numeric group id or a group name.
+// Group names are resolved to their id using os/user when unmarshaling.
+type NamedGID struct {
+	ID GID
+	// Name is the group name the ID was resolved from, it is empty when a
+	// numeric id was unmarshaled.
+	Name string
+}
+
+// LookupGID parses str as a numeric GID or resolves it as a group name using
+// user.LookupGroup.
+func LookupGID(str string) (NamedGID, error) {
+	if id, err := ParseGID(str); err == nil {
+		return NamedGID{ID: id}, nil
+	}
+
+	g, err := user.LookupGroup(str)
+	if err != nil {
+		return NamedGID{}, errors.Wrap(err, ErrValidationFailure)
+	}
+	id, err := ParseGID(g.Gid)
+	if err != nil {
+		return NamedGID{}, err
+	}
+	return NamedGID{ID: id, Name: g.Name}, nil
+}
+
+// MarshalText returns the name of NamedGID or its numeric id when the name is
+// empty.
+func (id NamedGID) MarshalText() ([]byte, error) {
+	if id.Name != "" {
+		return []byte(id.Name), nil
+	}
+	return id.ID.MarshalText()
+}
+
+// UnmarshalText parses text using LookupGID.
+func (id *NamedGID) UnmarshalText(text []byte) error {
+	x, err := LookupGID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = x
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"os/user"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUID(t *testing.T) {
+	var have UID
+	assert.NoError(t, Unmarshal("1000", &have))
+	assert.Equal(t, UID(1000), have)
+	assert.Equal(t, Value("1000"), MustMarshal(have))
+
+	assert.ErrorIs(t, Unmarshal("root", &have), ErrParseFailure)
+	assert.ErrorIs(t, Unmarshal("4294967296", &have), ErrValidationFailure)
+
+	var gid GID
+	assert.NoError(t, Unmarshal("100", &gid))
+	assert.Equal(t, GID(100), gid)
+}
+
+func TestNamedUID(t *testing.T) {
+	t.Run("numeric", func(t *testing.T) {
+		var have NamedUID
+		assert.NoError(t, Unmarshal("1000", &have))
+		assert.Equal(t, NamedUID{ID: 1000}, have)
+		assert.Equal(t, Value("1000"), MustMarshal(have))
+	})
+	t.Run("name", func(t *testing.T) {
+		current, err := user.Current()
+		if err != nil {
+			t.Skip(err)
+		}
+		want, err := ParseUID(current.Uid)
+		if err != nil {
+			t.Skip(err)
+		}
+
+		var have NamedUID
+		assert.NoError(t, Unmarshal(Value(current.Username), &have))
+		assert.Equal(t, NamedUID{ID: want, Name: current.Username}, have)
+		assert.Equal(t, Value(current.Username), MustMarshal(have))
+	})
+	t.Run("unknown", func(t *testing.T) {
+		var have NamedUID
+		assert.ErrorIs(t, Unmarshal("no-such-user-x", &have), ErrValidationFailure)
+		var gid NamedGID
+		assert.ErrorIs(t, Unmarshal("no-such-group-x", &gid), ErrValidationFailure)
+	})
+}
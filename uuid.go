@@ -0,0 +1,93 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"encoding/hex"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*UUID)(nil)
+	_ encoding.TextUnmarshaler = (*UUID)(nil)
+)
+
+// UUID is a 16 byte universally unique identifier. Accepted raw Values are the
+// canonical form "6ba7b810-9dad-11d1-80b4-00c04fd430c8", the compact form
+// "6ba7b8109dad11d180b400c04fd430c8" and both forms wrapped in curly braces or
+// prefixed with "urn:uuid:". Hex digits are case-insensitive. A UUID is
+// marshaled in its canonical lowercase form.
+//
+// UUID types of other packages, e.g. github.com/google/uuid, implement
+// encoding.TextMarshaler and encoding.TextUnmarshaler and are therefore
+// supported without conversion.
+type UUID [16]byte
+
+// ParseUUID parses str as a UUID.
+func ParseUUID(str string) (UUID, error) {
+	s := str
+	if len(s) > 9 && strings.EqualFold(s[:9], "urn:uuid:") {
+		s = s[9:]
+	} else if len(s) > 2 && s[0] == '{' && s[len(s)-1] == '}' {
+		s = s[1 : len(s)-1]
+	}
+
+	switch len(s) {
+	case 32:
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return UUID{}, errors.Wrap(errors.Newf("invalid uuid format `%s`", str), ErrParseFailure)
+		}
+		s = s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	default:
+		return UUID{}, errors.Wrap(errors.Newf("invalid uuid length `%s`", str), ErrParseFailure)
+	}
+
+	var u UUID
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return UUID{}, errors.Wrap(err, ErrParseFailure)
+	}
+	return u, nil
+}
+
+// IsZero indicates if UUID is the nil UUID.
+func (u UUID) IsZero() bool { return u == UUID{} }
+
+// Version returns the version number of the UUID.
+func (u UUID) Version() int { return int(u[6] >> 4) }
+
+// Compact returns the UUID formatted as 32 hex digits without hyphens.
+func (u UUID) Compact() string { return hex.EncodeToString(u[:]) }
+
+// String returns the UUID in its canonical form.
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// MarshalText returns the UUID in its canonical form.
+func (u UUID) MarshalText() ([]byte, error) { return []byte(u.String()), nil }
+
+// UnmarshalText parses text as a UUID.
+func (u *UUID) UnmarshalText(text []byte) error {
+	x, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = x
+	return nil
+}
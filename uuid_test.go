@@ -0,0 +1,48 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseUUID(t *testing.T) {
+	const canonical = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	want := UUID{0x6b, 0xa7, 0xb8, 0x10, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8}
+
+	for _, input := range []string{
+		canonical,
+		"6BA7B810-9DAD-11D1-80B4-00C04FD430C8",
+		"6ba7b8109dad11d180b400c04fd430c8",
+		"{6ba7b810-9dad-11d1-80b4-00c04fd430c8}",
+		"urn:uuid:6ba7b810-9dad-11d1-80b4-00c04fd430c8",
+	} {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseUUID(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, want, have)
+			assert.Equal(t, Value(canonical), MustMarshal(have))
+		})
+	}
+
+	assert.Equal(t, 1, want.Version())
+	assert.Equal(t, "6ba7b8109dad11d180b400c04fd430c8", want.Compact())
+	assert.True(t, UUID{}.IsZero())
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", UUID{}.String())
+
+	for _, input := range []string{
+		"",
+		"6ba7b810-9dad-11d1-80b4",
+		"6ba7b810_9dad_11d1_80b4_00c04fd430c8",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cz",
+	} {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseUUID(input)
+			assert.ErrorIs(t, haveErr, ErrParseFailure)
+		})
+	}
+}
@@ -7,7 +7,7 @@ package rawconv
 import (
 	"strconv"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 // ValueFromBool encodes v to a Value using strconv.FormatBool.
@@ -0,0 +1,60 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding/hex"
+	"image/color"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// ValueFromRGBA encodes c to a Value with format "#rrggbb", or "#rrggbbaa"
+// when c is not fully opaque.
+func ValueFromRGBA(c color.RGBA) Value {
+	b := []byte{c.R, c.G, c.B, c.A}
+	if c.A == 0xff {
+		b = b[:3]
+	}
+	return Value("#" + hex.EncodeToString(b))
+}
+
+// RGBA tries to parse Value as a hex color with format "#RRGGBB" or
+// "#RRGGBBAA". The leading '#' is optional. Colors without alpha are fully
+// opaque.
+func (v Value) RGBA() (color.RGBA, error) {
+	str := strings.TrimPrefix(v.String(), "#")
+	if len(str) != 6 && len(str) != 8 {
+		return color.RGBA{}, errors.Wrap(errors.Newf("invalid hex color `%s`", v.String()), ErrParseFailure)
+	}
+
+	b, err := hex.DecodeString(str)
+	if err != nil {
+		return color.RGBA{}, errors.Wrap(err, ErrParseFailure)
+	}
+	if len(b) == 3 {
+		b = append(b, 0xff)
+	}
+	return color.RGBA{R: b[0], G: b[1], B: b[2], A: b[3]}, nil
+}
+
+// RGBAVar sets the value p points to using RGBA.
+func (v Value) RGBAVar(p *color.RGBA) (err error) {
+	*p, err = v.RGBA()
+	return
+}
+
+func unmarshalRGBA(val Value, dest any) error {
+	if val.IsEmpty() {
+		return nil
+	}
+
+	return val.RGBAVar(dest.(*color.RGBA))
+}
+
+func marshalRGBA(v any) (string, error) {
+	return ValueFromRGBA(v.(color.RGBA)).String(), nil
+}
@@ -7,7 +7,7 @@ package rawconv
 import (
 	"strconv"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 // ValueFromComplex64 encodes v to a Value using strconv.FormatComplex.
@@ -21,6 +21,8 @@ func ValueFromComplex128(v complex128) Value {
 }
 
 // Complex64 tries to parse Value as a complex64 using strconv.ParseComplex.
+// Both "1+2i" and "(1+2i)" forms are accepted, as well as the 'j' suffix which
+// is used by Python, e.g. "1+2j".
 func (v Value) Complex64() (complex64, error) {
 	x, err := complexSize(v, 64)
 	return complex64(x), err
@@ -33,6 +35,8 @@ func (v Value) Complex64Var(p *complex64) (err error) {
 }
 
 // Complex128 tries to parse Value as a complex128 using strconv.ParseComplex.
+// Both "1+2i" and "(1+2i)" forms are accepted, as well as the 'j' suffix which
+// is used by Python, e.g. "1+2j".
 func (v Value) Complex128() (complex128, error) {
 	return complexSize(v, 128)
 }
@@ -44,9 +48,48 @@ func (v Value) Complex128Var(p *complex128) (err error) {
 }
 
 func complexSize(v Value, bitSize int) (complex128, error) {
-	x, err := strconv.ParseComplex(v.String(), bitSize)
+	x, err := strconv.ParseComplex(replaceSuffixJ(v.String()), bitSize)
 	if kind := errKind(err); kind != nil {
 		return x, errors.Wrap(err, kind)
 	}
 	return x, errors.WithStack(err)
 }
+
+// replaceSuffixJ replaces the imaginary suffix 'j' or 'J' with 'i'.
+func replaceSuffixJ(s string) string {
+	i := len(s) - 1
+	if i > 0 && s[i] == ')' {
+		i--
+	}
+	if i < 0 || (s[i] != 'j' && s[i] != 'J') {
+		return s
+	}
+	return s[:i] + "i" + s[i+1:]
+}
+
+// ComplexFormat determines how a Marshaler formats complex numbers. Flags can
+// be combined, e.g. ComplexNoParens|ComplexSuffixJ results in "1+2j".
+type ComplexFormat uint8
+
+const (
+	// ComplexParens formats complex numbers within parentheses, e.g. "(1+2i)".
+	// This is the default format.
+	ComplexParens ComplexFormat = 0
+	// ComplexNoParens formats complex numbers without parentheses, e.g.
+	// "1+2i".
+	ComplexNoParens ComplexFormat = 1 << (iota - 1)
+	// ComplexSuffixJ formats the imaginary part with a 'j' suffix, like
+	// Python does, e.g. "(1+2j)".
+	ComplexSuffixJ
+)
+
+func formatComplex(c complex128, fmt byte, bitSize int, format ComplexFormat) string {
+	s := strconv.FormatComplex(c, fmt, -1, bitSize)
+	if format&ComplexSuffixJ != 0 {
+		s = s[:len(s)-2] + "j)"
+	}
+	if format&ComplexNoParens != 0 {
+		s = s[1 : len(s)-1]
+	}
+	return s
+}
@@ -7,7 +7,7 @@ package rawconv
 import (
 	"time"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 // Duration tries to parse Value as a time.Duration using time.ParseDuration.
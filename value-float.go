@@ -7,7 +7,7 @@ package rawconv
 import (
 	"strconv"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 // ValueFromFloat32 encodes v to a Value using strconv.FormatFloat.
@@ -21,6 +21,8 @@ func ValueFromFloat64(v float64) Value {
 }
 
 // Float32 tries to parse Value as a float32 using strconv.ParseFloat.
+// It accepts the special values "NaN", "Inf", "+Inf", "-Inf", "Infinity" etc.
+// case-insensitively.
 func (v Value) Float32() (float32, error) {
 	x, err := floatSize(v, 32)
 	return float32(x), err
@@ -33,6 +35,8 @@ func (v Value) Float32Var(p *float32) (err error) {
 }
 
 // Float64 tries to parse Value as a float64 using strconv.ParseFloat.
+// It accepts the special values "NaN", "Inf", "+Inf", "-Inf", "Infinity" etc.
+// case-insensitively.
 func (v Value) Float64() (float64, error) {
 	return floatSize(v, 64)
 }
@@ -7,7 +7,7 @@ package rawconv
 import (
 	"strconv"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 // ValueFromInt encodes v to a Value using strconv.FormatInt.
@@ -95,7 +95,11 @@ func (v Value) Int64Var(p *int64) (err error) {
 }
 
 func intSize(v Value, bitSize int) (int64, error) {
-	x, err := strconv.ParseInt(v.String(), 0, bitSize)
+	return intBase(v, 0, bitSize)
+}
+
+func intBase(v Value, base, bitSize int) (int64, error) {
+	x, err := strconv.ParseInt(v.String(), base, bitSize)
 	if kind := errKind(err); kind != nil {
 		return x, errors.Wrap(err, kind)
 	}
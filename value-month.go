@@ -0,0 +1,52 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// Month tries to parse Value as a time.Month. It accepts full (e.g.
+// "January") and abbreviated (e.g. "Jan") English names, case-insensitively,
+// and integers from 1 (January) to 12 (December).
+func (v Value) Month() (time.Month, error) {
+	str := strings.TrimSpace(v.String())
+	if i, err := strconv.Atoi(str); err == nil {
+		if i < 1 || i > 12 {
+			return 0, errors.Wrap(errors.Newf("invalid month `%s`", str), ErrValidationFailure)
+		}
+		return time.Month(i), nil
+	}
+
+	for m := time.January; m <= time.December; m++ {
+		name := m.String()
+		if strings.EqualFold(str, name) || strings.EqualFold(str, name[:3]) {
+			return m, nil
+		}
+	}
+	return 0, errors.Wrap(errors.Newf("invalid month `%s`", str), ErrParseFailure)
+}
+
+// MonthVar sets the value p points to using Month.
+func (v Value) MonthVar(p *time.Month) (err error) {
+	*p, err = v.Month()
+	return
+}
+
+func unmarshalMonth(val Value, dest any) error {
+	if val.IsEmpty() {
+		return nil
+	}
+
+	return val.MonthVar(dest.(*time.Month))
+}
+
+func marshalMonth(v any) (string, error) {
+	return v.(time.Month).String(), nil
+}
@@ -5,7 +5,7 @@
 package rawconv
 
 import (
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 // Rune returns the first rune of Value.
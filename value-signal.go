@@ -0,0 +1,73 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix || windows
+
+package rawconv
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+func init() {
+	syscallSignal := reflect.TypeOf(syscall.SIGTERM)
+	RegisterUnmarshalFunc(syscallSignal, unmarshalSignal)
+	RegisterMarshalFunc(syscallSignal, marshalSignal)
+}
+
+// ValueFromSignal returns the name of sig, e.g. "SIGTERM", as Value. Signals
+// without a known name are returned as their number.
+func ValueFromSignal(sig syscall.Signal) Value {
+	for name, s := range signals {
+		if s == sig {
+			return Value(name)
+		}
+	}
+	return Value(strconv.Itoa(int(sig)))
+}
+
+// Signal tries to parse Value as a syscall.Signal. It accepts signal names
+// with or without "SIG" prefix (e.g. "SIGTERM" or "term"),
+// case-insensitively, and positive signal numbers (e.g. "15").
+func (v Value) Signal() (syscall.Signal, error) {
+	str := strings.TrimSpace(v.String())
+	if i, err := strconv.Atoi(str); err == nil {
+		if i <= 0 {
+			return 0, errors.Wrap(errors.Newf("invalid signal `%s`", str), ErrValidationFailure)
+		}
+		return syscall.Signal(i), nil
+	}
+
+	name := strings.ToUpper(str)
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+	if sig, ok := signals[name]; ok {
+		return sig, nil
+	}
+	return 0, errors.Wrap(errors.Newf("invalid signal `%s`", str), ErrParseFailure)
+}
+
+// SignalVar sets the value p points to using Signal.
+func (v Value) SignalVar(p *syscall.Signal) (err error) {
+	*p, err = v.Signal()
+	return
+}
+
+func unmarshalSignal(val Value, dest any) error {
+	if val.IsEmpty() {
+		return nil
+	}
+
+	return val.SignalVar(dest.(*syscall.Signal))
+}
+
+func marshalSignal(v any) (string, error) {
+	return ValueFromSignal(v.(syscall.Signal)).String(), nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix || windows
+
+package rawconv
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValue_Signal(t *testing.T) {
+	tests := map[Value]syscall.Signal{
+		"SIGTERM":  syscall.SIGTERM,
+		"sighup":   syscall.SIGHUP,
+		"INT":      syscall.SIGINT,
+		" kill ":   syscall.SIGKILL,
+		"15":       syscall.Signal(15),
+		"SIGQUIT ": syscall.SIGQUIT,
+	}
+	for input, want := range tests {
+		t.Run(input.String(), func(t *testing.T) {
+			var have syscall.Signal
+			assert.NoError(t, input.SignalVar(&have))
+			assert.Equal(t, want, have)
+		})
+	}
+
+	_, err := Value("0").Signal()
+	assert.ErrorIs(t, err, ErrValidationFailure)
+	_, err = Value("SIGNOPE").Signal()
+	assert.ErrorIs(t, err, ErrParseFailure)
+}
+
+func TestSignal(t *testing.T) {
+	var have syscall.Signal
+	assert.NoError(t, Unmarshal("term", &have))
+	assert.Equal(t, syscall.SIGTERM, have)
+	assert.Equal(t, Value("SIGTERM"), MustMarshal(have))
+	assert.Equal(t, Value("250"), ValueFromSignal(syscall.Signal(250)))
+}
@@ -7,7 +7,7 @@ package rawconv
 import (
 	"strconv"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 // ValueFromUint encodes v to a Value using strconv.FormatUint.
@@ -95,7 +95,11 @@ func (v Value) Uint64Var(p *uint64) (err error) {
 }
 
 func uintSize(v Value, bitSize int) (uint64, error) {
-	x, err := strconv.ParseUint(v.String(), 0, bitSize)
+	return uintBase(v, 0, bitSize)
+}
+
+func uintBase(v Value, base, bitSize int) (uint64, error) {
+	x, err := strconv.ParseUint(v.String(), base, bitSize)
 	if kind := errKind(err); kind != nil {
 		return x, errors.Wrap(err, kind)
 	}
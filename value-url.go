@@ -7,7 +7,7 @@ package rawconv
 import (
 	"net/url"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 )
 
 // Url tries to parse Value as an *url.URL using url.ParseRequestURI.
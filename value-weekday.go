@@ -0,0 +1,52 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+// Weekday tries to parse Value as a time.Weekday. It accepts full (e.g.
+// "Monday") and abbreviated (e.g. "Mon") English names, case-insensitively,
+// and integers from 0 (Sunday) to 6 (Saturday).
+func (v Value) Weekday() (time.Weekday, error) {
+	str := strings.TrimSpace(v.String())
+	if i, err := strconv.Atoi(str); err == nil {
+		if i < 0 || i > 6 {
+			return 0, errors.Wrap(errors.Newf("invalid weekday `%s`", str), ErrValidationFailure)
+		}
+		return time.Weekday(i), nil
+	}
+
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := d.String()
+		if strings.EqualFold(str, name) || strings.EqualFold(str, name[:3]) {
+			return d, nil
+		}
+	}
+	return 0, errors.Wrap(errors.Newf("invalid weekday `%s`", str), ErrParseFailure)
+}
+
+// WeekdayVar sets the value p points to using Weekday.
+func (v Value) WeekdayVar(p *time.Weekday) (err error) {
+	*p, err = v.Weekday()
+	return
+}
+
+func unmarshalWeekday(val Value, dest any) error {
+	if val.IsEmpty() {
+		return nil
+	}
+
+	return val.WeekdayVar(dest.(*time.Weekday))
+}
+
+func marshalWeekday(v any) (string, error) {
+	return v.(time.Weekday).String(), nil
+}
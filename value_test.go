@@ -5,13 +5,14 @@
 package rawconv
 
 import (
+	"image/color"
 	"math"
 	"net/url"
 	"strconv"
 	"testing"
 	"time"
 
-	"github.com/go-pogo/errors"
+	"github.com/go-pogo/rawconv/internal/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -360,3 +361,118 @@ func TestValueFromUint32(t *testing.T) {
 	assert.Equal(t, want, have)
 	assert.Nil(t, haveErr)
 }
+
+func TestValue_Complex128(t *testing.T) {
+	tests := map[Value]complex128{
+		"1+2i":    1 + 2i,
+		"(1+2i)":  1 + 2i,
+		"1+2j":    1 + 2i,
+		"(1-2J)":  1 - 2i,
+		"2j":      2i,
+		"(-1.5j)": -1.5i,
+		"3":       3,
+	}
+	for input, want := range tests {
+		t.Run(input.String(), func(t *testing.T) {
+			have, haveErr := input.Complex128()
+			assert.Equal(t, want, have)
+			assert.NoError(t, haveErr)
+		})
+	}
+
+	_, err := Value("j").Complex128()
+	assert.ErrorIs(t, err, ErrParseFailure)
+}
+
+func TestFormatComplex(t *testing.T) {
+	tests := map[ComplexFormat]string{
+		ComplexParens:                    "(1+2i)",
+		ComplexNoParens:                  "1+2i",
+		ComplexSuffixJ:                   "(1+2j)",
+		ComplexNoParens | ComplexSuffixJ: "1+2j",
+	}
+	for format, want := range tests {
+		t.Run(want, func(t *testing.T) {
+			have := formatComplex(1+2i, 'g', 128, format)
+			assert.Equal(t, want, have)
+
+			parsed, err := Value(have).Complex128()
+			assert.NoError(t, err)
+			assert.Equal(t, 1+2i, parsed)
+		})
+	}
+}
+
+func TestValue_Weekday(t *testing.T) {
+	tests := map[Value]time.Weekday{
+		"Sunday":   time.Sunday,
+		"monday":   time.Monday,
+		"TUE":      time.Tuesday,
+		" wed ":    time.Wednesday,
+		"4":        time.Thursday,
+		"0":        time.Sunday,
+		"saturday": time.Saturday,
+	}
+	for input, want := range tests {
+		t.Run(input.String(), func(t *testing.T) {
+			var have time.Weekday
+			assert.NoError(t, input.WeekdayVar(&have))
+			assert.Equal(t, want, have)
+		})
+	}
+
+	_, err := Value("7").Weekday()
+	assert.ErrorIs(t, err, ErrValidationFailure)
+	_, err = Value("sun-day").Weekday()
+	assert.ErrorIs(t, err, ErrParseFailure)
+}
+
+func TestValue_Month(t *testing.T) {
+	tests := map[Value]time.Month{
+		"January": time.January,
+		"feb":     time.February,
+		"MARCH":   time.March,
+		"12":      time.December,
+		"1":       time.January,
+		"Sep":     time.September,
+	}
+	for input, want := range tests {
+		t.Run(input.String(), func(t *testing.T) {
+			var have time.Month
+			assert.NoError(t, input.MonthVar(&have))
+			assert.Equal(t, want, have)
+		})
+	}
+
+	_, err := Value("0").Month()
+	assert.ErrorIs(t, err, ErrValidationFailure)
+	_, err = Value("Sept.").Month()
+	assert.ErrorIs(t, err, ErrParseFailure)
+}
+
+func TestValue_RGBA(t *testing.T) {
+	tests := map[Value]color.RGBA{
+		"#ff8000":   {R: 0xff, G: 0x80, A: 0xff},
+		"#FF800080": {R: 0xff, G: 0x80, A: 0x80},
+		"00ff00":    {G: 0xff, A: 0xff},
+	}
+	for input, want := range tests {
+		t.Run(input.String(), func(t *testing.T) {
+			var have color.RGBA
+			assert.NoError(t, input.RGBAVar(&have))
+			assert.Equal(t, want, have)
+		})
+	}
+
+	for _, input := range []Value{"", "#fff", "#ff80", "#gg0000", "#ff000000ff"} {
+		t.Run(input.String(), func(t *testing.T) {
+			_, err := input.RGBA()
+			assert.ErrorIs(t, err, ErrParseFailure)
+		})
+	}
+}
+
+func TestValueFromRGBA(t *testing.T) {
+	assert.Equal(t, Value("#ff8000"), ValueFromRGBA(color.RGBA{R: 0xff, G: 0x80, A: 0xff}))
+	assert.Equal(t, Value("#ff800080"), ValueFromRGBA(color.RGBA{R: 0xff, G: 0x80, A: 0x80}))
+}
@@ -0,0 +1,128 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Source is a provider of Value(s) which can be looked up by their key.
+type Source interface {
+	// Lookup returns the Value of key and a boolean indicating if the key
+	// exists within Source.
+	Lookup(key string) (Value, bool)
+	// Keys returns all keys available within Source, in sorted order.
+	Keys() []string
+}
+
+var _ Source = (Values)(nil)
+
+// Values is a map of Value(s) indexed by their key.
+type Values map[string]Value
+
+// ValuesFromMap returns the strings of map m as Values.
+func ValuesFromMap(m map[string]string) Values {
+	vs := make(Values, len(m))
+	for k, v := range m {
+		vs[k] = Value(v)
+	}
+	return vs
+}
+
+// ValuesFromURL returns url.Values q as Values. Keys with multiple values are
+// joined using DefaultItemsSeparator, so they can be unmarshaled to a slice.
+func ValuesFromURL(q url.Values) Values {
+	vs := make(Values, len(q))
+	for k, v := range q {
+		vs[k] = Value(strings.Join(v, DefaultItemsSeparator))
+	}
+	return vs
+}
+
+// ValuesFromEnviron returns the environment variables from environ, in the
+// "key=value" form of os.Environ, as Values. When prefix is not empty, only
+// variables with this prefix are included and the prefix is stripped from
+// their keys, like Values.WithPrefix.
+func ValuesFromEnviron(environ []string, prefix string) Values {
+	vs := make(Values, len(environ))
+	for _, env := range environ {
+		// skip the first char, so keys like "=C:" on Windows are kept intact
+		i := strings.IndexByte(env, '=')
+		if i == 0 {
+			i = strings.IndexByte(env[1:], '=') + 1
+		}
+		if i <= 0 {
+			continue
+		}
+		if key := env[:i]; len(key) > len(prefix) && strings.HasPrefix(key, prefix) {
+			vs[key[len(prefix):]] = Value(env[i+1:])
+		}
+	}
+	return vs
+}
+
+// Get returns the Value of key, or an empty Value when it does not exist.
+func (vs Values) Get(key string) Value { return vs[key] }
+
+// Lookup returns the Value of key and a boolean indicating if the key exists.
+func (vs Values) Lookup(key string) (Value, bool) {
+	v, ok := vs[key]
+	return v, ok
+}
+
+// Keys returns all keys of Values in sorted order.
+func (vs Values) Keys() []string {
+	keys := make([]string, 0, len(vs))
+	for k := range vs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Map returns Values as a map of strings.
+func (vs Values) Map() map[string]string {
+	m := make(map[string]string, len(vs))
+	for k, v := range vs {
+		m[k] = v.String()
+	}
+	return m
+}
+
+// URLValues returns Values as url.Values, with a single value per key. Values
+// are not split on any separator.
+func (vs Values) URLValues() url.Values {
+	q := make(url.Values, len(vs))
+	for k, v := range vs {
+		q[k] = []string{v.String()}
+	}
+	return q
+}
+
+// Environ returns Values in the "key=value" form of os.Environ, sorted by key
+// and with prefix prepended to each key. It is the inverse of
+// ValuesFromEnviron.
+func (vs Values) Environ(prefix string) []string {
+	res := make([]string, 0, len(vs))
+	for _, k := range vs.Keys() {
+		res = append(res, prefix+k+"="+vs[k].String())
+	}
+	return res
+}
+
+// WithPrefix returns the Values of which the key starts with prefix, with the
+// prefix stripped from their keys, e.g. "APP_PORT" becomes "PORT" for prefix
+// "APP_". A key which equals prefix is not included.
+func (vs Values) WithPrefix(prefix string) Values {
+	res := make(Values, len(vs))
+	for k, v := range vs {
+		if len(k) > len(prefix) && strings.HasPrefix(k, prefix) {
+			res[k[len(prefix):]] = v
+		}
+	}
+	return res
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValues_Lookup(t *testing.T) {
+	vs := Values{"foo": "bar", "empty": ""}
+
+	have, ok := vs.Lookup("foo")
+	assert.True(t, ok)
+	assert.Equal(t, Value("bar"), have)
+
+	have, ok = vs.Lookup("empty")
+	assert.True(t, ok)
+	assert.Equal(t, Value(""), have)
+
+	have, ok = vs.Lookup("qux")
+	assert.False(t, ok)
+	assert.Equal(t, Value(""), have)
+}
+
+func TestValues_Keys(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"}, Values{"c": "", "a": "", "b": ""}.Keys())
+	assert.Equal(t, []string{}, Values{}.Keys())
+}
+
+func TestValuesFromMap(t *testing.T) {
+	m := map[string]string{"foo": "bar", "empty": ""}
+	vs := ValuesFromMap(m)
+	assert.Equal(t, Values{"foo": "bar", "empty": ""}, vs)
+	assert.Equal(t, m, vs.Map())
+}
+
+func TestValuesFromURL(t *testing.T) {
+	q := url.Values{"ids": {"1", "2", "3"}, "name": {"foo"}}
+	vs := ValuesFromURL(q)
+	assert.Equal(t, Values{"ids": "1,2,3", "name": "foo"}, vs)
+	assert.Equal(t, url.Values{"ids": {"1,2,3"}, "name": {"foo"}}, vs.URLValues())
+
+	var ids []int
+	assert.NoError(t, Unmarshal(vs.Get("ids"), &ids))
+	assert.Equal(t, []int{1, 2, 3}, ids)
+}
+
+func TestValuesFromEnviron(t *testing.T) {
+	environ := []string{"APP_PORT=8080", "APP_DSN=a=b", "APP_=x", "HOME=/root", "=C:=C:\\", "invalid"}
+	assert.Equal(t, Values{
+		"APP_PORT": "8080",
+		"APP_DSN":  "a=b",
+		"APP_":     "x",
+		"HOME":     "/root",
+		"=C:":      "C:\\",
+	}, ValuesFromEnviron(environ, ""))
+	assert.Equal(t, Values{"PORT": "8080", "DSN": "a=b"}, ValuesFromEnviron(environ, "APP_"))
+}
+
+func TestValues_Environ(t *testing.T) {
+	vs := Values{"PORT": "8080", "DSN": "a=b", "EMPTY": ""}
+	environ := vs.Environ("APP_")
+	assert.Equal(t, []string{"APP_DSN=a=b", "APP_EMPTY=", "APP_PORT=8080"}, environ)
+	assert.Equal(t, Values{"PORT": "8080", "DSN": "a=b", "EMPTY": ""}, ValuesFromEnviron(environ, "APP_"))
+}
+
+func TestValues_WithPrefix(t *testing.T) {
+	vs := Values{"APP_PORT": "8080", "APP_": "x", "HOME": "/root"}
+	assert.Equal(t, Values{"PORT": "8080"}, vs.WithPrefix("APP_"))
+	assert.Equal(t, Values{}, vs.WithPrefix("OTHER_"))
+}
@@ -0,0 +1,207 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*Version)(nil)
+	_ encoding.TextUnmarshaler = (*Version)(nil)
+)
+
+// Version is a semantic version as described by https://semver.org, e.g.
+// "1.2.3-rc.1+build.5". When parsing, an optional "v" prefix is allowed.
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+}
+
+// ParseVersion parses str as a semantic Version.
+func ParseVersion(str string) (Version, error) {
+	var ver Version
+	if err := ver.parse(str); err != nil {
+		return ver, errors.Wrap(err, ErrParseFailure)
+	}
+	return ver, nil
+}
+
+func (v *Version) parse(str string) error {
+	orig := str
+	str = strings.TrimPrefix(str, "v")
+
+	var ok bool
+	if str, v.Build, ok = strings.Cut(str, "+"); ok {
+		if err := validateSemverIdents(v.Build, false); err != nil {
+			return errors.Newf("invalid build metadata in `%s`: %s", orig, err.Error())
+		}
+	}
+	if str, v.Prerelease, ok = strings.Cut(str, "-"); ok {
+		if err := validateSemverIdents(v.Prerelease, true); err != nil {
+			return errors.Newf("invalid prerelease in `%s`: %s", orig, err.Error())
+		}
+	}
+
+	parts := strings.Split(str, ".")
+	if len(parts) != 3 {
+		return errors.Newf("invalid version `%s`: expected major.minor.patch", orig)
+	}
+
+	nums := [3]*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		if !isSemverNumber(part) {
+			return errors.Newf("invalid version `%s`: invalid number `%s`", orig, part)
+		}
+
+		var err error
+		if *nums[i], err = strconv.ParseUint(part, 10, 64); err != nil {
+			return errors.Newf("invalid version `%s`: invalid number `%s`", orig, part)
+		}
+	}
+	return nil
+}
+
+func isSemverNumber(s string) bool {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func validateSemverIdents(str string, noLeadingZeros bool) error {
+	for _, ident := range strings.Split(str, ".") {
+		if ident == "" {
+			return errors.New("empty identifier")
+		}
+
+		numeric := true
+		for i := 0; i < len(ident); i++ {
+			c := ident[i]
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return errors.Newf("invalid identifier `%s`", ident)
+			}
+		}
+		if numeric && noLeadingZeros && !isSemverNumber(ident) {
+			return errors.Newf("numeric identifier `%s` has leading zeros", ident)
+		}
+	}
+	return nil
+}
+
+// String returns Version formatted as MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD].
+func (v Version) String() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatUint(v.Major, 10))
+	sb.WriteByte('.')
+	sb.WriteString(strconv.FormatUint(v.Minor, 10))
+	sb.WriteByte('.')
+	sb.WriteString(strconv.FormatUint(v.Patch, 10))
+	if v.Prerelease != "" {
+		sb.WriteByte('-')
+		sb.WriteString(v.Prerelease)
+	}
+	if v.Build != "" {
+		sb.WriteByte('+')
+		sb.WriteString(v.Build)
+	}
+	return sb.String()
+}
+
+// Compare returns -1, 0 or +1 depending on whether Version v has a lower,
+// equal or higher precedence than Version o. Build metadata is ignored.
+func (v Version) Compare(o Version) int {
+	if c := compareUint(v.Major, o.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, o.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, o.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, o.Prerelease)
+}
+
+// Less indicates if Version v has a lower precedence than Version o.
+func (v Version) Less(o Version) bool { return v.Compare(o) < 0 }
+
+// Equal indicates if Version v has the same precedence as Version o.
+func (v Version) Equal(o Version) bool { return v.Compare(o) == 0 }
+
+// MarshalText returns Version formatted as text.
+func (v Version) MarshalText() ([]byte, error) { return []byte(v.String()), nil }
+
+// UnmarshalText parses text as a semantic Version.
+func (v *Version) UnmarshalText(text []byte) error {
+	x, err := ParseVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = x
+	return nil
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func comparePrerelease(a, b string) int {
+	// a version without prerelease has a higher precedence
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.ParseUint(as[i], 10, 64)
+		bn, bErr := strconv.ParseUint(bs[i], 10, 64)
+
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareUint(an, bn)
+		case aErr == nil:
+			// numeric identifiers have lower precedence
+			c = -1
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(as[i], bs[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(as)), uint64(len(bs)))
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := map[string]Version{
+		"1.2.3":                {Major: 1, Minor: 2, Patch: 3},
+		"v1.2.3-rc.1":          {Major: 1, Minor: 2, Patch: 3, Prerelease: "rc.1"},
+		"0.0.0+build.5":        {Build: "build.5"},
+		"10.20.30-alpha+exp01": {Major: 10, Minor: 20, Patch: 30, Prerelease: "alpha", Build: "exp01"},
+		"1.0.0-x-y.7":          {Major: 1, Prerelease: "x-y.7"},
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseVersion(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, want, have)
+		})
+	}
+
+	for _, input := range []string{
+		"", "1", "1.2", "1.2.3.4", "01.2.3", "1.2.x", "1.2.3-", "1.2.3-01", "1.2.3-rc..1", "1.2.3+", "1.2.3+b@d",
+	} {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseVersion(input)
+			assert.ErrorIs(t, haveErr, ErrParseFailure)
+		})
+	}
+}
+
+func TestVersion_String(t *testing.T) {
+	for _, want := range []string{"1.2.3", "1.2.3-rc.1", "1.2.3+build", "1.2.3-beta+exp.sha.5114f85"} {
+		var have Version
+		assert.NoError(t, Unmarshal(Value(want), &have))
+		assert.Equal(t, Value(want), MustMarshal(have))
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	// ordered by precedence, as listed at https://semver.org
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := ParseVersion(ordered[i-1])
+		b, _ := ParseVersion(ordered[i])
+		assert.True(t, a.Less(b), "%s < %s", a, b)
+		assert.Equal(t, 1, b.Compare(a), "%s > %s", b, a)
+	}
+
+	a, _ := ParseVersion("1.0.0+build.1")
+	b, _ := ParseVersion("v1.0.0+build.2")
+	assert.True(t, a.Equal(b))
+}
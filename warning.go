@@ -0,0 +1,100 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import "reflect"
+
+// WarningKind describes the kind of non-fatal issue a Warning reports.
+type WarningKind uint8
+
+const (
+	// WarnTrimmedSpace indicates leading or trailing whitespace is removed
+	// from an item of an array, slice or map before it is unmarshaled.
+	WarnTrimmedSpace WarningKind = iota + 1
+	// WarnUnknownKey indicates a key of a Source has no matching struct
+	// field. See WarnUnknownKeys.
+	WarnUnknownKey
+	// WarnDeprecatedKey indicates a Value is set using an alias of a struct
+	// field's key, or using the key of a field which is marked as deprecated.
+	WarnDeprecatedKey
+)
+
+func (k WarningKind) String() string {
+	switch k {
+	case WarnTrimmedSpace:
+		return "trimmed whitespace"
+	case WarnUnknownKey:
+		return "unknown key"
+	case WarnDeprecatedKey:
+		return "deprecated key"
+	default:
+		return "unknown warning"
+	}
+}
+
+// Warning describes a non-fatal issue which is found while unmarshaling. It
+// never contains the Value itself as it may contain secrets.
+type Warning struct {
+	Kind WarningKind
+	// Type is the type which is unmarshaled to, if known.
+	Type reflect.Type
+	// Field is the path of the struct field, when unmarshaling a struct.
+	Field string
+	// Key is the key of the Value within the Source, when unmarshaling a
+	// struct.
+	Key string
+	// Origin is the Origin of the Value, when the Source is an OriginSource.
+	Origin Origin
+}
+
+// String returns a human-readable representation of Warning, e.g.
+// "key `db.port` from `config.env:3`: trimmed whitespace".
+func (w Warning) String() string {
+	var str string
+	if w.Key != "" {
+		str = "key `" + w.Key + "`"
+		if origin := w.Origin.String(); origin != "" {
+			str += " from `" + origin + "`"
+		}
+		str += ": "
+	} else if w.Type != nil {
+		str = "type `" + w.Type.String() + "`: "
+	}
+	return str + w.Kind.String()
+}
+
+// Warner receives Warning(s) about non-fatal issues. Set Options.Warner to
+// receive them.
+type Warner interface {
+	Warn(w Warning)
+}
+
+var _ Warner = (*Warnings)(nil)
+
+// Warnings is a Warner which collects all received Warning(s).
+type Warnings []Warning
+
+// Warn adds Warning w to Warnings.
+func (ws *Warnings) Warn(w Warning) { *ws = append(*ws, w) }
+
+// fieldWarner adds the details of a struct field to each Warning before
+// passing it to Warner.
+type fieldWarner struct {
+	Warner
+	field  string
+	key    string
+	origin Origin
+}
+
+func (fw fieldWarner) Warn(w Warning) {
+	w.Field, w.Key, w.Origin = fw.field, fw.key, fw.origin
+	fw.Warner.Warn(w)
+}
+
+func (o Options) warn(kind WarningKind, typ reflect.Type) {
+	if o.Warner != nil {
+		o.Warner.Warn(Warning{Kind: kind, Type: indirectType(typ)})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarnings(t *testing.T) {
+	intType := reflect.TypeOf(0)
+
+	t.Run("trimmed space", func(t *testing.T) {
+		var ws Warnings
+		var have []int
+		assert.NoError(t, Unmarshal("1, 2,3", &have, WithWarner(&ws)))
+		assert.Equal(t, []int{1, 2, 3}, have)
+		assert.Equal(t, Warnings{{Kind: WarnTrimmedSpace, Type: intType}}, ws)
+		assert.Equal(t, "type `int`: trimmed whitespace", ws[0].String())
+	})
+	t.Run("struct", func(t *testing.T) {
+		var have struct {
+			Ports []int `rawconv:"ports"`
+		}
+
+		var ws Warnings
+		u := NewUnmarshaler(WithWarner(&ws))
+		_, err := u.UnmarshalStruct(AnnotatedValues{
+			"ports": {Value: "80, 443", Origin: Origin{Source: "env"}},
+		}, &have)
+		assert.NoError(t, err)
+		assert.Equal(t, Warnings{{
+			Kind:   WarnTrimmedSpace,
+			Type:   intType,
+			Field:  "Ports",
+			Key:    "ports",
+			Origin: Origin{Source: "env"},
+		}}, ws)
+		assert.Equal(t, "key `ports` from `env`: trimmed whitespace", ws[0].String())
+	})
+	t.Run("none", func(t *testing.T) {
+		var ws Warnings
+		var have []int
+		assert.NoError(t, Unmarshal("1,2", &have, WithWarner(&ws)))
+		assert.Empty(t, ws)
+	})
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"encoding"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+)
+
+var (
+	_ encoding.TextMarshaler   = (*WeightedList)(nil)
+	_ encoding.TextUnmarshaler = (*WeightedList)(nil)
+)
+
+// WeightedItem is an item of a WeightedList.
+type WeightedItem struct {
+	Name   string
+	Weight float64
+}
+
+// WeightedList is a list of named items with a weight, e.g. for traffic
+// splitting or A/B testing. Its raw Value has the format "a=3,b=1,c=1". An
+// item without weight, e.g. "a" in "a,b=2", has a weight of 1. Separators and
+// backslashes within names are escaped with DefaultEscapeChar, e.g. "a\,b=1".
+type WeightedList []WeightedItem
+
+// ParseWeightedList parses str as a WeightedList. Names must be unique and
+// not empty, weights must be finite and not negative, and their total must be
+// greater than zero. An empty str results in an empty WeightedList.
+func ParseWeightedList(str string) (WeightedList, error) {
+	if str == "" {
+		return nil, nil
+	}
+
+	parts := splitEscaped(str, DefaultItemsSeparator, DefaultEscapeChar)
+	res := make(WeightedList, 0, len(parts))
+	seen := make(map[string]struct{}, len(parts))
+
+	var total float64
+	for _, part := range parts {
+		name, weight, found := cutEscaped(part, DefaultKeyValueSeparator, DefaultEscapeChar)
+		item := WeightedItem{
+			Name:   unescape(strings.TrimSpace(name), DefaultEscapeChar),
+			Weight: 1,
+		}
+		if item.Name == "" {
+			return nil, errors.Wrap(errors.Newf("missing name in `%s`", str), ErrParseFailure)
+		}
+		if _, ok := seen[item.Name]; ok {
+			return nil, errors.Wrap(errors.Newf("duplicate name `%s`", item.Name), ErrValidationFailure)
+		}
+		if found {
+			var err error
+			if item.Weight, err = floatSize(Value(strings.TrimSpace(weight)), 64); err != nil {
+				return nil, err
+			}
+			if item.Weight < 0 || math.IsNaN(item.Weight) || math.IsInf(item.Weight, 0) {
+				return nil, errors.Wrap(errors.Newf("invalid weight for `%s`", item.Name), ErrValidationFailure)
+			}
+		}
+
+		seen[item.Name] = struct{}{}
+		total += item.Weight
+		res = append(res, item)
+	}
+	if total <= 0 {
+		return nil, errors.Wrap(errors.Newf("total weight of `%s` is zero", str), ErrValidationFailure)
+	}
+	return res, nil
+}
+
+// Total returns the sum of all weights.
+func (wl WeightedList) Total() float64 {
+	var total float64
+	for _, item := range wl {
+		total += item.Weight
+	}
+	return total
+}
+
+// Normalize returns a copy of WeightedList with weights which sum up to 1.
+func (wl WeightedList) Normalize() WeightedList {
+	res := make(WeightedList, len(wl))
+	total := wl.Total()
+	for i, item := range wl {
+		res[i] = item
+		if total > 0 {
+			res[i].Weight = item.Weight / total
+		}
+	}
+	return res
+}
+
+// Weight returns the weight of the item with name, or 0 when it does not
+// exist.
+func (wl WeightedList) Weight(name string) float64 {
+	for _, item := range wl {
+		if item.Name == name {
+			return item.Weight
+		}
+	}
+	return 0
+}
+
+// Pick returns the name of the item which corresponds to r, a number in the
+// half-open interval [0,1), e.g. the result of rand.Float64. The chance of
+// each item to be picked is proportional to its weight.
+func (wl WeightedList) Pick(r float64) string {
+	target := r * wl.Total()
+	for _, item := range wl {
+		if target < item.Weight {
+			return item.Name
+		}
+		target -= item.Weight
+	}
+	// handle rounding errors and r >= 1
+	for i := len(wl) - 1; i >= 0; i-- {
+		if wl[i].Weight > 0 {
+			return wl[i].Name
+		}
+	}
+	return ""
+}
+
+// String returns WeightedList formatted as "a=3,b=1,c=1", with the separators
+// within names escaped.
+func (wl WeightedList) String() string {
+	var sb strings.Builder
+	for i, item := range wl {
+		if i > 0 {
+			sb.WriteString(DefaultItemsSeparator)
+		}
+		sb.WriteString(escapeSeparators(item.Name, DefaultEscapeChar,
+			DefaultKeyValueSeparator, DefaultItemsSeparator))
+		sb.WriteString(DefaultKeyValueSeparator)
+		sb.WriteString(strconv.FormatFloat(item.Weight, 'g', -1, 64))
+	}
+	return sb.String()
+}
+
+// MarshalText returns the result of String.
+func (wl WeightedList) MarshalText() ([]byte, error) { return []byte(wl.String()), nil }
+
+// UnmarshalText parses text using ParseWeightedList. An empty text results in
+// an empty WeightedList.
+func (wl *WeightedList) UnmarshalText(text []byte) error {
+	x, err := ParseWeightedList(string(text))
+	if err != nil {
+		return err
+	}
+	*wl = x
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWeightedList(t *testing.T) {
+	tests := map[string]struct {
+		want    WeightedList
+		wantStr string
+	}{
+		"a=3,b=1,c=1": {
+			want:    WeightedList{{"a", 3}, {"b", 1}, {"c", 1}},
+			wantStr: "a=3,b=1,c=1",
+		},
+		"a, b=0.5": {
+			want:    WeightedList{{"a", 1}, {"b", 0.5}},
+			wantStr: "a=1,b=0.5",
+		},
+		"a=0,b=2": {
+			want:    WeightedList{{"a", 0}, {"b", 2}},
+			wantStr: "a=0,b=2",
+		},
+		`a\,b=2,c\=d,e\\f`: {
+			want:    WeightedList{{"a,b", 2}, {"c=d", 1}, {`e\f`, 1}},
+			wantStr: `a\,b=2,c\=d=1,e\\f=1`,
+		},
+	}
+	for input, tc := range tests {
+		t.Run(input, func(t *testing.T) {
+			have, haveErr := ParseWeightedList(input)
+			assert.NoError(t, haveErr)
+			assert.Equal(t, tc.want, have)
+			assert.Equal(t, tc.wantStr, have.String())
+
+			var dest WeightedList
+			assert.NoError(t, Unmarshal(MustMarshal(have), &dest))
+			assert.Equal(t, have, dest)
+		})
+	}
+
+	errs := map[string]error{
+		"a=x":     ErrParseFailure,
+		"=1":      ErrParseFailure,
+		"a=1,a=2": ErrValidationFailure,
+		"a=-1":    ErrValidationFailure,
+		"a=Inf":   ErrValidationFailure,
+		"a=0,b=0": ErrValidationFailure,
+	}
+	for input, wantErr := range errs {
+		t.Run(input, func(t *testing.T) {
+			_, haveErr := ParseWeightedList(input)
+			assert.ErrorIs(t, haveErr, wantErr)
+		})
+	}
+}
+
+func TestWeightedList(t *testing.T) {
+	wl := WeightedList{{"a", 3}, {"b", 0}, {"c", 1}}
+	assert.Equal(t, 4.0, wl.Total())
+	assert.Equal(t, WeightedList{{"a", 0.75}, {"b", 0}, {"c", 0.25}}, wl.Normalize())
+	assert.Equal(t, 3.0, wl.Weight("a"))
+	assert.Equal(t, 0.0, wl.Weight("x"))
+
+	assert.Equal(t, "a", wl.Pick(0))
+	assert.Equal(t, "a", wl.Pick(0.74))
+	assert.Equal(t, "c", wl.Pick(0.75))
+	assert.Equal(t, "c", wl.Pick(1))
+	assert.Equal(t, "", WeightedList{}.Pick(0.5))
+}
+
+func TestWeightedList_zero(t *testing.T) {
+	have, haveErr := ParseWeightedList("")
+	assert.NoError(t, haveErr)
+	assert.Empty(t, have)
+	assert.Equal(t, Value(""), MustMarshal(WeightedList(nil)))
+
+	wl := WeightedList{{"a", 1}}
+	assert.NoError(t, wl.UnmarshalText(nil))
+	assert.Empty(t, wl)
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"strings"
+
+	"github.com/go-pogo/rawconv/internal/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const ErrUnsupportedYAMLNode errors.Msg = "unsupported yaml node"
+
+var (
+	_ yaml.Marshaler   = Value("")
+	_ yaml.Unmarshaler = (*Value)(nil)
+	_ yaml.Marshaler   = (Values)(nil)
+	_ yaml.Unmarshaler = (*Values)(nil)
+)
+
+// MarshalYAML returns Value as a string scalar yaml.Node. Its style is
+// determined by the yaml encoder, so a Value is written as plain scalar
+// whenever possible, and quoted when it would otherwise be read back as e.g.
+// null, a boolean or a number.
+func (v Value) MarshalYAML() (any, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v.String()}, nil
+}
+
+// UnmarshalYAML sets the string form of a scalar yaml.Node as Value, without
+// interpreting it. A null node results in an empty Value. The items of a
+// sequence of scalars are joined using DefaultItemsSeparator and the pairs of
+// a mapping of scalars are joined using DefaultKeyValueSeparator, so they can
+// be unmarshaled to a slice or map. Separators within the items are escaped
+// with DefaultEscapeChar, unmarshal such a Value with Options.EscapeSeparators
+// enabled to restore them.
+func (v *Value) UnmarshalYAML(node *yaml.Node) error {
+	str, err := yamlValue(node)
+	if err != nil {
+		return err
+	}
+	*v = Value(str)
+	return nil
+}
+
+func yamlValue(node *yaml.Node) (string, error) {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+
+	switch node.Kind {
+	case yaml.ScalarNode:
+		if node.ShortTag() == "!!null" {
+			return "", nil
+		}
+		return node.Value, nil
+
+	case yaml.SequenceNode:
+		items := make([]string, 0, len(node.Content))
+		for _, n := range node.Content {
+			if n.Kind != yaml.ScalarNode {
+				return "", yamlNodeError(n)
+			}
+			items = append(items, escapeSeparators(n.Value, DefaultEscapeChar, DefaultItemsSeparator))
+		}
+		return strings.Join(items, DefaultItemsSeparator), nil
+
+	case yaml.MappingNode:
+		items := make([]string, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			k, v := node.Content[i], node.Content[i+1]
+			if k.Kind != yaml.ScalarNode {
+				return "", yamlNodeError(k)
+			}
+			if v.Kind != yaml.ScalarNode {
+				return "", yamlNodeError(v)
+			}
+			items = append(items, escapeSeparators(k.Value, DefaultEscapeChar, DefaultKeyValueSeparator, DefaultItemsSeparator)+
+				DefaultKeyValueSeparator+
+				escapeSeparators(v.Value, DefaultEscapeChar, DefaultKeyValueSeparator, DefaultItemsSeparator))
+		}
+		return strings.Join(items, DefaultItemsSeparator), nil
+
+	default:
+		return "", yamlNodeError(node)
+	}
+}
+
+func yamlNodeError(node *yaml.Node) error {
+	return errors.Newf("%w at line %d, column %d", ErrUnsupportedYAMLNode, node.Line, node.Column)
+}
+
+// MarshalYAML returns Values as a yaml mapping node with its keys in sorted
+// order.
+func (vs Values) MarshalYAML() (any, error) {
+	node := &yaml.Node{
+		Kind:    yaml.MappingNode,
+		Content: make([]*yaml.Node, 0, len(vs)*2),
+	}
+	for _, k := range vs.Keys() {
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Value: k},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: vs[k].String()},
+		)
+	}
+	return node, nil
+}
+
+// UnmarshalYAML sets the entries of a yaml mapping node as Values. Nested
+// mappings are flattened by joining their keys with StructKeySeparator, e.g.
+// "db.host", which matches the keys of nested struct fields used by
+// UnmarshalStruct. Other nodes are unmarshaled like Value.UnmarshalYAML does.
+func (vs *Values) UnmarshalYAML(node *yaml.Node) error {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind != yaml.MappingNode {
+		return yamlNodeError(node)
+	}
+
+	res := make(Values, len(node.Content)/2)
+	if err := res.appendYAML("", node); err != nil {
+		return err
+	}
+	*vs = res
+	return nil
+}
+
+func (vs Values) appendYAML(prefix string, node *yaml.Node) error {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+		if k.Kind != yaml.ScalarNode {
+			return yamlNodeError(k)
+		}
+		for v.Kind == yaml.AliasNode {
+			v = v.Alias
+		}
+
+		key := prefix + k.Value
+		if v.Kind == yaml.MappingNode {
+			if err := vs.appendYAML(key+StructKeySeparator, v); err != nil {
+				return err
+			}
+			continue
+		}
+
+		str, err := yamlValue(v)
+		if err != nil {
+			return err
+		}
+		vs[key] = Value(str)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2024, Roel Schut. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rawconv
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestValue_UnmarshalYAML(t *testing.T) {
+	tests := map[string]Value{
+		"v: 0x0A":            "0x0A",
+		"v: 1h30m":           "1h30m",
+		"v: 'quoted: text'":  "quoted: text",
+		"v: 1.50":            "1.50",
+		"v: ~":               "",
+		"v: null":            "",
+		"v: [a, b, c]":       "a,b,c",
+		"v: {a: 1, b: 2}":    "a=1,b=2",
+		"v: ['x,y', z]":      `x\,y,z`,
+		"v: {'a=b': 'x,y'}":  `a\=b=x\,y`,
+		"a: &x foo\nv: *x\n": "foo",
+	}
+	for input, want := range tests {
+		t.Run(input, func(t *testing.T) {
+			var have struct{ V Value }
+			assert.NoError(t, yaml.Unmarshal([]byte(input), &have))
+			assert.Equal(t, want, have.V)
+		})
+	}
+
+	t.Run("nested", func(t *testing.T) {
+		var have struct{ V Value }
+		assert.ErrorIs(t, yaml.Unmarshal([]byte("v: [[a], b]"), &have), ErrUnsupportedYAMLNode)
+	})
+}
+
+func TestValue_MarshalYAML(t *testing.T) {
+	tests := map[Value]string{
+		"foo":          "v: foo\n",
+		"0x0A":         "v: \"0x0A\"\n",
+		"true":         "v: \"true\"\n",
+		"null":         "v: \"null\"\n",
+		"~":            "v: \"~\"\n",
+		"quoted: text": "v: 'quoted: text'\n",
+		"":             "v: \"\"\n",
+	}
+	for input, want := range tests {
+		t.Run(input.String(), func(t *testing.T) {
+			have, err := yaml.Marshal(struct{ V Value }{V: input})
+			assert.NoError(t, err)
+			assert.Equal(t, want, string(have))
+
+			var dest struct{ V Value }
+			assert.NoError(t, yaml.Unmarshal(have, &dest))
+			assert.Equal(t, input, dest.V)
+		})
+	}
+}
+
+func TestValues_YAML(t *testing.T) {
+	const input = `
+name: app
+timeout: 5s
+db:
+  host: localhost
+  port: 5432
+hosts: [a, b]
+`
+
+	var have Values
+	assert.NoError(t, yaml.Unmarshal([]byte(input), &have))
+	assert.Equal(t, Values{
+		"name":    "app",
+		"timeout": "5s",
+		"db.host": "localhost",
+		"db.port": "5432",
+		"hosts":   "a,b",
+	}, have)
+
+	out, err := yaml.Marshal(have)
+	assert.NoError(t, err)
+	assert.Equal(t, "db.host: localhost\ndb.port: \"5432\"\nhosts: a,b\nname: app\ntimeout: 5s\n", string(out))
+
+	t.Run("struct", func(t *testing.T) {
+		type config struct {
+			Name    string        `rawconv:"name"`
+			Timeout time.Duration `rawconv:"timeout"`
+			Hosts   []string      `rawconv:"hosts"`
+			Db      struct {
+				Host string `rawconv:"host"`
+				Port int    `rawconv:"port"`
+			} `rawconv:"db"`
+		}
+
+		var cfg config
+		_, err := UnmarshalStruct(NewLayered(
+			Layer{Name: "config.yaml", Source: have},
+			Layer{Name: "env", Source: Values{"db.port": "6543"}},
+		), &cfg)
+		assert.NoError(t, err)
+		assert.Equal(t, "app", cfg.Name)
+		assert.Equal(t, 5*time.Second, cfg.Timeout)
+		assert.Equal(t, []string{"a", "b"}, cfg.Hosts)
+		assert.Equal(t, "localhost", cfg.Db.Host)
+		assert.Equal(t, 6543, cfg.Db.Port)
+	})
+	t.Run("escaped items", func(t *testing.T) {
+		var have struct{ List, Map Value }
+		assert.NoError(t, yaml.Unmarshal([]byte("list: ['x,y', z]\nmap: {'a=b': 'x,y'}"), &have))
+
+		var list []string
+		assert.NoError(t, Unmarshal(have.List, &list, WithEscapeSeparators(DefaultEscapeChar)))
+		assert.Equal(t, []string{"x,y", "z"}, list)
+
+		var m map[string]string
+		assert.NoError(t, Unmarshal(have.Map, &m, WithEscapeSeparators(DefaultEscapeChar)))
+		assert.Equal(t, map[string]string{"a=b": "x,y"}, m)
+	})
+	t.Run("not a mapping", func(t *testing.T) {
+		var have Values
+		assert.ErrorIs(t, yaml.Unmarshal([]byte("[a, b]"), &have), ErrUnsupportedYAMLNode)
+	})
+}